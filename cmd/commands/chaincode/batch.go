@@ -0,0 +1,379 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"google.golang.org/grpc/codes"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+)
+
+// batchResultsSuffix is appended to the batch file to derive the default results file
+const batchResultsSuffix = ".results.jsonl"
+
+// batchEntry is a single transaction of a batch file
+type batchEntry struct {
+	Fcn       string            `json:"fcn"`
+	Args      []string          `json:"args"`
+	Transient map[string]string `json:"transient,omitempty"`
+}
+
+// batchLine is an entry of a batch file together with its line number
+type batchLine struct {
+	line  int
+	entry *batchEntry
+	err   error
+}
+
+// batchResult is the outcome of a single transaction of a batch
+type batchResult struct {
+	Line     int    `json:"line"`
+	TxID     string `json:"tx_id,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Attempts int    `json:"attempts"`
+}
+
+// runBatch invokes the transactions of the batch file and writes the result of each to the results file
+func (c *InvokeCommand) runBatch() error {
+	lines, err := readBatchFile(c.Batch)
+	if err != nil {
+		return err
+	}
+
+	resultsPath := c.BatchResults
+	if resultsPath == "" {
+		resultsPath = c.Batch + batchResultsSuffix
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+
+	committed := make(map[int]bool)
+	if c.Resume {
+		committed, err = readCommittedLines(resultsPath)
+		if err != nil {
+			return err
+		}
+
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	resultsFile, err := os.OpenFile(resultsPath, flags, 0600)
+	if err != nil {
+		return err
+	}
+	defer resultsFile.Close()
+
+	pending := make(chan *batchLine)
+	results := make(chan *batchResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for l := range pending {
+				results <- c.invokeBatchLine(l)
+			}
+		}()
+	}
+
+	skipped := 0
+
+	go func() {
+		var interval time.Duration
+		if c.Rate > 0 {
+			interval = time.Duration(float64(time.Second) / c.Rate)
+		}
+
+		start := time.Now()
+		sent := 0
+
+		for _, l := range lines {
+			if committed[l.line] {
+				skipped++
+				continue
+			}
+
+			if interval > 0 {
+				if wait := time.Until(start.Add(time.Duration(sent) * interval)); wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+
+			pending <- l
+			sent++
+		}
+
+		close(pending)
+		wg.Wait()
+		close(results)
+	}()
+
+	encoder := json.NewEncoder(resultsFile)
+	succeeded, failed := 0, 0
+
+	for result := range results {
+		if result.Error == "" {
+			succeeded++
+		} else {
+			failed++
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "Batch complete: %d committed, %d failed, %d skipped\n", succeeded, failed, skipped)
+	fmt.Fprintf(c.Settings.Streams.Out, "Results written to %s\n", resultsPath)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d transactions failed", failed, succeeded+failed)
+	}
+
+	return nil
+}
+
+// invokeBatchLine invokes a single transaction, retrying transient failures with an exponential backoff
+func (c *InvokeCommand) invokeBatchLine(l *batchLine) *batchResult {
+	result := &batchResult{Line: l.line}
+
+	if l.err != nil {
+		result.Status = "INVALID_INPUT"
+		result.Error = l.err.Error()
+		return result
+	}
+
+	req, err := c.batchRequest(l.entry)
+	if err != nil {
+		result.Status = "INVALID_INPUT"
+		result.Error = err.Error()
+		return result
+	}
+
+	backoff := c.RetryBackoff
+
+	for {
+		result.Attempts++
+
+		resp, err := c.Channel.Execute(req)
+
+		result.TxID = string(resp.TransactionID)
+		result.Status = resultCode(false, err)
+		result.Error = ""
+
+		if err == nil {
+			return result
+		}
+
+		result.Error = err.Error()
+
+		if result.Attempts > c.MaxRetries || !retryable(err) {
+			return result
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// retryable returns true for errors which are transient and occur before the transaction is
+// sent to the orderer: unavailable peers, mismatching endorsements and read conflicts during
+// validation. Failures of the orderer and commit timeouts are not retried, as the transaction
+// may still be committed.
+func retryable(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	switch s.Group {
+	case status.EndorserClientStatus:
+		return s.Code == status.ConnectionFailed.ToInt32() || s.Code == status.EndorsementMismatch.ToInt32()
+	case status.ClientStatus:
+		return s.Code == status.EndorsementMismatch.ToInt32()
+	case status.GRPCTransportStatus:
+		return s.Code == int32(codes.Unavailable)
+	case status.EventServerStatus:
+		return s.Code == int32(pb.TxValidationCode_MVCC_READ_CONFLICT) ||
+			s.Code == int32(pb.TxValidationCode_PHANTOM_READ_CONFLICT)
+	}
+
+	return false
+}
+
+func (c *InvokeCommand) batchRequest(entry *batchEntry) (channel.Request, error) {
+	args, err := common.GetByteArgs(entry.Args, c.ArgEncoding)
+	if err != nil {
+		return channel.Request{}, err
+	}
+
+	var transientMap map[string][]byte
+	if len(entry.Transient) > 0 {
+		transientMap, err = common.DecodeTransientMap(entry.Transient, c.TransientEncoding)
+		if err != nil {
+			return channel.Request{}, err
+		}
+	}
+
+	return channel.Request{
+		ChaincodeID:  c.ChaincodeName,
+		Fcn:          entry.Fcn,
+		Args:         args,
+		TransientMap: transientMap,
+	}, nil
+}
+
+// readBatchFile reads a JSONL batch file, or a CSV batch file where each row contains the
+// function followed by the arguments
+func readBatchFile(path string) ([]*batchLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %s", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readBatchCSV(f)
+	}
+
+	return readBatchJSONL(f)
+}
+
+func readBatchJSONL(r io.Reader) ([]*batchLine, error) {
+	var lines []*batchLine
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		l := &batchLine{line: n, entry: &batchEntry{}}
+		if err := json.Unmarshal([]byte(text), l.entry); err != nil {
+			l.err = fmt.Errorf("invalid JSON: %s", err)
+		}
+
+		lines = append(lines, l)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %s", err)
+	}
+
+	return lines, nil
+}
+
+func readBatchCSV(r io.Reader) ([]*batchLine, error) {
+	reader := bufio.NewReader(r)
+
+	var (
+		lines  []*batchLine
+		record strings.Builder
+		start  int
+		quotes int
+	)
+
+	for n := 1; ; n++ {
+		text, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read batch file: %s", err)
+		}
+
+		// blank lines between records are skipped like the csv reader does
+		if record.Len() == 0 && strings.TrimRight(text, "\r\n") != "" {
+			start = n
+		}
+
+		if start != 0 {
+			record.WriteString(text)
+			quotes += strings.Count(text, `"`)
+		}
+
+		// a record ends at the first line break outside of a quoted field
+		if start != 0 && (quotes%2 == 0 || err == io.EOF) {
+			l, perr := parseBatchCSVRecord(record.String(), start)
+			if perr != nil {
+				return nil, fmt.Errorf("failed to read batch file: %s", perr)
+			}
+
+			lines = append(lines, l)
+
+			record.Reset()
+			start, quotes = 0, 0
+		}
+
+		if err == io.EOF {
+			return lines, nil
+		}
+	}
+}
+
+// parseBatchCSVRecord parses a single CSV record which starts at the given line of the batch file
+func parseBatchCSVRecord(text string, line int) (*batchLine, error) {
+	record, err := csv.NewReader(strings.NewReader(text)).Read()
+	if err != nil {
+		if perr, ok := err.(*csv.ParseError); ok {
+			perr.StartLine += line - 1
+			perr.Line += line - 1
+		}
+
+		return nil, err
+	}
+
+	return &batchLine{
+		line:  line,
+		entry: &batchEntry{Fcn: record[0], Args: record[1:]},
+	}, nil
+}
+
+// readCommittedLines returns the lines of an existing results file which were committed
+func readCommittedLines(path string) (map[int]bool, error) {
+	committed := make(map[int]bool)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return committed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var result batchResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			continue
+		}
+
+		if result.Status == pb.TxValidationCode_VALID.String() {
+			committed[result.Line] = true
+		}
+	}
+
+	return committed, scanner.Err()
+}
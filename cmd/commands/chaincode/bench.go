@@ -0,0 +1,477 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// Argument templates replaced for each benchmark request
+const (
+	benchSeqTemplate    = "{{seq}}"
+	benchWorkerTemplate = "{{worker}}"
+	benchRandTemplate   = "{{rand}}"
+
+	benchResultOK = "OK"
+	csvFormat     = "csv"
+)
+
+// NewChaincodeBenchCommand creates a new "fabric chaincode bench" command
+func NewChaincodeBenchCommand(settings *environment.Settings) *cobra.Command {
+	c := BenchCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "bench <chaincode-name>",
+		Short: "Benchmark a chaincode",
+		Long: "Benchmark a chaincode by sending invoke or query requests and report throughput and latency.\n" +
+			"The arguments may contain the templates {{seq}} (request number), {{worker}} (worker number)\n" +
+			"and {{rand}} (random key).",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodeName)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.ChaincodeFcn, "fcn", "", "set the function")
+	flags.StringArrayVar(&c.ChaincodeArgs, "args", []string{}, "set the arguments, which may contain templates")
+	flags.StringVar(&c.ArgEncoding, "arg-encoding", common.ArgEncodingUTF8, common.ArgEncodingUsage)
+	flags.BoolVar(&c.Query, "query", false, "send query requests instead of invoke requests")
+	flags.IntVar(&c.Concurrency, "concurrency", 1, "set the number of concurrent requests")
+	flags.Float64Var(&c.TPS, "tps", 0, "set the target number of requests per second (0 for no limit)")
+	flags.DurationVar(&c.Duration, "duration", 0, "set the duration of the benchmark")
+	flags.IntVar(&c.Requests, "requests", 0, "set the number of requests to send")
+	flags.StringVar(&c.OutputFormat, "output", "", "set the output format of the summary, 'json', 'csv' or human-readable text if not set")
+	flags.StringVar(&c.ResultsFile, "results", "", "set the path of a CSV file to write the result of every request to")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// BenchCommand implements the chaincode bench command
+type BenchCommand struct {
+	BaseCommand
+
+	ChaincodeName string
+	ChaincodeFcn  string
+	ChaincodeArgs []string
+	ArgEncoding   string
+	Query         bool
+
+	Concurrency int
+	TPS         float64
+	Duration    time.Duration
+	Requests    int
+
+	OutputFormat string
+	ResultsFile  string
+}
+
+// benchRequest is a single request of a benchmark
+type benchRequest struct {
+	seq    int
+	worker int
+	start  time.Time
+
+	latency time.Duration
+	result  string
+	err     error
+}
+
+// benchLatency holds latency statistics in milliseconds
+type benchLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// benchSummary is the outcome of a benchmark
+type benchSummary struct {
+	Requests   int            `json:"requests"`
+	Errors     int            `json:"errors"`
+	Duration   float64        `json:"duration_ms"`
+	Throughput float64        `json:"throughput"`
+	Latency    benchLatency   `json:"latency_ms"`
+	Results    map[string]int `json:"results"`
+}
+
+// Validate checks the required parameters for run
+func (c *BenchCommand) Validate() error {
+	if len(c.ChaincodeName) == 0 {
+		return errors.New("chaincode name not specified")
+	}
+
+	if c.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+
+	if c.TPS < 0 {
+		return errors.New("tps must not be negative")
+	}
+
+	if c.Duration <= 0 && c.Requests <= 0 {
+		return errors.New("either duration or number of requests must be specified")
+	}
+
+	switch c.OutputFormat {
+	case "", jsonFormat, csvFormat:
+	default:
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *BenchCommand) Run() error {
+	// decode the arguments once to fail early on invalid arguments
+	if _, err := common.GetByteArgs(c.ChaincodeArgs, c.ArgEncoding); err != nil {
+		return err
+	}
+
+	requests := make(chan *benchRequest)
+	done := make(chan *benchRequest)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for r := range requests {
+				r.worker = worker
+				c.send(r)
+				done <- r
+			}
+		}(i)
+	}
+
+	start := time.Now()
+
+	go func() {
+		c.produce(requests, start)
+		close(requests)
+		wg.Wait()
+		close(done)
+	}()
+
+	var results []*benchRequest
+	for r := range done {
+		results = append(results, r)
+	}
+
+	elapsed := time.Since(start)
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].seq < results[j].seq
+	})
+
+	if c.ResultsFile != "" {
+		if err := writeBenchResults(c.ResultsFile, results); err != nil {
+			return err
+		}
+	}
+
+	return c.print(summarize(results, elapsed))
+}
+
+// produce sends requests until the number of requests or the duration is reached,
+// pacing them to the target TPS
+func (c *BenchCommand) produce(requests chan<- *benchRequest, start time.Time) {
+	var interval time.Duration
+	if c.TPS > 0 {
+		interval = time.Duration(float64(time.Second) / c.TPS)
+	}
+
+	for seq := 0; c.Requests <= 0 || seq < c.Requests; seq++ {
+		if c.Duration > 0 && time.Since(start) >= c.Duration {
+			return
+		}
+
+		if interval > 0 {
+			if wait := time.Until(start.Add(time.Duration(seq) * interval)); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+
+		requests <- &benchRequest{seq: seq}
+	}
+}
+
+// send executes a single request
+func (c *BenchCommand) send(r *benchRequest) {
+	args := make([]string, len(c.ChaincodeArgs))
+	for i, arg := range c.ChaincodeArgs {
+		expanded, err := expandBenchTemplate(arg, r)
+		if err != nil {
+			r.result, r.err = "ERROR", err
+			return
+		}
+
+		args[i] = expanded
+	}
+
+	byteArgs, err := common.GetByteArgs(args, c.ArgEncoding)
+	if err != nil {
+		r.result, r.err = "ERROR", err
+		return
+	}
+
+	req := channel.Request{
+		ChaincodeID: c.ChaincodeName,
+		Fcn:         c.ChaincodeFcn,
+		Args:        byteArgs,
+	}
+
+	r.start = time.Now()
+
+	if c.Query {
+		_, err = c.Channel.Query(req)
+	} else {
+		_, err = c.Channel.Execute(req)
+	}
+
+	r.latency = time.Since(r.start)
+	r.err = err
+	r.result = resultCode(c.Query, err)
+}
+
+func expandBenchTemplate(arg string, r *benchRequest) (string, error) {
+	arg = strings.Replace(arg, benchSeqTemplate, strconv.Itoa(r.seq), -1)
+	arg = strings.Replace(arg, benchWorkerTemplate, strconv.Itoa(r.worker), -1)
+
+	for strings.Contains(arg, benchRandTemplate) {
+		key := make([]byte, 8)
+		if _, err := rand.Read(key); err != nil {
+			return "", fmt.Errorf("failed to generate random key: %s", err)
+		}
+
+		arg = strings.Replace(arg, benchRandTemplate, hex.EncodeToString(key), 1)
+	}
+
+	return arg, nil
+}
+
+// resultCode classifies the outcome of a request by its validation code or error status
+func resultCode(query bool, err error) string {
+	if err == nil {
+		if query {
+			return benchResultOK
+		}
+
+		return pb.TxValidationCode_VALID.String()
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return "ERROR"
+	}
+
+	if s.Group == status.EventServerStatus {
+		return pb.TxValidationCode(s.Code).String()
+	}
+
+	return fmt.Sprintf("%s(%d)", s.Group, s.Code)
+}
+
+func summarize(results []*benchRequest, elapsed time.Duration) *benchSummary {
+	summary := &benchSummary{
+		Requests: len(results),
+		Duration: milliseconds(elapsed),
+		Results:  make(map[string]int),
+	}
+
+	if elapsed > 0 {
+		summary.Throughput = float64(len(results)) / elapsed.Seconds()
+	}
+
+	if len(results) == 0 {
+		return summary
+	}
+
+	latencies := make([]time.Duration, len(results))
+	var total time.Duration
+
+	for i, r := range results {
+		latencies[i] = r.latency
+		total += r.latency
+
+		summary.Results[r.result]++
+		if r.err != nil {
+			summary.Errors++
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	summary.Latency = benchLatency{
+		Min:  milliseconds(latencies[0]),
+		Mean: milliseconds(total / time.Duration(len(latencies))),
+		P50:  milliseconds(percentile(latencies, 50)),
+		P95:  milliseconds(percentile(latencies, 95)),
+		P99:  milliseconds(percentile(latencies, 99)),
+		Max:  milliseconds(latencies[len(latencies)-1]),
+	}
+
+	return summary
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func (c *BenchCommand) print(summary *benchSummary) error {
+	out := c.Settings.Streams.Out
+
+	codes := make([]string, 0, len(summary.Results))
+	for code := range summary.Results {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	switch c.OutputFormat {
+	case jsonFormat:
+		return json.NewEncoder(out).Encode(summary)
+	case csvFormat:
+		w := csv.NewWriter(out)
+		header := []string{"requests", "errors", "duration_ms", "throughput", "min_ms", "mean_ms", "p50_ms", "p95_ms", "p99_ms", "max_ms"}
+		row := []string{
+			strconv.Itoa(summary.Requests),
+			strconv.Itoa(summary.Errors),
+			formatFloat(summary.Duration),
+			formatFloat(summary.Throughput),
+			formatFloat(summary.Latency.Min),
+			formatFloat(summary.Latency.Mean),
+			formatFloat(summary.Latency.P50),
+			formatFloat(summary.Latency.P95),
+			formatFloat(summary.Latency.P99),
+			formatFloat(summary.Latency.Max),
+		}
+		for _, code := range codes {
+			header = append(header, code)
+			row = append(row, strconv.Itoa(summary.Results[code]))
+		}
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	fmt.Fprintf(out, "Requests: %d (%d errors)\n", summary.Requests, summary.Errors)
+	fmt.Fprintf(out, "Duration: %.3fms\n", summary.Duration)
+	fmt.Fprintf(out, "Throughput: %.2f tx/s\n", summary.Throughput)
+	fmt.Fprintf(out, "Latency: min %.3fms, mean %.3fms, p50 %.3fms, p95 %.3fms, p99 %.3fms, max %.3fms\n",
+		summary.Latency.Min, summary.Latency.Mean, summary.Latency.P50, summary.Latency.P95,
+		summary.Latency.P99, summary.Latency.Max)
+	fmt.Fprintln(out, "Results:")
+
+	for _, code := range codes {
+		fmt.Fprintf(out, "  %s: %d\n", code, summary.Results[code])
+	}
+
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 3, 64)
+}
+
+// writeBenchResults writes the result of every request as CSV
+func writeBenchResults(path string, results []*benchRequest) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"seq", "worker", "start", "latency_ms", "result", "error"}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		errMsg := ""
+		if r.err != nil {
+			errMsg = r.err.Error()
+		}
+
+		err := w.Write([]string{
+			strconv.Itoa(r.seq),
+			strconv.Itoa(r.worker),
+			r.start.UTC().Format(time.RFC3339Nano),
+			formatFloat(milliseconds(r.latency)),
+			r.result,
+			errMsg,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
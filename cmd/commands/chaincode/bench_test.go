@@ -0,0 +1,273 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"bytes"
+	"errors"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("ChaincodeBenchCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = chaincode.NewChaincodeBenchCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a chaincode bench command", func() {
+		Expect(cmd.Name()).To(Equal("bench"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("bench <chaincode-name>"))
+	})
+})
+
+var _ = Describe("ChaincodeBenchImplementation", func() {
+	var (
+		impl     *chaincode.BenchCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		client   *mocks.Channel
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		client = &mocks.Channel{}
+
+		impl = &chaincode.BenchCommand{}
+		impl.Settings = settings
+		impl.Channel = client
+		impl.ChaincodeName = "mycc"
+		impl.Concurrency = 1
+		impl.Requests = 10
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when neither duration nor requests are set", func() {
+			BeforeEach(func() {
+				impl.Requests = 0
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("either duration or number of requests must be specified"))
+			})
+		})
+
+		Context("when concurrency is invalid", func() {
+			BeforeEach(func() {
+				impl.Concurrency = 0
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("concurrency must be at least 1"))
+			})
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "yaml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'yaml'"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			impl.ChaincodeFcn = "put"
+			impl.ChaincodeArgs = []string{"key{{seq}}", "{{rand}}"}
+			impl.Concurrency = 4
+
+			client.ExecuteStub = func(req channel.Request, _ ...channel.RequestOption) (channel.Response, error) {
+				if string(req.Args[0]) == "key3" {
+					return channel.Response{}, status.New(status.EventServerStatus,
+						int32(pb.TxValidationCode_MVCC_READ_CONFLICT), "received invalid transaction", nil)
+				}
+
+				return channel.Response{}, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should send the requests with expanded arguments", func() {
+			Expect(err).To(BeNil())
+			Expect(client.ExecuteCallCount()).To(Equal(10))
+
+			keys := make(map[string]bool)
+			for i := 0; i < 10; i++ {
+				req, _ := client.ExecuteArgsForCall(i)
+				Expect(req.Fcn).To(Equal("put"))
+				Expect(string(req.Args[1])).To(HaveLen(16))
+				keys[string(req.Args[0])] = true
+			}
+			Expect(keys).To(HaveLen(10))
+			Expect(keys).To(HaveKey("key9"))
+
+			Expect(fmt.Sprint(out)).To(ContainSubstring("Requests: 10 (1 errors)"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("  MVCC_READ_CONFLICT: 1\n  VALID: 9\n"))
+		})
+
+		Context("when sending queries with JSON output", func() {
+			BeforeEach(func() {
+				impl.Query = true
+				impl.OutputFormat = "json"
+			})
+
+			It("should report the summary as JSON", func() {
+				Expect(err).To(BeNil())
+				Expect(client.QueryCallCount()).To(Equal(10))
+				Expect(client.ExecuteCallCount()).To(Equal(0))
+
+				var summary map[string]interface{}
+				Expect(json.Unmarshal(out.Bytes(), &summary)).To(Succeed())
+				Expect(summary["requests"]).To(BeEquivalentTo(10))
+				Expect(summary["results"]).To(Equal(map[string]interface{}{"OK": float64(10)}))
+				Expect(summary["latency_ms"]).To(HaveKey("p99"))
+			})
+		})
+
+		Context("when the output format is csv and a results file is set", func() {
+			var dir string
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "bench")
+				Expect(err).To(BeNil())
+
+				impl.OutputFormat = "csv"
+				impl.ResultsFile = filepath.Join(dir, "results.csv")
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should write the summary and the results as CSV", func() {
+				Expect(err).To(BeNil())
+
+				lines := strings.Split(strings.TrimSpace(fmt.Sprint(out)), "\n")
+				Expect(lines).To(HaveLen(2))
+				Expect(lines[0]).To(HavePrefix("requests,errors,duration_ms,throughput,"))
+				Expect(lines[0]).To(HaveSuffix(",MVCC_READ_CONFLICT,VALID"))
+				Expect(lines[1]).To(HavePrefix("10,1,"))
+				Expect(lines[1]).To(HaveSuffix(",1,9"))
+
+				data, err := ioutil.ReadFile(impl.ResultsFile)
+				Expect(err).To(BeNil())
+
+				results := strings.Split(strings.TrimSpace(string(data)), "\n")
+				Expect(results).To(HaveLen(11))
+				Expect(results[0]).To(Equal("seq,worker,start,latency_ms,result,error"))
+				Expect(results[4]).To(HavePrefix("3,"))
+				Expect(results[4]).To(ContainSubstring("MVCC_READ_CONFLICT"))
+			})
+		})
+
+		Context("when the CSV summary can't be written", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "csv"
+				settings.Streams.Out = failingWriter{}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("write error"))
+			})
+		})
+
+		Context("when a duration and a target TPS are set", func() {
+			BeforeEach(func() {
+				impl.Requests = 0
+				impl.Duration = 200 * time.Millisecond
+				impl.TPS = 20
+			})
+
+			It("should pace the requests", func() {
+				Expect(err).To(BeNil())
+				Expect(client.ExecuteCallCount()).To(BeNumerically(">=", 3))
+				Expect(client.ExecuteCallCount()).To(BeNumerically("<=", 5))
+			})
+		})
+	})
+})
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write error")
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"io"
+
 	"github.com/spf13/cobra"
 
 	"github.com/hyperledger/fabric-cli/cmd/common"
@@ -14,12 +16,17 @@ import (
 	"github.com/hyperledger/fabric-cli/pkg/fabric"
 )
 
+const (
+	jsonFormat = "json"
+	yamlFormat = "yaml"
+)
+
 // NewChaincodeCommand creates a new "fabric chaincode" command
 func NewChaincodeCommand(settings *environment.Settings) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "chaincode",
 		Short: "Manage chaincode",
-		Long:  "Manage chaincode with events|install|instantiate|invoke|list|package|query|upgrade",
+		Long:  "Manage chaincode with bench|codegen|collections|describe|dev|events|install|instantiate|invoke|list|package|query|upgrade",
 	}
 
 	cmd.AddCommand(
@@ -31,6 +38,11 @@ func NewChaincodeCommand(settings *environment.Settings) *cobra.Command {
 		NewChaincodeQueryCommand(settings),
 		NewChaincodeInvokeCommand(settings),
 		NewChaincodeEventsCommand(settings),
+		NewChaincodeBenchCommand(settings),
+		NewChaincodeDescribeCommand(settings),
+		NewChaincodeCodegenCommand(settings),
+		NewChaincodeDevCommand(settings),
+		NewChaincodeCollectionsCommand(settings),
 	)
 
 	cmd.SetOutput(settings.Streams.Out)
@@ -70,3 +82,12 @@ func (c *BaseCommand) Complete() error {
 
 	return nil
 }
+
+// Close releases the connection held by the channel client, which is only the case for the gateway
+func (c *BaseCommand) Close() error {
+	if closer, ok := c.Channel.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -62,6 +62,11 @@ var _ = Describe("ChaincodeCommand", func() {
 			Expect(fmt.Sprint(out)).To(ContainSubstring("query"))
 			Expect(fmt.Sprint(out)).To(ContainSubstring("invoke"))
 			Expect(fmt.Sprint(out)).To(ContainSubstring("events"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("bench"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("describe"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("codegen"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("dev"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("collections"))
 		})
 	})
 })
@@ -0,0 +1,412 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+const (
+	langGo = "go"
+
+	// codegenFile is the name of the file written to the output directory
+	codegenFile = "client.go"
+)
+
+// NewChaincodeCodegenCommand creates a new "fabric chaincode codegen" command
+func NewChaincodeCodegenCommand(settings *environment.Settings) *cobra.Command {
+	c := CodegenCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "codegen <chaincode-name>",
+		Short: "Generate a typed client of a chaincode",
+		Long: "Generate a typed client package from the contract metadata of a chaincode written with the contract API.\n" +
+			"The client has one method per transaction and is created from a fabric.Channel.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodeName)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.Lang, "lang", langGo, "set the language of the generated client (only go is supported)")
+	flags.StringVar(&c.Out, "out", "", "set the output directory of the generated client")
+	flags.StringVar(&c.Package, "package", "", "set the package name of the generated client (defaults to the name of the output directory)")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// CodegenCommand implements the chaincode codegen command
+type CodegenCommand struct {
+	BaseCommand
+
+	ChaincodeName string
+	Lang          string
+	Out           string
+	Package       string
+}
+
+// Validate checks the required parameters for run
+func (c *CodegenCommand) Validate() error {
+	if len(c.ChaincodeName) == 0 {
+		return errors.New("chaincode name not specified")
+	}
+
+	if c.Lang != langGo {
+		return fmt.Errorf("unsupported language '%s'", c.Lang)
+	}
+
+	if len(c.Out) == 0 {
+		return errors.New("output directory not specified")
+	}
+
+	if c.Package != "" && !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("invalid package name '%s'", c.Package)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *CodegenCommand) Run() error {
+	_, metadata, err := queryMetadata(c.Channel, c.ChaincodeName)
+	if err != nil {
+		return err
+	}
+
+	pkg := c.Package
+	if pkg == "" {
+		pkg = packageName(c.Out)
+	}
+
+	src, err := generateGoClient(metadata, c.ChaincodeName, pkg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.Out, 0755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.Out, codegenFile)
+	if err := ioutil.WriteFile(path, src, 0644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "Generated client of chaincode '%s' in %s\n", c.ChaincodeName, path)
+
+	return nil
+}
+
+// goClient is the model of a generated Go client
+type goClient struct {
+	Package   string
+	Chaincode string
+	Types     []*goType
+	Methods   []*goMethod
+}
+
+// goType is a named type, either a struct with fields or a definition based on another type
+type goType struct {
+	Name   string
+	Doc    string
+	Fields []*goField
+	Base   string
+}
+
+type goField struct {
+	Name string
+	Type string
+	JSON string
+}
+
+// goMethod invokes a transaction with the fields of its parameter struct as arguments
+type goMethod struct {
+	Name       string
+	Fcn        string
+	Submit     bool
+	Params     *goType
+	Returns    string
+	ReturnsRaw bool
+}
+
+// generateGoClient generates the formatted source of a Go client for the given contract metadata
+func generateGoClient(metadata *contractMetadata, chaincode string, pkg string) ([]byte, error) {
+	client := &goClient{Package: pkg, Chaincode: chaincode}
+
+	for _, name := range sortedSchemaNames(metadata.Components.Schemas) {
+		client.Types = append(client.Types, newGoType(exportedName(name), "is the "+name+" schema", metadata.Components.Schemas[name]))
+	}
+
+	for _, contractName := range metadata.contractNames() {
+		c := metadata.Contracts[contractName]
+
+		for _, tx := range c.Transactions {
+			name := exportedName(tx.Name)
+			if !c.Default {
+				name = exportedName(c.Name) + name
+			}
+
+			method := &goMethod{
+				Name:   name,
+				Fcn:    metadata.functionName(c, tx),
+				Submit: !containsString(tx.Tag, "evaluate"),
+			}
+
+			if len(tx.Parameters) > 0 {
+				params := &goType{Name: name + "Params", Doc: "are the arguments of " + name}
+				for _, p := range tx.Parameters {
+					params.Fields = append(params.Fields, &goField{Name: exportedName(p.Name), Type: goTypeName(p.Schema), JSON: p.Name})
+				}
+
+				method.Params = params
+				client.Types = append(client.Types, params)
+			}
+
+			if tx.Returns != nil {
+				returns, err := metadata.resolve(tx.Returns)
+				if err != nil {
+					return nil, err
+				}
+
+				method.Returns = goTypeName(tx.Returns)
+				method.ReturnsRaw = returns.Type == "string"
+			}
+
+			client.Methods = append(client.Methods, method)
+		}
+	}
+
+	var src bytes.Buffer
+	if err := goClientTemplate.Execute(&src, client); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated client: %s", err)
+	}
+
+	return formatted, nil
+}
+
+func newGoType(name string, doc string, s *schema) *goType {
+	t := &goType{Name: name, Doc: doc}
+
+	if s.Type != "object" || len(s.Properties) == 0 {
+		t.Base = goTypeName(s)
+		return t
+	}
+
+	for _, prop := range sortedSchemaNames(s.Properties) {
+		tag := prop
+		if !containsString(s.Required, prop) {
+			tag += ",omitempty"
+		}
+
+		t.Fields = append(t.Fields, &goField{Name: exportedName(prop), Type: goTypeName(s.Properties[prop]), JSON: tag})
+	}
+
+	return t
+}
+
+// goTypeName returns the Go type of a schema
+func goTypeName(s *schema) string {
+	switch {
+	case s == nil:
+		return "interface{}"
+	case s.Ref != "":
+		return exportedName(strings.TrimPrefix(s.Ref, schemaRefPrefix))
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + goTypeName(s.Items)
+	case "object":
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// exportedName converts a schema, parameter or transaction name into an exported Go identifier
+func exportedName(name string) string {
+	var b strings.Builder
+
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	s := b.String()
+	if s == "" || unicode.IsDigit([]rune(s)[0]) {
+		s = "X" + s
+	}
+
+	return s
+}
+
+// packageName derives a package name from the output directory
+func packageName(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err == nil {
+		dir = abs
+	}
+
+	name := strings.ToLower(exportedName(filepath.Base(dir)))
+	if token.Lookup(name).IsKeyword() {
+		name += "client"
+	}
+
+	return name
+}
+
+var goClientTemplate = template.Must(template.New("client").Parse(`// Code generated by "fabric chaincode codegen"; DO NOT EDIT.
+
+// Package {{.Package}} is a client of chaincode "{{.Chaincode}}"
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+// ChaincodeName is the name of the chaincode the client was generated from
+const ChaincodeName = "{{.Chaincode}}"
+{{range .Types}}
+// {{.Name}} {{.Doc}}
+{{if .Fields}}type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}} ` + "`json:\"{{.JSON}}\"`" + `
+{{end}}}
+{{else}}type {{.Name}} {{.Base}}
+{{end}}{{end}}
+// Client invokes the transactions of the chaincode
+type Client struct {
+	channel   fabric.Channel
+	chaincode string
+}
+
+// New returns a client of the chaincode on the given channel
+func New(ch fabric.Channel) *Client {
+	return NewWithName(ch, ChaincodeName)
+}
+
+// NewWithName returns a client of the chaincode deployed with the given name on the given channel
+func NewWithName(ch fabric.Channel, chaincode string) *Client {
+	return &Client{channel: ch, chaincode: chaincode}
+}
+{{range .Methods}}
+// {{.Name}} {{if .Submit}}submits{{else}}evaluates{{end}} the transaction "{{.Fcn}}"
+func (c *Client) {{.Name}}({{if .Params}}params {{.Params.Name}}{{end}}) ({{if .Returns}}{{.Returns}}, {{end}}error) {
+{{- if .Returns}}
+	var result {{.Returns}}
+{{end}}
+	args, err := marshalArgs({{if .Params}}{{range $i, $f := .Params.Fields}}{{if $i}}, {{end}}params.{{$f.Name}}{{end}}{{end}})
+	if err != nil {
+		return {{if .Returns}}result, {{end}}err
+	}
+{{if .Returns}}
+	resp, err := c.channel.{{if .Submit}}Execute{{else}}Query{{end}}(channel.Request{ChaincodeID: c.chaincode, Fcn: "{{.Fcn}}", Args: args})
+	if err != nil {
+		return result, err
+	}
+{{if .ReturnsRaw}}
+	return {{.Returns}}(resp.Payload), nil
+{{- else}}
+	err = json.Unmarshal(resp.Payload, &result)
+
+	return result, err
+{{- end}}
+{{- else}}
+	_, err = c.channel.{{if .Submit}}Execute{{else}}Query{{end}}(channel.Request{ChaincodeID: c.chaincode, Fcn: "{{.Fcn}}", Args: args})
+
+	return err
+{{- end}}
+}
+{{end}}
+// marshalArgs passes strings, including named string types, as they are and all other values as
+// JSON, like the contract API expects
+func marshalArgs(values ...interface{}) ([][]byte, error) {
+	args := make([][]byte, len(values))
+	for i, value := range values {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+			args[i] = []byte(v.String())
+			continue
+		}
+
+		arg, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = arg
+	}
+
+	return args, nil
+}
+`))
@@ -0,0 +1,206 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("ChaincodeCodegenCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = chaincode.NewChaincodeCodegenCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a chaincode codegen command", func() {
+		Expect(cmd.Name()).To(Equal("codegen"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("codegen <chaincode-name>"))
+	})
+})
+
+var _ = Describe("ChaincodeCodegenImplementation", func() {
+	var (
+		impl     *chaincode.CodegenCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		client   *mocks.Channel
+		dir      string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		dir, err = ioutil.TempDir("", "codegen")
+		Expect(err).To(BeNil())
+
+		client = &mocks.Channel{}
+		client.QueryReturns(channel.Response{Payload: []byte(contractMetadata)}, nil)
+
+		impl = &chaincode.CodegenCommand{}
+		impl.Settings = settings
+		impl.Channel = client
+		impl.ChaincodeName = "mycc"
+		impl.Lang = "go"
+		impl.Out = filepath.Join(dir, "assets")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the language is not supported", func() {
+			BeforeEach(func() {
+				impl.Lang = "java"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("unsupported language 'java'"))
+			})
+		})
+
+		Context("when the output directory is not set", func() {
+			BeforeEach(func() {
+				impl.Out = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("output directory not specified"))
+			})
+		})
+
+		Context("when the package name is invalid", func() {
+			BeforeEach(func() {
+				impl.Package = "my-client"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid package name 'my-client'"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		var src string
+
+		JustBeforeEach(func() {
+			err = impl.Run()
+
+			data, _ := ioutil.ReadFile(filepath.Join(impl.Out, "client.go"))
+			src = string(data)
+		})
+
+		It("should generate a valid Go package", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(ContainSubstring("Generated client of chaincode 'mycc'"))
+
+			f, err := parser.ParseFile(token.NewFileSet(), "client.go", src, 0)
+			Expect(err).To(BeNil())
+			Expect(f.Name.Name).To(Equal("assets"))
+		})
+
+		It("should generate structs from the schemas", func() {
+			Expect(src).To(ContainSubstring("type Asset struct {\n\tID   string `json:\"ID\"`\n\tSize int64  `json:\"Size,omitempty\"`\n}"))
+			Expect(src).To(ContainSubstring("type CreateAssetParams struct {\n" +
+				"\tId    string `json:\"id\"`\n\tColor string `json:\"color\"`\n\tSize  int64  `json:\"size\"`\n}"))
+		})
+
+		It("should pass strings and named string types as they are", func() {
+			Expect(src).To(ContainSubstring("if v := reflect.ValueOf(value); v.Kind() == reflect.String {"))
+		})
+
+		It("should generate a constructor taking a channel", func() {
+			Expect(src).To(ContainSubstring("func New(ch fabric.Channel) *Client {"))
+		})
+
+		It("should generate one method per transaction", func() {
+			Expect(src).To(ContainSubstring("func (c *Client) CreateAsset(params CreateAssetParams) error {"))
+			Expect(src).To(ContainSubstring("marshalArgs(params.Id, params.Color, params.Size)"))
+			Expect(src).To(ContainSubstring("func (c *Client) ReadAsset(params ReadAssetParams) (Asset, error) {"))
+			Expect(src).To(ContainSubstring("c.channel.Query(channel.Request{ChaincodeID: c.chaincode, Fcn: \"ReadAsset\", Args: args})"))
+			Expect(src).To(ContainSubstring("func (c *Client) AuditContractHistory(params AuditContractHistoryParams) error {"))
+			Expect(src).To(ContainSubstring("Fcn: \"AuditContract:History\""))
+			Expect(src).NotTo(ContainSubstring("GetMetadata"))
+		})
+
+		Context("when a package name is set", func() {
+			BeforeEach(func() {
+				impl.Package = "mycc"
+			})
+
+			It("should use the package name", func() {
+				Expect(err).To(BeNil())
+				Expect(src).To(ContainSubstring("package mycc\n"))
+			})
+		})
+	})
+})
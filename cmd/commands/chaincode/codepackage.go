@@ -0,0 +1,211 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/gopackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/javapackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/nodepackager"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+)
+
+// Legacy chaincode languages
+const (
+	langGolang = "golang"
+	langNode   = "node"
+	langJava   = "java"
+)
+
+// metadataDir is the directory of the chaincode metadata, such as state database indexes
+const metadataDir = "META-INF"
+
+var (
+	// javaBuildFiles are the files of which one is needed by the peer to build Java chaincode
+	javaBuildFiles = []string{"build.gradle", "build.gradle.kts", "pom.xml"}
+
+	// indexPathRegexp matches the paths of state database index definitions in the metadata
+	indexPathRegexp = regexp.MustCompile(`^META-INF/statedb/couchdb/(collections/[A-Za-z0-9_-]+/)?indexes/[^/]+$`)
+)
+
+// codePackage is the code package of a legacy chaincode deployment spec
+type codePackage struct {
+	Type pb.ChaincodeSpec_Type
+	Path string
+	Code []byte
+}
+
+// newCodePackage packages the chaincode of the given language found at the given path with the
+// packagers of the SDK and validates the metadata it contains. Go chaincode is either a directory
+// in GOPATH or an import path, Node.js and Java chaincode is a project directory.
+func newCodePackage(lang string, ccPath string, goPath string) (*codePackage, error) {
+	var (
+		pkg *resource.CCPackage
+		err error
+	)
+
+	path := ccPath
+
+	switch lang {
+	case langGolang:
+		if path, goPath, err = goImportPath(ccPath, goPath); err != nil {
+			return nil, err
+		}
+
+		pkg, err = gopackager.NewCCPackage(path, goPath)
+	case langNode:
+		if err = checkProjectDir(ccPath, []string{"package.json"}); err != nil {
+			return nil, err
+		}
+
+		pkg, err = nodepackager.NewCCPackage(ccPath)
+	case langJava:
+		if err = checkProjectDir(ccPath, javaBuildFiles); err != nil {
+			return nil, err
+		}
+
+		pkg, err = javapackager.NewCCPackage(ccPath)
+	default:
+		return nil, fmt.Errorf("unsupported chaincode language '%s'", lang)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMetadata(pkg.Code); err != nil {
+		return nil, err
+	}
+
+	return &codePackage{Type: pkg.Type, Path: path, Code: pkg.Code}, nil
+}
+
+// goImportPath returns the import path and GOPATH of Go chaincode, of which the path is either an
+// import path or a directory in GOPATH
+func goImportPath(ccPath string, goPath string) (string, string, error) {
+	if goPath == "" {
+		if paths := filepath.SplitList(os.Getenv("GOPATH")); len(paths) > 0 {
+			goPath = paths[0]
+		}
+	}
+
+	if goPath == "" {
+		return "", "", errors.New("GOPATH not set")
+	}
+
+	src, err := filepath.Abs(filepath.Join(goPath, "src"))
+	if err != nil {
+		return "", "", err
+	}
+
+	dir := filepath.Join(src, filepath.FromSlash(ccPath))
+	if info, err := os.Stat(ccPath); err == nil && info.IsDir() {
+		if dir, err = filepath.Abs(ccPath); err != nil {
+			return "", "", err
+		}
+	}
+
+	importPath, err := filepath.Rel(src, dir)
+	if err != nil || importPath == "." || strings.HasPrefix(importPath, "..") {
+		return "", "", fmt.Errorf("chaincode directory '%s' is not in GOPATH '%s'", ccPath, goPath)
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", "", fmt.Errorf("chaincode '%s' not found in GOPATH '%s'", ccPath, goPath)
+	}
+
+	return filepath.ToSlash(importPath), goPath, nil
+}
+
+// checkProjectDir checks that a Node.js or Java project directory contains one of the given build files
+func checkProjectDir(dir string, buildFiles []string) error {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("chaincode directory '%s' not found", dir)
+	}
+
+	for _, name := range buildFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("chaincode directory '%s' contains none of %s", dir, strings.Join(buildFiles, ", "))
+}
+
+// validateMetadata checks the metadata files of a gzipped code package
+func validateMetadata(code []byte) error {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !strings.HasPrefix(header.Name, metadataDir+"/") {
+			continue
+		}
+
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+
+		if err := validateMetadataFile(header.Name, content); err != nil {
+			return err
+		}
+	}
+}
+
+// validateMetadataFile checks that a metadata file is a state database index definition the peer accepts
+func validateMetadataFile(name string, content []byte) error {
+	if !indexPathRegexp.MatchString(name) {
+		return fmt.Errorf("invalid metadata file '%s', expected %s/statedb/couchdb/indexes or "+
+			"%s/statedb/couchdb/collections/<collection>/indexes", name, metadataDir, metadataDir)
+	}
+
+	if path.Ext(name) != ".json" {
+		return fmt.Errorf("invalid index definition '%s', expected a .json file", name)
+	}
+
+	var index struct {
+		Index *struct {
+			Fields []interface{} `json:"fields"`
+		} `json:"index"`
+	}
+
+	if err := json.Unmarshal(content, &index); err != nil {
+		return fmt.Errorf("invalid index definition '%s': %s", name, err)
+	}
+
+	if index.Index == nil || len(index.Index.Fields) == 0 {
+		return fmt.Errorf("invalid index definition '%s': index fields not specified", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewChaincodeCollectionsCommand creates a new "fabric chaincode collections" command
+func NewChaincodeCollectionsCommand(settings *environment.Settings) *cobra.Command {
+	c := CollectionsCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "collections <chaincode-name>",
+		Short: "Show the private data collections of a chaincode",
+		Long: "Show the private data collections of a chaincode. The JSON output is a collections config file\n" +
+			"which is accepted by --collections-config.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodeName)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.ChannelID, "channel", "", "Set the channel of the chaincode instead of the channel of the current context")
+	flags.StringVar(&c.OutputFormat, "output", "", "Set the output format, 'json' for a collections config file or human-readable text if not set")
+
+	cmd.AddCommand(NewChaincodeCollectionsValidateCommand(settings))
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// NewChaincodeCollectionsValidateCommand creates a new "fabric chaincode collections validate" command
+func NewChaincodeCollectionsValidateCommand(settings *environment.Settings) *cobra.Command {
+	c := CollectionsValidateCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Validate a collections config file",
+		Long: "Validate the names, counts and policies of a JSON or YAML collections config file without\n" +
+			"submitting it",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Validate()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Path)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// CollectionsCommand implements the chaincode collections command
+type CollectionsCommand struct {
+	BaseCommand
+
+	ChaincodeName string
+	ChannelID     string
+	OutputFormat  string
+}
+
+// Validate checks the required parameters for run
+func (c *CollectionsCommand) Validate() error {
+	if len(c.ChaincodeName) == 0 {
+		return errors.New("chaincode name not specified")
+	}
+
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *CollectionsCommand) Run() error {
+	context, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+
+	channel := c.ChannelID
+	if channel == "" {
+		channel = context.Channel
+	}
+
+	if channel == "" {
+		return errors.New("channel not specified")
+	}
+
+	options := []resmgmt.RequestOption{
+		resmgmt.WithTargetEndpoints(context.Peers...),
+	}
+
+	pkg, err := c.ResourceManagement.QueryCollectionsConfig(channel, c.ChaincodeName, options...)
+	if err != nil {
+		return err
+	}
+
+	collections, err := common.GetCollectionsConfigJSON(pkg.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(collections)
+	}
+
+	if len(collections) == 0 {
+		fmt.Fprintf(out, "Chaincode '%s' has no collections on channel '%s'\n", c.ChaincodeName, channel)
+		return nil
+	}
+
+	for _, coll := range collections {
+		fmt.Fprintf(out, "Collection: %s\n", coll.Name)
+		fmt.Fprintf(out, "  Policy: %s\n", coll.Policy)
+		fmt.Fprintf(out, "  Required Peer Count: %d\n", coll.RequiredCount)
+		fmt.Fprintf(out, "  Maximum Peer Count: %d\n", coll.MaxPeerCount)
+		fmt.Fprintf(out, "  Block to Live: %d\n", coll.BlockToLive)
+		fmt.Fprintf(out, "  Member Only Read: %t\n", coll.MemberOnlyRead)
+		fmt.Fprintf(out, "  Member Only Write: %t\n", coll.MemberOnlyWrite)
+
+		switch ep := coll.EndorsementPolicy; {
+		case ep == nil:
+		case ep.SignaturePolicy != "":
+			fmt.Fprintf(out, "  Endorsement Policy: %s\n", ep.SignaturePolicy)
+		default:
+			fmt.Fprintf(out, "  Endorsement Policy: %s (channel config policy)\n", ep.ChannelConfigPolicy)
+		}
+	}
+
+	return nil
+}
+
+// CollectionsValidateCommand implements the chaincode collections validate command
+type CollectionsValidateCommand struct {
+	BaseCommand
+
+	Path string
+}
+
+// Validate checks the required parameters for run
+func (c *CollectionsValidateCommand) Validate() error {
+	if len(c.Path) == 0 {
+		return errors.New("collections config file not specified")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *CollectionsValidateCommand) Run() error {
+	config, err := common.GetCollectionConfigFromFile(c.Path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "collections config '%s' is valid with %d collections\n", c.Path, len(config))
+
+	return nil
+}
@@ -0,0 +1,360 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("ChaincodeCollectionsCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = chaincode.NewChaincodeCollectionsCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a chaincode collections command", func() {
+		Expect(cmd.Name()).To(Equal("collections"))
+		Expect(cmd.HasSubCommands()).To(BeTrue())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("collections <chaincode-name>"))
+	})
+})
+
+var _ = Describe("ChaincodeCollectionsImplementation", func() {
+	const collectionsConfig = `[
+		{
+			"name": "assetPrivate",
+			"policy": "OR('Org1MSP.member', 'Org2MSP.member')",
+			"requiredPeerCount": 1,
+			"maxPeerCount": 3,
+			"blockToLive": 100,
+			"memberOnlyRead": true,
+			"memberOnlyWrite": false
+		}
+	]`
+
+	var (
+		impl     *chaincode.CollectionsCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		client   *mocks.ResourceManagement
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {
+						Channel: "mychannel",
+						Peers:   []string{"peer0"},
+					},
+				},
+				CurrentContext: "foo",
+			},
+		}
+
+		config, err := common.GetCollectionsConfigFromBytes([]byte(collectionsConfig))
+		Expect(err).To(BeNil())
+
+		client = &mocks.ResourceManagement{}
+		client.QueryCollectionsConfigReturns(&pb.CollectionConfigPackage{Config: config}, nil)
+
+		impl = &chaincode.CollectionsCommand{}
+		impl.Settings = settings
+		impl.ResourceManagement = client
+		impl.ChaincodeName = "mycc"
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the chaincode name is not set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode name not specified"))
+			})
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "yaml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'yaml'"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should query the collections of the context channel", func() {
+			Expect(err).To(BeNil())
+			channel, cc, _ := client.QueryCollectionsConfigArgsForCall(0)
+			Expect(channel).To(Equal("mychannel"))
+			Expect(cc).To(Equal("mycc"))
+		})
+
+		It("should print the collections", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal(
+				"Collection: assetPrivate\n" +
+					"  Policy: OR('Org1MSP.member', 'Org2MSP.member')\n" +
+					"  Required Peer Count: 1\n" +
+					"  Maximum Peer Count: 3\n" +
+					"  Block to Live: 100\n" +
+					"  Member Only Read: true\n" +
+					"  Member Only Write: false\n"))
+		})
+
+		Context("when a collection has an endorsement policy", func() {
+			BeforeEach(func() {
+				config, err := common.GetCollectionsConfigFromBytes([]byte("- name: assetPrivate\n" +
+					"  policy: OR('Org1MSP.member')\n" +
+					"  maxPeerCount: 1\n" +
+					"  endorsementPolicy:\n" +
+					"    signaturePolicy: OR('Org1MSP.peer')\n"))
+				Expect(err).To(BeNil())
+
+				client.QueryCollectionsConfigReturns(&pb.CollectionConfigPackage{Config: config}, nil)
+			})
+
+			It("should print the endorsement policy", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(HaveSuffix("  Endorsement Policy: OutOf(1, 'Org1MSP.peer')\n"))
+			})
+		})
+
+		Context("when a channel is set", func() {
+			BeforeEach(func() {
+				impl.ChannelID = "otherchannel"
+			})
+
+			It("should query the given channel", func() {
+				Expect(err).To(BeNil())
+				channel, _, _ := client.QueryCollectionsConfigArgsForCall(0)
+				Expect(channel).To(Equal("otherchannel"))
+			})
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "json"
+			})
+
+			It("should print a collections config which round-trips", func() {
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(MatchJSON(collectionsConfig))
+
+				config, err := common.GetCollectionsConfigFromBytes(out.Bytes())
+				Expect(err).To(BeNil())
+				Expect(config).To(HaveLen(1))
+				Expect(config[0].GetStaticCollectionConfig().Name).To(Equal("assetPrivate"))
+			})
+		})
+
+		Context("when the chaincode has no collections", func() {
+			BeforeEach(func() {
+				client.QueryCollectionsConfigReturns(&pb.CollectionConfigPackage{}, nil)
+			})
+
+			It("should say so", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(Equal("Chaincode 'mycc' has no collections on channel 'mychannel'\n"))
+			})
+		})
+
+		Context("when no channel is set", func() {
+			BeforeEach(func() {
+				settings.Config.Contexts["foo"].Channel = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("channel not specified"))
+			})
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				client.QueryCollectionsConfigReturns(nil, errors.New("query error"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("query error"))
+			})
+		})
+	})
+})
+
+var _ = Describe("ChaincodeCollectionsValidateImplementation", func() {
+	var (
+		impl     *chaincode.CollectionsValidateCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		dir      string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		dir, err = ioutil.TempDir("", "collections")
+		Expect(err).To(BeNil())
+
+		impl = &chaincode.CollectionsValidateCommand{}
+		impl.Settings = settings
+		impl.Path = filepath.Join(dir, "collections.yaml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the file is not set", func() {
+			BeforeEach(func() {
+				impl.Path = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("collections config file not specified"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		var config string
+
+		BeforeEach(func() {
+			config = "- name: assetPrivate\n" +
+				"  policy: OR('Org1MSP.member')\n" +
+				"  maxPeerCount: 1\n" +
+				"- name: auditPrivate\n" +
+				"  policy: OR('Org2MSP.member')\n" +
+				"  endorsementPolicy:\n" +
+				"    channelConfigPolicy: /Channel/Application/Endorsement\n"
+		})
+
+		JustBeforeEach(func() {
+			Expect(ioutil.WriteFile(impl.Path, []byte(config), 0644)).To(Succeed())
+
+			err = impl.Run()
+		})
+
+		It("should report a valid config", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal(fmt.Sprintf("collections config '%s' is valid with 2 collections\n", impl.Path)))
+		})
+
+		Context("when a collection name is duplicated", func() {
+			BeforeEach(func() {
+				config += "- name: assetPrivate\n" +
+					"  policy: OR('Org1MSP.member')\n"
+			})
+
+			It("should fail with the line of the collection", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("line 8: duplicate collection name 'assetPrivate' (first defined on line 1)"))
+			})
+		})
+
+		Context("when a policy is invalid", func() {
+			BeforeEach(func() {
+				config += "- name: brokenPrivate\n" +
+					"  policy: barf\n"
+			})
+
+			It("should fail with the line of the collection", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(HavePrefix("line 8: collection 'brokenPrivate': invalid policy:"))
+			})
+		})
+	})
+})
@@ -0,0 +1,188 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewChaincodeDescribeCommand creates a new "fabric chaincode describe" command
+func NewChaincodeDescribeCommand(settings *environment.Settings) *cobra.Command {
+	c := DescribeCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "describe <chaincode-name>",
+		Short: "Describe the contracts of a chaincode",
+		Long: "Describe the contracts, transactions, parameters and schemas of a chaincode written with the\n" +
+			"contract API, as returned by " + getMetadataFcn,
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodeName)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.OutputFormat, "output", "", "set the output format, 'json' for the raw metadata or human-readable text if not set")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// DescribeCommand implements the chaincode describe command
+type DescribeCommand struct {
+	BaseCommand
+
+	ChaincodeName string
+	OutputFormat  string
+}
+
+// Validate checks the required parameters for run
+func (c *DescribeCommand) Validate() error {
+	if len(c.ChaincodeName) == 0 {
+		return errors.New("chaincode name not specified")
+	}
+
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *DescribeCommand) Run() error {
+	raw, metadata, err := queryMetadata(c.Channel, c.ChaincodeName)
+	if err != nil {
+		return err
+	}
+
+	if c.OutputFormat == jsonFormat {
+		var out bytes.Buffer
+		if err := json.Indent(&out, raw, "", "  "); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(c.Settings.Streams.Out, out.String())
+
+		return nil
+	}
+
+	metadata.print(c.Settings.Streams.Out)
+
+	return nil
+}
+
+func (m *contractMetadata) print(out io.Writer) {
+	for _, name := range m.contractNames() {
+		c := m.Contracts[name]
+
+		if c.Default {
+			fmt.Fprintf(out, "Contract: %s (default)\n", c.Name)
+		} else {
+			fmt.Fprintf(out, "Contract: %s\n", c.Name)
+		}
+
+		if c.Info != nil {
+			if c.Info.Title != "" {
+				fmt.Fprintf(out, "  Title: %s\n", c.Info.Title)
+			}
+
+			if c.Info.Version != "" {
+				fmt.Fprintf(out, "  Version: %s\n", c.Info.Version)
+			}
+
+			if c.Info.Description != "" {
+				fmt.Fprintf(out, "  Description: %s\n", c.Info.Description)
+			}
+		}
+
+		fmt.Fprintln(out, "  Transactions:")
+		for _, tx := range c.Transactions {
+			params := make([]string, len(tx.Parameters))
+			for i, p := range tx.Parameters {
+				params[i] = p.Name + " " + typeName(p.Schema)
+			}
+
+			line := fmt.Sprintf("    %s(%s)", m.functionName(c, tx), strings.Join(params, ", "))
+			if tx.Returns != nil {
+				line += " " + typeName(tx.Returns)
+			}
+
+			if len(tx.Tag) > 0 {
+				line += " [" + strings.Join(tx.Tag, ", ") + "]"
+			}
+
+			fmt.Fprintln(out, line)
+		}
+	}
+
+	if len(m.Components.Schemas) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "Schemas:")
+	for _, name := range sortedSchemaNames(m.Components.Schemas) {
+		s := m.Components.Schemas[name]
+
+		fmt.Fprintf(out, "  %s:\n", name)
+		for _, prop := range sortedSchemaNames(s.Properties) {
+			line := fmt.Sprintf("    %s: %s", prop, typeName(s.Properties[prop]))
+			if containsString(s.Required, prop) {
+				line += " (required)"
+			}
+
+			fmt.Fprintln(out, line)
+		}
+	}
+}
+
+func sortedSchemaNames(schemas map[string]*schema) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,243 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+// contractMetadata is the metadata of an asset transfer chaincode written with the contract API
+const contractMetadata = `{
+	"info": {"title": "asset-transfer", "version": "1.0.0"},
+	"contracts": {
+		"AssetContract": {
+			"info": {"title": "Asset contract", "version": "1.0.0"},
+			"name": "AssetContract",
+			"default": true,
+			"transactions": [
+				{
+					"name": "CreateAsset",
+					"tag": ["submit"],
+					"parameters": [
+						{"name": "id", "schema": {"type": "string", "pattern": "^asset[0-9]+$"}},
+						{"name": "color", "schema": {"type": "string", "enum": ["red", "blue"]}},
+						{"name": "size", "schema": {"type": "integer", "minimum": 1}}
+					]
+				},
+				{
+					"name": "ReadAsset",
+					"tag": ["evaluate"],
+					"parameters": [{"name": "id", "schema": {"type": "string"}}],
+					"returns": {"$ref": "#/components/schemas/Asset"}
+				},
+				{
+					"name": "PutAsset",
+					"tag": ["submit"],
+					"parameters": [{"name": "asset", "schema": {"$ref": "#/components/schemas/Asset"}}]
+				}
+			]
+		},
+		"AuditContract": {
+			"name": "AuditContract",
+			"transactions": [{"name": "History", "parameters": [{"name": "id", "schema": {"type": "string"}}]}]
+		},
+		"org.hyperledger.fabric": {
+			"name": "org.hyperledger.fabric",
+			"transactions": [{"name": "GetMetadata"}]
+		}
+	},
+	"components": {
+		"schemas": {
+			"Asset": {
+				"type": "object",
+				"properties": {"ID": {"type": "string"}, "Size": {"type": "integer"}},
+				"required": ["ID"]
+			}
+		}
+	}
+}`
+
+var _ = Describe("ChaincodeDescribeCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = chaincode.NewChaincodeDescribeCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a chaincode describe command", func() {
+		Expect(cmd.Name()).To(Equal("describe"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("describe <chaincode-name>"))
+	})
+})
+
+var _ = Describe("ChaincodeDescribeImplementation", func() {
+	var (
+		impl     *chaincode.DescribeCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		client   *mocks.Channel
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		client = &mocks.Channel{}
+		client.QueryReturns(channel.Response{Payload: []byte(contractMetadata)}, nil)
+
+		impl = &chaincode.DescribeCommand{}
+		impl.Settings = settings
+		impl.Channel = client
+		impl.ChaincodeName = "mycc"
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the chaincode name is not set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode name not specified"))
+			})
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "yaml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'yaml'"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should query the metadata", func() {
+			Expect(err).To(BeNil())
+			req, _ := client.QueryArgsForCall(0)
+			Expect(req.ChaincodeID).To(Equal("mycc"))
+			Expect(req.Fcn).To(Equal("org.hyperledger.fabric:GetMetadata"))
+		})
+
+		It("should print the contracts and schemas", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal(
+				"Contract: AssetContract (default)\n" +
+					"  Title: Asset contract\n" +
+					"  Version: 1.0.0\n" +
+					"  Transactions:\n" +
+					"    CreateAsset(id string, color string, size integer) [submit]\n" +
+					"    ReadAsset(id string) Asset [evaluate]\n" +
+					"    PutAsset(asset Asset) [submit]\n" +
+					"Contract: AuditContract\n" +
+					"  Transactions:\n" +
+					"    AuditContract:History(id string)\n" +
+					"Schemas:\n" +
+					"  Asset:\n" +
+					"    ID: string (required)\n" +
+					"    Size: integer\n"))
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "json"
+			})
+
+			It("should print the metadata", func() {
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(ContainSubstring(`"title": "asset-transfer"`))
+			})
+		})
+
+		Context("when the chaincode has no metadata", func() {
+			BeforeEach(func() {
+				client.QueryReturns(channel.Response{Payload: []byte("hello")}, nil)
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode 'mycc' does not provide contract metadata"))
+			})
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				client.QueryReturns(channel.Response{}, errors.New("query error"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("failed to query metadata of chaincode 'mycc': query error"))
+			})
+		})
+	})
+})
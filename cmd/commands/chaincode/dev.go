@@ -0,0 +1,508 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+const (
+	devChannel = "dev"
+
+	// devErrorThreshold is the lowest status of an error response, as defined by the shim
+	devErrorThreshold = 400
+)
+
+// NewChaincodeDevCommand creates a new "fabric chaincode dev" command
+func NewChaincodeDevCommand(settings *environment.Settings) *cobra.Command {
+	c := DevCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "dev <chaincode-path>",
+		Short: "Run a chaincode locally against an in-memory ledger",
+		Long: "Build a Go chaincode and invoke it without a network. The chaincode registers with a local\n" +
+			"peer which serves its world state, private data and history from a ledger stored in the\n" +
+			"fabric home directory, so that the state is kept between runs.\n\n" +
+			"Unlike a peer, range and history queries return all results at once, and rich queries\n" +
+			"(GetQueryResult) are not supported since the ledger has no CouchDB state database.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Validate()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodePath)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.ChaincodeName, "name", "", "set the chaincode name (defaults to the name of the chaincode directory)")
+	flags.StringVar(&c.ChannelID, "channel", devChannel, "set the channel reported to the chaincode")
+	flags.StringVar(&c.ChaincodeFcn, "fcn", "", "set the invoke function")
+	flags.StringArrayVar(&c.ChaincodeArgs, "args", []string{}, "set the invoke arguments")
+	flags.StringVarP(&c.Ctor, "ctor", "c", "", "set the invoke function and arguments as peer CLI JSON, e.g. {\"Args\":[\"fcn\",\"arg1\"]}")
+	flags.StringVar(&c.ArgsFile, "args-file", "", "set the path to a JSON file containing an array of invoke arguments or a constructor")
+	flags.StringVar(&c.ArgEncoding, "arg-encoding", common.ArgEncodingUTF8, common.ArgEncodingUsage)
+	flags.BoolVar(&c.IsInit, "is-init", false, "indicates whether or not this invocation is meant to initialize the chaincode")
+	flags.StringArrayVar(&c.Transient, "transient", []string{}, "set a transient data entry as key=value (this option may be specified multiple times)")
+	flags.StringVar(&c.TransientFile, "transient-file", "", "set the path to a JSON file containing an object of transient data values")
+	flags.StringVar(&c.TransientEncoding, "transient-encoding", common.TransientEncodingUTF8,
+		"set the encoding of the transient data values (utf8, base64 or hex)")
+	flags.StringVar(&c.MSPDir, "msp-dir", "", "set the local MSP directory whose signing certificate is the creator of the transaction")
+	flags.StringVar(&c.MSPID, "msp-id", "", "set the MSP ID of the creator of the transaction")
+	flags.BoolVar(&c.Reset, "reset", false, "start from an empty ledger")
+	flags.DurationVar(&c.Timeout, "timeout", 30*time.Second, "set the time to wait for the chaincode to start and complete the transaction")
+	flags.StringVar(&c.OutputFormat, "output", "", "set the output format, 'json' or human-readable text if not set")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// DevCommand implements the chaincode dev command
+type DevCommand struct {
+	BaseCommand
+
+	ChaincodePath string
+	ChaincodeName string
+	ChannelID     string
+
+	ChaincodeFcn  string
+	ChaincodeArgs []string
+	Ctor          string
+	ArgsFile      string
+	ArgEncoding   string
+	IsInit        bool
+
+	Transient         []string
+	TransientFile     string
+	TransientEncoding string
+
+	MSPDir       string
+	MSPID        string
+	Reset        bool
+	Timeout      time.Duration
+	OutputFormat string
+}
+
+// devResult is the outcome of a transaction run with "chaincode dev"
+type devResult struct {
+	TxID        string      `json:"tx_id"`
+	Status      int32       `json:"status"`
+	Message     string      `json:"message,omitempty"`
+	Payload     string      `json:"payload"`
+	Reads       []*devRead  `json:"reads"`
+	Writes      []*devWrite `json:"writes"`
+	Events      []*devEvent `json:"events"`
+	Committed   bool        `json:"committed"`
+	BlockNumber uint64      `json:"block_number,omitempty"`
+}
+
+type devEvent struct {
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
+}
+
+// Validate checks the required parameters for run
+func (c *DevCommand) Validate() error {
+	if len(c.ChaincodePath) == 0 {
+		return errors.New("chaincode path not specified")
+	}
+
+	if len(c.MSPDir) == 0 {
+		return errors.New("MSP directory not specified")
+	}
+
+	if len(c.MSPID) == 0 {
+		return errors.New("MSP ID not specified")
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *DevCommand) Run() error {
+	fcn, args, err := common.GetChaincodeArgs(c.ChaincodeFcn, c.ChaincodeArgs, c.Ctor, c.ArgsFile, c.ArgEncoding)
+	if err != nil {
+		return err
+	}
+
+	transientMap, err := common.GetTransientMap(c.Transient, c.TransientFile, c.TransientEncoding)
+	if err != nil {
+		return err
+	}
+
+	creator, err := c.creator()
+	if err != nil {
+		return err
+	}
+
+	name := c.ChaincodeName
+	if name == "" {
+		abs, err := filepath.Abs(c.ChaincodePath)
+		if err != nil {
+			return err
+		}
+
+		name = filepath.Base(abs)
+	}
+
+	ledgerPath := c.Settings.Home.Path("dev", name+".json")
+	if c.Reset {
+		if err := os.Remove(ledgerPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	ledger, err := readDevLedger(ledgerPath)
+	if err != nil {
+		return err
+	}
+
+	dir, err := ioutil.TempDir("", "fabric-chaincode-dev")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	binary, err := buildChaincode(c.ChaincodePath, dir)
+	if err != nil {
+		return err
+	}
+
+	msg, txID, timestamp, err := newDevTransaction(name, c.ChannelID, fcn, args, transientMap, creator, c.IsInit)
+	if err != nil {
+		return err
+	}
+
+	tx := &devTx{ledger: ledger, chaincode: name, txID: txID}
+
+	resp, err := c.execute(binary, name, msg, tx)
+	if err != nil {
+		return err
+	}
+
+	result := &devResult{TxID: txID, Reads: tx.reads, Writes: tx.writes, Events: []*devEvent{}}
+
+	if resp.Type == pb.ChaincodeMessage_ERROR {
+		result.Status = 500
+		result.Message = string(resp.Payload)
+	} else {
+		response := &pb.Response{}
+		if err := proto.Unmarshal(resp.Payload, response); err != nil {
+			return err
+		}
+
+		result.Status = response.Status
+		result.Message = response.Message
+		result.Payload = string(response.Payload)
+
+		if event := resp.ChaincodeEvent; event != nil && event.EventName != "" {
+			result.Events = append(result.Events, &devEvent{Name: event.EventName, Payload: string(event.Payload)})
+		}
+	}
+
+	if result.Status < devErrorThreshold {
+		ledger.commit(txID, timestamp, tx.writes)
+
+		if err := ledger.write(ledgerPath); err != nil {
+			return err
+		}
+
+		result.Committed = true
+		result.BlockNumber = ledger.Height
+	}
+
+	if err := c.print(result); err != nil {
+		return err
+	}
+
+	if !result.Committed {
+		return fmt.Errorf("chaincode returned status %d: %s", result.Status, result.Message)
+	}
+
+	return nil
+}
+
+// execute starts the chaincode, waits for it to register and runs the transaction
+func (c *DevCommand) execute(binary string, name string, msg *pb.ChaincodeMessage, tx *devTx) (*pb.ChaincodeMessage, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	peer := newDevPeer()
+	server := grpc.NewServer()
+	pb.RegisterChaincodeSupportServer(server, peer)
+
+	go server.Serve(listener)
+
+	defer server.Stop()
+	defer close(peer.done)
+
+	cmd := exec.Command(binary)
+	cmd.Env = append(os.Environ(),
+		"CORE_CHAINCODE_ID_NAME="+name+":dev",
+		"CORE_PEER_ADDRESS="+listener.Addr().String(),
+		"CORE_PEER_TLS_ENABLED=false",
+	)
+	cmd.Stdout = c.Settings.Streams.Err
+	cmd.Stderr = c.Settings.Streams.Err
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start chaincode: %s", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	defer func() {
+		cmd.Process.Kill()
+		<-exited
+	}()
+
+	timer := time.AfterFunc(c.Timeout, func() {
+		cmd.Process.Kill()
+	})
+	defer timer.Stop()
+
+	select {
+	case stream := <-peer.streams:
+		resp, err := peer.execute(stream, msg, tx)
+		if err != nil && !timer.Stop() {
+			return nil, fmt.Errorf("chaincode did not complete the transaction within %s", c.Timeout)
+		}
+
+		return resp, err
+	case err := <-exited:
+		exited <- err
+
+		if !timer.Stop() {
+			return nil, fmt.Errorf("chaincode did not register within %s", c.Timeout)
+		}
+
+		return nil, fmt.Errorf("chaincode exited before registering: %v", err)
+	}
+}
+
+// creator returns the serialized identity of the signing certificate of the MSP directory
+func (c *DevCommand) creator() ([]byte, error) {
+	certs, err := filepath.Glob(filepath.Join(c.MSPDir, "signcerts", "*.pem"))
+	if err != nil || len(certs) == 0 {
+		return nil, fmt.Errorf("no signing certificate found in %s", filepath.Join(c.MSPDir, "signcerts"))
+	}
+
+	cert, err := ioutil.ReadFile(certs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&msp.SerializedIdentity{Mspid: c.MSPID, IdBytes: cert})
+}
+
+func (c *DevCommand) print(result *devResult) error {
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(result)
+	}
+
+	fmt.Fprintf(out, "Transaction ID: %s\n", result.TxID)
+	if result.Message != "" {
+		fmt.Fprintf(out, "Status: %d (%s)\n", result.Status, result.Message)
+	} else {
+		fmt.Fprintf(out, "Status: %d\n", result.Status)
+	}
+	fmt.Fprintf(out, "Payload: %s\n", result.Payload)
+
+	for _, r := range result.Reads {
+		if r.Version == 0 {
+			fmt.Fprintf(out, "Read: %s (not found)\n", devKey(r.Collection, r.Key))
+		} else {
+			fmt.Fprintf(out, "Read: %s (version %d)\n", devKey(r.Collection, r.Key), r.Version)
+		}
+	}
+
+	for _, w := range result.Writes {
+		if w.IsDelete {
+			fmt.Fprintf(out, "Write: %s (deleted)\n", devKey(w.Collection, w.Key))
+		} else {
+			fmt.Fprintf(out, "Write: %s = %s\n", devKey(w.Collection, w.Key), w.Value)
+		}
+	}
+
+	for _, e := range result.Events {
+		fmt.Fprintf(out, "Event: %s = %s\n", e.Name, e.Payload)
+	}
+
+	if result.Committed {
+		fmt.Fprintf(out, "Committed in block %d\n", result.BlockNumber)
+	} else {
+		fmt.Fprintln(out, "Not committed")
+	}
+
+	return nil
+}
+
+// devKey formats a key for display, quoting keys with unprintable characters such as composite keys
+func devKey(collection string, key string) string {
+	if strings.IndexFunc(key, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		key = strconv.Quote(key)
+	}
+
+	if collection != "" {
+		return collection + "/" + key
+	}
+
+	return key
+}
+
+// buildChaincode builds the chaincode in the given directory into an executable in the output directory
+func buildChaincode(path string, outDir string) (string, error) {
+	binary := filepath.Join(outDir, "chaincode")
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("go", "build", "-o", binary, ".")
+	cmd.Dir = path
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to build chaincode: %s\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return binary, nil
+}
+
+// newDevTransaction creates the transaction message sent to the chaincode, including an unsigned
+// proposal from which the chaincode reads its creator and transient data
+func newDevTransaction(name string, channelID string, fcn string, args [][]byte, transientMap map[string][]byte,
+	creator []byte, isInit bool) (*pb.ChaincodeMessage, string, time.Time, error) {
+	nonce := make([]byte, 24)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	hash := sha256.Sum256(append(append([]byte{}, nonce...), creator...))
+	txID := hex.EncodeToString(hash[:])
+
+	now := time.Now().UTC()
+	timestamp, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	ccID := &pb.ChaincodeID{Name: name}
+	input := &pb.ChaincodeInput{Args: append([][]byte{[]byte(fcn)}, args...), IsInit: isInit}
+
+	extension, err := proto.Marshal(&pb.ChaincodeHeaderExtension{ChaincodeId: ccID})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	channelHeader, err := proto.Marshal(&cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: channelID,
+		TxId:      txID,
+		Timestamp: timestamp,
+		Extension: extension,
+	})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	signatureHeader, err := proto.Marshal(&cb.SignatureHeader{Creator: creator, Nonce: nonce})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	header, err := proto.Marshal(&cb.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	spec, err := proto.Marshal(&pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeId: ccID, Input: input},
+	})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	payload, err := proto.Marshal(&pb.ChaincodeProposalPayload{Input: spec, TransientMap: transientMap})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	proposal, err := proto.Marshal(&pb.Proposal{Header: header, Payload: payload})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	inputBytes, err := proto.Marshal(input)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	msgType := pb.ChaincodeMessage_TRANSACTION
+	if isInit {
+		msgType = pb.ChaincodeMessage_INIT
+	}
+
+	msg := &pb.ChaincodeMessage{
+		Type:      msgType,
+		Timestamp: timestamp,
+		Payload:   inputBytes,
+		Txid:      txID,
+		ChannelId: channelID,
+		Proposal:  &pb.SignedProposal{ProposalBytes: proposal},
+	}
+
+	return msg, txID, now, nil
+}
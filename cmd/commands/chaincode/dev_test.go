@@ -0,0 +1,349 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+var _ = Describe("ChaincodeDevCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = chaincode.NewChaincodeDevCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a chaincode dev command", func() {
+		Expect(cmd.Name()).To(Equal("dev"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("dev <chaincode-path>"))
+	})
+})
+
+var _ = Describe("ChaincodeDevImplementation", func() {
+	var (
+		impl     *chaincode.DevCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		home     string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		home, err = ioutil.TempDir("", "dev")
+		Expect(err).To(BeNil())
+
+		mspDir := filepath.Join(home, "msp")
+		Expect(os.MkdirAll(filepath.Join(mspDir, "signcerts"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(mspDir, "signcerts", "cert.pem"), []byte("certificate"), 0600)).To(Succeed())
+
+		settings = &environment.Settings{
+			Home: environment.Home(home),
+			Streams: environment.Streams{
+				Out: out,
+				Err: GinkgoWriter,
+			},
+		}
+
+		impl = &chaincode.DevCommand{}
+		impl.Settings = settings
+		impl.ChaincodePath = "./testdata/devcc"
+		impl.ChannelID = "dev"
+		impl.MSPDir = mspDir
+		impl.MSPID = "Org1MSP"
+		impl.Timeout = time.Minute
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(home)
+	})
+
+	writeLedger := func(ledger string) {
+		Expect(os.MkdirAll(filepath.Join(home, "dev"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(home, "dev", "devcc.json"), []byte(ledger), 0600)).To(Succeed())
+	}
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the chaincode path is not set", func() {
+			BeforeEach(func() {
+				impl.ChaincodePath = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode path not specified"))
+			})
+		})
+
+		Context("when the MSP directory is not set", func() {
+			BeforeEach(func() {
+				impl.MSPDir = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("MSP directory not specified"))
+			})
+		})
+
+		Context("when the MSP ID is not set", func() {
+			BeforeEach(func() {
+				impl.MSPID = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("MSP ID not specified"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		Context("when the chaincode writes a key", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "put"
+				impl.ChaincodeArgs = []string{"a", "100"}
+			})
+
+			It("should print the read/write set and event", func() {
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(MatchRegexp("^Transaction ID: [0-9a-f]{64}\n" +
+					"Status: 200\n" +
+					"Payload: 100\n" +
+					"Read: a \\(not found\\)\n" +
+					"Write: a = 100\n" +
+					"Event: put = a\n" +
+					"Committed in block 1\n$"))
+			})
+
+			It("should store the state in the home directory", func() {
+				data, err := ioutil.ReadFile(filepath.Join(home, "dev", "devcc.json"))
+				Expect(err).To(BeNil())
+
+				var ledger map[string]interface{}
+				Expect(json.Unmarshal(data, &ledger)).To(Succeed())
+				Expect(ledger["height"]).To(Equal(1.0))
+				Expect(ledger["state"]).To(HaveKeyWithValue("a", map[string]interface{}{"value": "MTAw", "version": 1.0}))
+			})
+
+			Context("when the key exists", func() {
+				BeforeEach(func() {
+					writeLedger(`{"height":3,"state":{"a":{"value":"NTA=","version":2}}}`)
+				})
+
+				It("should read the committed version", func() {
+					Expect(err).To(BeNil())
+					Expect(out.String()).To(ContainSubstring("Read: a (version 2)\n"))
+					Expect(out.String()).To(ContainSubstring("Committed in block 4\n"))
+				})
+
+				Context("when the ledger is reset", func() {
+					BeforeEach(func() {
+						impl.Reset = true
+					})
+
+					It("should start from an empty ledger", func() {
+						Expect(err).To(BeNil())
+						Expect(out.String()).To(ContainSubstring("Read: a (not found)\n"))
+						Expect(out.String()).To(ContainSubstring("Committed in block 1\n"))
+					})
+				})
+			})
+
+			Context("when the output format is json", func() {
+				BeforeEach(func() {
+					impl.OutputFormat = "json"
+				})
+
+				It("should print the result as JSON", func() {
+					Expect(err).To(BeNil())
+
+					var result map[string]interface{}
+					Expect(json.Unmarshal(out.Bytes(), &result)).To(Succeed())
+					Expect(result["status"]).To(Equal(200.0))
+					Expect(result["reads"]).To(Equal([]interface{}{map[string]interface{}{"key": "a", "version": 0.0}}))
+					Expect(result["writes"]).To(Equal([]interface{}{map[string]interface{}{"key": "a", "value": "100"}}))
+					Expect(result["events"]).To(Equal([]interface{}{map[string]interface{}{"name": "put", "payload": "a"}}))
+					Expect(result["committed"]).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the chaincode queries the ledger", func() {
+			BeforeEach(func() {
+				writeLedger(`{
+					"height": 2,
+					"state": {
+						"a": {"value": "MQ==", "version": 1},
+						"b": {"value": "Mg==", "version": 2},
+						"c": {"value": "Mw==", "version": 2}
+					},
+					"history": {"a": [
+						{"tx_id": "tx1", "value": "MA==", "timestamp": "2020-01-01T00:00:00Z"},
+						{"tx_id": "tx2", "value": "MQ==", "timestamp": "2020-01-02T00:00:00Z"}
+					]}
+				}`)
+			})
+
+			Context("by range", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "range"
+					impl.ChaincodeArgs = []string{"a", "c"}
+				})
+
+				It("should return the keys in the range", func() {
+					Expect(err).To(BeNil())
+					Expect(out.String()).To(ContainSubstring("Payload: a=1,b=2\nRead: a (version 1)\nRead: b (version 2)\n"))
+				})
+			})
+
+			Context("by history", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "history"
+					impl.ChaincodeArgs = []string{"a"}
+				})
+
+				It("should return the most recent value first", func() {
+					Expect(err).To(BeNil())
+					Expect(out.String()).To(ContainSubstring("Payload: 1,0\n"))
+				})
+			})
+		})
+
+		Context("when the chaincode writes private data", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "putPrivate"
+				impl.ChaincodeArgs = []string{"secrets", "k"}
+				impl.Transient = []string{"value=s3cret"}
+			})
+
+			It("should write the transient value to the collection", func() {
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(ContainSubstring("Write: secrets/k = s3cret\n"))
+			})
+		})
+
+		Context("when the chaincode reads the creator", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "creator"
+			})
+
+			It("should use the identity of the MSP directory", func() {
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(ContainSubstring("Payload: Org1MSP\n"))
+			})
+		})
+
+		Context("when the chaincode returns an error", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "richQuery"
+				impl.ChaincodeArgs = []string{"{}"}
+			})
+
+			It("should not commit the transaction", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode returned status 500: GET_QUERY_RESULT is not supported by chaincode dev, rich queries require CouchDB"))
+				Expect(out.String()).To(ContainSubstring("Not committed\n"))
+
+				_, err := os.Stat(filepath.Join(home, "dev", "devcc.json"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+
+		Context("when the chaincode requests the next page of a query", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "queryNext"
+				impl.ChaincodeArgs = []string{"1"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode returned status 500: " +
+					"QUERY_STATE_NEXT is not supported by chaincode dev, which returns all query results at once"))
+			})
+		})
+
+		Context("when the chaincode does not build", func() {
+			BeforeEach(func() {
+				impl.ChaincodePath = home
+				impl.ChaincodeFcn = "get"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(HavePrefix("failed to build chaincode"))
+			})
+		})
+
+		Context("when the MSP directory has no signing certificate", func() {
+			BeforeEach(func() {
+				impl.MSPDir = home
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("no signing certificate found in " + filepath.Join(home, "signcerts")))
+			})
+		})
+	})
+})
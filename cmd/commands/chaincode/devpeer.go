@@ -0,0 +1,431 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// devLedger is the world state, private data and history of a chaincode run with "chaincode dev"
+type devLedger struct {
+	Height  uint64                          `json:"height"`
+	State   map[string]*devValue            `json:"state"`
+	Private map[string]map[string]*devValue `json:"private,omitempty"`
+	History map[string][]*devModification   `json:"history,omitempty"`
+}
+
+// devValue is a committed value, versioned by the height of the transaction which wrote it
+type devValue struct {
+	Value   []byte `json:"value"`
+	Version uint64 `json:"version"`
+}
+
+type devModification struct {
+	TxID      string    `json:"tx_id"`
+	Value     []byte    `json:"value,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	IsDelete  bool      `json:"is_delete,omitempty"`
+}
+
+// devRead is a key read by a transaction, version 0 means the key was not found
+type devRead struct {
+	Collection string `json:"collection,omitempty"`
+	Key        string `json:"key"`
+	Version    uint64 `json:"version"`
+}
+
+type devWrite struct {
+	Collection string `json:"collection,omitempty"`
+	Key        string `json:"key"`
+	Value      string `json:"value,omitempty"`
+	IsDelete   bool   `json:"is_delete,omitempty"`
+}
+
+func newDevLedger() *devLedger {
+	return &devLedger{
+		State:   make(map[string]*devValue),
+		Private: make(map[string]map[string]*devValue),
+		History: make(map[string][]*devModification),
+	}
+}
+
+// readDevLedger reads a ledger file, returning an empty ledger if the file does not exist
+func readDevLedger(path string) (*devLedger, error) {
+	l := newDevLedger()
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ledger file %s: %s", path, err)
+	}
+
+	if l.State == nil {
+		l.State = make(map[string]*devValue)
+	}
+
+	if l.Private == nil {
+		l.Private = make(map[string]map[string]*devValue)
+	}
+
+	if l.History == nil {
+		l.History = make(map[string][]*devModification)
+	}
+
+	return l, nil
+}
+
+func (l *devLedger) write(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func (l *devLedger) values(collection string) map[string]*devValue {
+	if collection == "" {
+		return l.State
+	}
+
+	return l.Private[collection]
+}
+
+// commit applies the writes of a transaction as a new block
+func (l *devLedger) commit(txID string, timestamp time.Time, writes []*devWrite) {
+	l.Height++
+
+	for _, w := range writes {
+		if w.Collection != "" && l.Private[w.Collection] == nil {
+			l.Private[w.Collection] = make(map[string]*devValue)
+		}
+
+		values := l.values(w.Collection)
+		if w.IsDelete {
+			delete(values, w.Key)
+		} else {
+			values[w.Key] = &devValue{Value: []byte(w.Value), Version: l.Height}
+		}
+
+		if w.Collection == "" {
+			l.History[w.Key] = append(l.History[w.Key], &devModification{
+				TxID:      txID,
+				Value:     []byte(w.Value),
+				Timestamp: timestamp,
+				IsDelete:  w.IsDelete,
+			})
+		}
+	}
+}
+
+// devTx simulates a transaction against the committed state of a ledger. Like on a peer, reads
+// do not see the writes of the same transaction.
+type devTx struct {
+	ledger    *devLedger
+	chaincode string
+	txID      string
+
+	reads  []*devRead
+	writes []*devWrite
+}
+
+func (tx *devTx) get(collection string, key string) []byte {
+	value := tx.ledger.values(collection)[key]
+
+	var version uint64
+	if value != nil {
+		version = value.Version
+	}
+
+	found := false
+	for _, r := range tx.reads {
+		if r.Collection == collection && r.Key == key {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		tx.reads = append(tx.reads, &devRead{Collection: collection, Key: key, Version: version})
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	return value.Value
+}
+
+func (tx *devTx) put(collection string, key string, value []byte, isDelete bool) error {
+	if key == "" {
+		return errors.New("key must not be empty")
+	}
+
+	for i, w := range tx.writes {
+		if w.Collection == collection && w.Key == key {
+			tx.writes = append(tx.writes[:i], tx.writes[i+1:]...)
+			break
+		}
+	}
+
+	tx.writes = append(tx.writes, &devWrite{Collection: collection, Key: key, Value: string(value), IsDelete: isDelete})
+
+	return nil
+}
+
+// handle answers a request of the chaincode
+func (tx *devTx) handle(msg *pb.ChaincodeMessage) *pb.ChaincodeMessage {
+	payload, err := tx.dispatch(msg)
+	if err != nil {
+		return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid, ChannelId: msg.ChannelId}
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: msg.Txid, ChannelId: msg.ChannelId}
+}
+
+func (tx *devTx) dispatch(msg *pb.ChaincodeMessage) ([]byte, error) {
+	switch msg.Type {
+	case pb.ChaincodeMessage_GET_STATE:
+		req := &pb.GetState{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		return tx.get(req.Collection, req.Key), nil
+	case pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH:
+		req := &pb.GetState{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		value := tx.get(req.Collection, req.Key)
+		if value == nil {
+			return nil, nil
+		}
+
+		hash := sha256.Sum256(value)
+
+		return hash[:], nil
+	case pb.ChaincodeMessage_PUT_STATE:
+		req := &pb.PutState{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		return nil, tx.put(req.Collection, req.Key, req.Value, false)
+	case pb.ChaincodeMessage_DEL_STATE:
+		req := &pb.DelState{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		return nil, tx.put(req.Collection, req.Key, nil, true)
+	case pb.ChaincodeMessage_GET_STATE_BY_RANGE:
+		req := &pb.GetStateByRange{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		return tx.getStateByRange(req)
+	case pb.ChaincodeMessage_QUERY_STATE_CLOSE:
+		req := &pb.QueryStateClose{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		return proto.Marshal(&pb.QueryResponse{Id: req.Id})
+	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
+		req := &pb.GetHistoryForKey{}
+		if err := proto.Unmarshal(msg.Payload, req); err != nil {
+			return nil, err
+		}
+
+		return tx.getHistoryForKey(req.Key)
+	case pb.ChaincodeMessage_GET_STATE_METADATA:
+		return proto.Marshal(&pb.StateMetadataResult{})
+	case pb.ChaincodeMessage_PUT_STATE_METADATA:
+		return nil, nil
+	case pb.ChaincodeMessage_GET_QUERY_RESULT:
+		return nil, fmt.Errorf("%s is not supported by chaincode dev, rich queries require CouchDB", msg.Type)
+	case pb.ChaincodeMessage_QUERY_STATE_NEXT:
+		return nil, fmt.Errorf("%s is not supported by chaincode dev, which returns all query results at once", msg.Type)
+	default:
+		return nil, fmt.Errorf("%s is not supported by chaincode dev", msg.Type)
+	}
+}
+
+// getStateByRange returns all matching keys at once, paginated if requested
+func (tx *devTx) getStateByRange(req *pb.GetStateByRange) ([]byte, error) {
+	values := tx.ledger.values(req.Collection)
+
+	var keys []string
+	for key := range values {
+		if key >= req.StartKey && (req.EndKey == "" || key < req.EndKey) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	var metadata *pb.QueryResponseMetadata
+	if len(req.Metadata) > 0 {
+		paging := &pb.QueryMetadata{}
+		if err := proto.Unmarshal(req.Metadata, paging); err != nil {
+			return nil, err
+		}
+
+		if paging.Bookmark != "" {
+			i := sort.SearchStrings(keys, paging.Bookmark)
+			keys = keys[i:]
+		}
+
+		metadata = &pb.QueryResponseMetadata{}
+		if paging.PageSize > 0 && len(keys) > int(paging.PageSize) {
+			metadata.Bookmark = keys[paging.PageSize]
+			keys = keys[:paging.PageSize]
+		}
+
+		metadata.FetchedRecordsCount = int32(len(keys))
+	}
+
+	resp := &pb.QueryResponse{Id: tx.txID}
+	for _, key := range keys {
+		kv, err := proto.Marshal(&queryresult.KV{Namespace: tx.chaincode, Key: key, Value: tx.get(req.Collection, key)})
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Results = append(resp.Results, &pb.QueryResultBytes{ResultBytes: kv})
+	}
+
+	if metadata != nil {
+		var err error
+		if resp.Metadata, err = proto.Marshal(metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return proto.Marshal(resp)
+}
+
+// getHistoryForKey returns the modifications of a key, the most recent first
+func (tx *devTx) getHistoryForKey(key string) ([]byte, error) {
+	history := tx.ledger.History[key]
+
+	resp := &pb.QueryResponse{Id: tx.txID}
+	for i := len(history) - 1; i >= 0; i-- {
+		timestamp, err := ptypes.TimestampProto(history[i].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		km, err := proto.Marshal(&queryresult.KeyModification{
+			TxId:      history[i].TxID,
+			Value:     history[i].Value,
+			Timestamp: timestamp,
+			IsDelete:  history[i].IsDelete,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Results = append(resp.Results, &pb.QueryResultBytes{ResultBytes: km})
+	}
+
+	return proto.Marshal(resp)
+}
+
+// devPeer is the chaincode support service of a peer, serving a single chaincode
+type devPeer struct {
+	streams chan pb.ChaincodeSupport_RegisterServer
+	done    chan struct{}
+}
+
+func newDevPeer() *devPeer {
+	return &devPeer{
+		streams: make(chan pb.ChaincodeSupport_RegisterServer, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// Register completes the handshake with a chaincode and hands its stream over to the command
+func (p *devPeer) Register(stream pb.ChaincodeSupport_RegisterServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	if msg.Type != pb.ChaincodeMessage_REGISTER {
+		return fmt.Errorf("expected %s message, received %s", pb.ChaincodeMessage_REGISTER, msg.Type)
+	}
+
+	if err := stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}); err != nil {
+		return err
+	}
+
+	if err := stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_READY}); err != nil {
+		return err
+	}
+
+	select {
+	case p.streams <- stream:
+	case <-p.done:
+		return nil
+	}
+
+	<-p.done
+
+	return nil
+}
+
+// execute sends a transaction to the chaincode and answers its requests until it completes
+func (p *devPeer) execute(stream pb.ChaincodeSupport_RegisterServer, msg *pb.ChaincodeMessage, tx *devTx) (*pb.ChaincodeMessage, error) {
+	if err := stream.Send(msg); err != nil {
+		return nil, err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("chaincode disconnected: %s", err)
+		}
+
+		switch req.Type {
+		case pb.ChaincodeMessage_COMPLETED, pb.ChaincodeMessage_ERROR:
+			return req, nil
+		case pb.ChaincodeMessage_KEEPALIVE:
+			continue
+		}
+
+		if err := stream.Send(tx.handle(req)); err != nil {
+			return nil, err
+		}
+	}
+}
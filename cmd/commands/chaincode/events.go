@@ -38,6 +38,8 @@ func NewChaincodeEventsCommand(settings *environment.Settings) *cobra.Command {
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
 			return c.Run()
 		},
 	}
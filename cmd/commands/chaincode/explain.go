@@ -0,0 +1,307 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// missingValue is shown for a key which an endorser did not write
+const missingValue = "-"
+
+// explanation describes the proposal responses of the endorsers and the differences between them
+type explanation struct {
+	Endorsements []*endorsement `json:"endorsements"`
+	Differences  []*difference  `json:"differences"`
+}
+
+// endorsement is the decoded proposal response of a single endorser
+type endorsement struct {
+	Peer    string            `json:"peer"`
+	Status  int32             `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Payload string            `json:"payload"`
+	Event   *event            `json:"event,omitempty"`
+	Reads   map[string]string `json:"reads"`
+	Writes  map[string]string `json:"writes"`
+}
+
+// event is the chaincode event set by an endorser
+type event struct {
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
+}
+
+// difference is a value which is not the same for all endorsers
+type difference struct {
+	Key    string            `json:"key"`
+	Values map[string]string `json:"values"`
+}
+
+// matchHandler only continues with the next handler when all proposal responses match,
+// so that the responses can be explained instead of failing the validation
+type matchHandler struct {
+	next invoke.Handler
+}
+
+// Handle compares the proposal response payloads
+func (h *matchHandler) Handle(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext) {
+	responses := requestContext.Response.Responses
+
+	for i := 1; i < len(responses); i++ {
+		if !bytes.Equal(responses[i].ProposalResponse.GetPayload(), responses[0].ProposalResponse.GetPayload()) {
+			return
+		}
+	}
+
+	h.next.Handle(requestContext, clientContext)
+}
+
+// explain decodes the given proposal responses and computes the differences between them
+func explain(responses []*fab.TransactionProposalResponse) (*explanation, error) {
+	e := &explanation{
+		Endorsements: []*endorsement{},
+		Differences:  []*difference{},
+	}
+
+	for _, r := range responses {
+		endorsement, err := decodeEndorsement(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response of peer %s: %s", r.Endorser, err)
+		}
+
+		e.Endorsements = append(e.Endorsements, endorsement)
+	}
+
+	values := make(map[string]map[string]string)
+	add := func(key, peer, value string) {
+		if values[key] == nil {
+			values[key] = make(map[string]string)
+		}
+		values[key][peer] = value
+	}
+
+	for _, endorsement := range e.Endorsements {
+		add("(status)", endorsement.Peer, fmt.Sprint(endorsement.Status))
+		add("(payload)", endorsement.Peer, endorsement.Payload)
+
+		if endorsement.Event != nil {
+			add("(event)", endorsement.Peer, endorsement.Event.Name+" "+endorsement.Event.Payload)
+		}
+
+		for key, version := range endorsement.Reads {
+			add("read "+key, endorsement.Peer, version)
+		}
+
+		for key, value := range endorsement.Writes {
+			add("write "+key, endorsement.Peer, value)
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !sameValues(values[key], e.Endorsements) {
+			d := &difference{Key: key, Values: make(map[string]string)}
+			for _, endorsement := range e.Endorsements {
+				d.Values[endorsement.Peer] = valueOrMissing(values[key], endorsement.Peer)
+			}
+
+			e.Differences = append(e.Differences, d)
+		}
+	}
+
+	return e, nil
+}
+
+func sameValues(values map[string]string, endorsements []*endorsement) bool {
+	first := valueOrMissing(values, endorsements[0].Peer)
+
+	for _, endorsement := range endorsements[1:] {
+		if valueOrMissing(values, endorsement.Peer) != first {
+			return false
+		}
+	}
+
+	return true
+}
+
+func valueOrMissing(values map[string]string, peer string) string {
+	if value, ok := values[peer]; ok {
+		return value
+	}
+
+	return missingValue
+}
+
+func decodeEndorsement(r *fab.TransactionProposalResponse) (*endorsement, error) {
+	e := &endorsement{
+		Peer:   r.Endorser,
+		Status: r.Status,
+		Reads:  make(map[string]string),
+		Writes: make(map[string]string),
+	}
+
+	if r.ProposalResponse == nil {
+		return e, nil
+	}
+
+	if response := r.ProposalResponse.GetResponse(); response != nil {
+		e.Message = response.Message
+		e.Payload = string(response.Payload)
+	}
+
+	prp := &pb.ProposalResponsePayload{}
+	if err := proto.Unmarshal(r.ProposalResponse.Payload, prp); err != nil {
+		return nil, err
+	}
+
+	action := &pb.ChaincodeAction{}
+	if err := proto.Unmarshal(prp.Extension, action); err != nil {
+		return nil, err
+	}
+
+	if len(action.Events) > 0 {
+		ccEvent := &pb.ChaincodeEvent{}
+		if err := proto.Unmarshal(action.Events, ccEvent); err != nil {
+			return nil, err
+		}
+
+		e.Event = &event{Name: ccEvent.EventName, Payload: string(ccEvent.Payload)}
+	}
+
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(action.Results, txRWSet); err != nil {
+		return nil, err
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwset {
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return nil, err
+		}
+
+		for _, read := range kvRWSet.Reads {
+			e.Reads[nsRWSet.Namespace+"/"+read.Key] = versionString(read.Version)
+		}
+
+		for _, write := range kvRWSet.Writes {
+			e.Writes[nsRWSet.Namespace+"/"+write.Key] = writeString(write.IsDelete, write.Value)
+		}
+
+		for _, collRWSet := range nsRWSet.CollectionHashedRwset {
+			hashedRWSet := &kvrwset.HashedRWSet{}
+			if err := proto.Unmarshal(collRWSet.HashedRwset, hashedRWSet); err != nil {
+				return nil, err
+			}
+
+			prefix := nsRWSet.Namespace + "/" + collRWSet.CollectionName + "/#"
+
+			for _, read := range hashedRWSet.HashedReads {
+				e.Reads[prefix+hex.EncodeToString(read.KeyHash)] = versionString(read.Version)
+			}
+
+			for _, write := range hashedRWSet.HashedWrites {
+				e.Writes[prefix+hex.EncodeToString(write.KeyHash)] = writeString(write.IsDelete, []byte(hex.EncodeToString(write.ValueHash)))
+			}
+		}
+	}
+
+	return e, nil
+}
+
+func versionString(version *kvrwset.Version) string {
+	if version == nil {
+		return "(none)"
+	}
+
+	return fmt.Sprintf("%d:%d", version.BlockNum, version.TxNum)
+}
+
+func writeString(isDelete bool, value []byte) string {
+	if isDelete {
+		return "(deleted)"
+	}
+
+	return string(value)
+}
+
+// print writes the endorsements followed by a table of the differences between them
+func (e *explanation) print(out io.Writer) {
+	for _, endorsement := range e.Endorsements {
+		fmt.Fprintf(out, "Endorsement from %s:\n", endorsement.Peer)
+		if endorsement.Message != "" {
+			fmt.Fprintf(out, "  Status: %d (%s)\n", endorsement.Status, endorsement.Message)
+		} else {
+			fmt.Fprintf(out, "  Status: %d\n", endorsement.Status)
+		}
+		fmt.Fprintf(out, "  Payload: %s\n", endorsement.Payload)
+
+		if endorsement.Event != nil {
+			fmt.Fprintf(out, "  Event: %s %s\n", endorsement.Event.Name, endorsement.Event.Payload)
+		}
+
+		for _, key := range sortedKeys(endorsement.Reads) {
+			fmt.Fprintf(out, "  Read: %s (version %s)\n", key, endorsement.Reads[key])
+		}
+
+		for _, key := range sortedKeys(endorsement.Writes) {
+			fmt.Fprintf(out, "  Write: %s = %s\n", key, endorsement.Writes[key])
+		}
+	}
+
+	if len(e.Differences) == 0 {
+		fmt.Fprintln(out, "All endorsements match")
+		return
+	}
+
+	fmt.Fprintln(out, "Differences:")
+
+	w := tabwriter.NewWriter(out, 4, 4, 4, ' ', 0)
+
+	fmt.Fprint(w, "KEY")
+	for _, endorsement := range e.Endorsements {
+		fmt.Fprintf(w, "\t%s", endorsement.Peer)
+	}
+	fmt.Fprintln(w)
+
+	for _, d := range e.Differences {
+		fmt.Fprint(w, d.Key)
+		for _, endorsement := range e.Endorsements {
+			fmt.Fprintf(w, "\t%s", d.Values[endorsement.Peer])
+		}
+		fmt.Fprintln(w)
+	}
+
+	w.Flush()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
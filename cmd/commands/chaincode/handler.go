@@ -0,0 +1,117 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// discoveryTargetsHandler targets all peers found by the discovery service of the channel
+type discoveryTargetsHandler struct {
+	next invoke.Handler
+}
+
+// Handle sets the request targets to the discovered peers
+func (h *discoveryTargetsHandler) Handle(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext) {
+	peers, err := clientContext.Discovery.GetPeers()
+	if err != nil {
+		requestContext.Error = fmt.Errorf("failed to discover endorsers: %s", err)
+		return
+	}
+
+	if len(peers) == 0 {
+		requestContext.Error = fmt.Errorf("no endorsers discovered")
+		return
+	}
+
+	requestContext.Opts.Targets = peers
+
+	h.next.Handle(requestContext, clientContext)
+}
+
+// submitHandler sends the endorsed transaction to the orderer and, when wait is set,
+// waits for the transaction to be committed
+type submitHandler struct {
+	wait bool
+
+	sent        bool
+	blockNumber uint64
+}
+
+// Handle submits the transaction
+func (h *submitHandler) Handle(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext) {
+	txID := string(requestContext.Response.TransactionID)
+
+	var statusCh <-chan *fab.TxStatusEvent
+	if h.wait {
+		reg, ch, err := clientContext.EventService.RegisterTxStatusEvent(txID)
+		if err != nil {
+			requestContext.Error = fmt.Errorf("failed to register for transaction status: %s", err)
+			return
+		}
+		defer clientContext.EventService.Unregister(reg)
+
+		statusCh = ch
+	}
+
+	tx, err := clientContext.Transactor.CreateTransaction(fab.TransactionRequest{
+		Proposal:          requestContext.Response.Proposal,
+		ProposalResponses: requestContext.Response.Responses,
+	})
+	if err != nil {
+		requestContext.Error = fmt.Errorf("failed to create transaction: %s", err)
+		return
+	}
+
+	if _, err := clientContext.Transactor.SendTransaction(tx); err != nil {
+		requestContext.Error = fmt.Errorf("failed to send transaction: %s", err)
+		return
+	}
+
+	h.sent = true
+
+	if !h.wait {
+		return
+	}
+
+	select {
+	case status := <-statusCh:
+		requestContext.Response.TxValidationCode = status.TxValidationCode
+		h.blockNumber = status.BlockNumber
+	case <-requestContext.Ctx.Done():
+		requestContext.Error = fmt.Errorf("timed out waiting for transaction '%s' to be committed", txID)
+	}
+}
+
+// newInvokeHandler returns the handler chain used to endorse and submit a transaction.
+// With endorseOnly the transaction is never submitted and with explain it is only
+// submitted when all endorsements match.
+func newInvokeHandler(fromDiscovery, endorseOnly, explain bool, submit *submitHandler) invoke.Handler {
+	var next invoke.Handler = invoke.NewEndorsementValidationHandler(
+		invoke.NewSignatureValidationHandler(submit),
+	)
+
+	if explain {
+		next = &matchHandler{next: next}
+	}
+
+	var handler invoke.Handler
+	if endorseOnly {
+		handler = invoke.NewSelectAndEndorseHandler()
+	} else {
+		handler = invoke.NewSelectAndEndorseHandler(next)
+	}
+
+	if fromDiscovery {
+		return &discoveryTargetsHandler{next: handler}
+	}
+
+	return handler
+}
@@ -9,7 +9,10 @@ package chaincode
 import (
 	"errors"
 	"fmt"
+	"go/build"
 	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
@@ -29,8 +32,10 @@ func NewChaincodeInstallCommand(settings *environment.Settings) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install <chaincode-name> <version> <path>",
 		Short: "Install a chaincode",
-		Long:  "Install a chaincode to current context's peers",
-		Args:  c.ParseArgs(),
+		Long: "Install a chaincode to current context's peers. The path is either a code package file, the directory\n" +
+			"of a Node.js or Java project or the directory or import path of Go chaincode in GOPATH. Index\n" +
+			"definitions in the META-INF directory of the chaincode are validated and included in the package.",
+		Args: c.ParseArgs(),
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := c.Complete(); err != nil {
 				return err
@@ -51,6 +56,10 @@ func NewChaincodeInstallCommand(settings *environment.Settings) *cobra.Command {
 	c.AddArg(&c.ChaincodeVersion)
 	c.AddArg(&c.ChaincodePath)
 
+	flags := cmd.Flags()
+	flags.StringVar(&c.ChaincodeLang, "lang", langGolang, "Set the chaincode language (golang, node or java)")
+	flags.StringVar(&c.GoPath, "gopath", build.Default.GOPATH, "Set the GOPATH of Go chaincode")
+
 	cmd.SetOutput(c.Settings.Streams.Out)
 
 	return cmd
@@ -63,6 +72,8 @@ type InstallCommand struct {
 	ChaincodeName    string
 	ChaincodeVersion string
 	ChaincodePath    string
+	ChaincodeLang    string
+	GoPath           string
 }
 
 // Validate checks the required parameters for run
@@ -79,6 +90,12 @@ func (c *InstallCommand) Validate() error {
 		return errors.New("chaincode path not specified")
 	}
 
+	switch c.ChaincodeLang {
+	case "", langGolang, langNode, langJava:
+	default:
+		return fmt.Errorf("unsupported chaincode language '%s'", c.ChaincodeLang)
+	}
+
 	return nil
 }
 
@@ -89,18 +106,18 @@ func (c *InstallCommand) Run() error {
 		return err
 	}
 
-	pkg, err := ioutil.ReadFile(c.ChaincodePath)
+	pkg, err := c.codePackage()
 	if err != nil {
 		return err
 	}
 
 	req := resmgmt.InstallCCRequest{
 		Name:    c.ChaincodeName,
-		Path:    c.ChaincodePath,
+		Path:    pkg.Path,
 		Version: c.ChaincodeVersion,
 		Package: &resource.CCPackage{
-			Type: peer.ChaincodeSpec_GOLANG,
-			Code: pkg,
+			Type: pkg.Type,
+			Code: pkg.Code,
 		},
 	}
 
@@ -117,3 +134,26 @@ func (c *InstallCommand) Run() error {
 
 	return nil
 }
+
+// codePackage reads the code package file or packages the chaincode source at the chaincode path
+func (c *InstallCommand) codePackage() (*codePackage, error) {
+	lang := c.ChaincodeLang
+	if lang == "" {
+		lang = langGolang
+	}
+
+	if info, err := os.Stat(c.ChaincodePath); err == nil && info.Mode().IsRegular() {
+		code, err := ioutil.ReadFile(c.ChaincodePath)
+		if err != nil {
+			return nil, err
+		}
+
+		return &codePackage{
+			Type: peer.ChaincodeSpec_Type(peer.ChaincodeSpec_Type_value[strings.ToUpper(lang)]),
+			Path: c.ChaincodePath,
+			Code: code,
+		}, nil
+	}
+
+	return newCodePackage(lang, c.ChaincodePath, c.GoPath)
+}
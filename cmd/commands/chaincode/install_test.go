@@ -7,11 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
@@ -140,6 +146,17 @@ var _ = Describe("ChaincodeInstallImplementation", func() {
 			It("should succeed with all arguments", func() {
 				Expect(err).To(BeNil())
 			})
+
+			Context("when the language is not supported", func() {
+				BeforeEach(func() {
+					impl.ChaincodeLang = "python"
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("unsupported chaincode language 'python'"))
+				})
+			})
 		})
 	})
 
@@ -194,6 +211,207 @@ var _ = Describe("ChaincodeInstallImplementation", func() {
 			})
 		})
 
+		Context("when the chaincode path is a source directory", func() {
+			var dir string
+
+			writeFiles := func(files map[string]string) {
+				for name, content := range files {
+					file := filepath.Join(dir, filepath.FromSlash(name))
+					Expect(os.MkdirAll(filepath.Dir(file), 0755)).To(Succeed())
+					Expect(ioutil.WriteFile(file, []byte(content), 0644)).To(Succeed())
+				}
+			}
+
+			packageFiles := func() []string {
+				Expect(client.InstallCCCallCount()).To(Equal(1))
+				req, _ := client.InstallCCArgsForCall(0)
+
+				gr, err := gzip.NewReader(bytes.NewReader(req.Package.Code))
+				Expect(err).To(BeNil())
+
+				var names []string
+				tr := tar.NewReader(gr)
+				for {
+					header, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					Expect(err).To(BeNil())
+
+					names = append(names, header.Name)
+				}
+
+				return names
+			}
+
+			const index = `{"index": {"fields": ["owner"]}, "name": "ownerIndex", "type": "json"}`
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "chaincode")
+				Expect(err).To(BeNil())
+
+				settings.Config = &environment.Config{
+					Contexts: map[string]*environment.Context{
+						"foo": {},
+					},
+					CurrentContext: "foo",
+				}
+
+				client.InstallCCReturns([]resmgmt.InstallCCResponse{}, nil)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			Context("when the chaincode is written in Go", func() {
+				BeforeEach(func() {
+					impl.GoPath = dir
+					impl.ChaincodePath = "github.com/example/mycc"
+
+					writeFiles(map[string]string{
+						"src/github.com/example/mycc/main.go":                                     "package main",
+						"src/github.com/example/mycc/README.md":                                   "# mycc",
+						"src/github.com/example/mycc/.git/config":                                 "[core]",
+						"src/github.com/example/mycc/META-INF/statedb/couchdb/indexes/owner.json": index,
+					})
+				})
+
+				It("should package the source in GOPATH layout", func() {
+					Expect(err).To(BeNil())
+
+					req, _ := client.InstallCCArgsForCall(0)
+					Expect(req.Path).To(Equal("github.com/example/mycc"))
+					Expect(req.Package.Type).To(Equal(pb.ChaincodeSpec_GOLANG))
+					Expect(packageFiles()).To(ConsistOf(
+						"META-INF/statedb/couchdb/indexes/owner.json",
+						"src/github.com/example/mycc/main.go",
+					))
+				})
+
+				Context("when the path is a directory in GOPATH", func() {
+					BeforeEach(func() {
+						impl.ChaincodePath = filepath.Join(dir, "src", "github.com", "example", "mycc")
+					})
+
+					It("should use the import path", func() {
+						Expect(err).To(BeNil())
+
+						req, _ := client.InstallCCArgsForCall(0)
+						Expect(req.Path).To(Equal("github.com/example/mycc"))
+					})
+				})
+
+				Context("when the chaincode is not in GOPATH", func() {
+					BeforeEach(func() {
+						impl.ChaincodePath = "github.com/example/othercc"
+					})
+
+					It("should fail", func() {
+						Expect(err).NotTo(BeNil())
+						Expect(err.Error()).To(Equal(fmt.Sprintf("chaincode 'github.com/example/othercc' not found in GOPATH '%s'", dir)))
+					})
+				})
+			})
+
+			Context("when the chaincode is written in Node.js", func() {
+				BeforeEach(func() {
+					impl.ChaincodeLang = "node"
+					impl.ChaincodePath = dir
+
+					writeFiles(map[string]string{
+						"package.json":                      `{"name": "mycc"}`,
+						"lib/contract.js":                   "module.exports = {}",
+						"node_modules/fabric-shim/index.js": "module.exports = {}",
+						"META-INF/statedb/couchdb/collections/private/indexes/owner.json": index,
+					})
+				})
+
+				It("should package the project with the metadata", func() {
+					Expect(err).To(BeNil())
+
+					req, _ := client.InstallCCArgsForCall(0)
+					Expect(req.Package.Type).To(Equal(pb.ChaincodeSpec_NODE))
+					Expect(packageFiles()).To(ConsistOf(
+						"META-INF/statedb/couchdb/collections/private/indexes/owner.json",
+						"src/lib/contract.js",
+						"src/node_modules/fabric-shim/index.js",
+						"src/package.json",
+					))
+				})
+
+				Context("when package.json is missing", func() {
+					BeforeEach(func() {
+						Expect(os.Remove(filepath.Join(dir, "package.json"))).To(Succeed())
+					})
+
+					It("should fail", func() {
+						Expect(err).NotTo(BeNil())
+						Expect(err.Error()).To(Equal(fmt.Sprintf("chaincode directory '%s' contains none of package.json", dir)))
+					})
+				})
+			})
+
+			Context("when the chaincode is written in Java", func() {
+				BeforeEach(func() {
+					impl.ChaincodeLang = "java"
+					impl.ChaincodePath = dir
+
+					writeFiles(map[string]string{
+						"build.gradle":                         "plugins { id 'java' }",
+						"src/main/java/org/example/MyCC.java":  "package org.example;",
+						"build/classes/org/example/MyCC.class": "cafebabe",
+						"target/mycc.jar":                      "jar",
+					})
+				})
+
+				It("should package the project sources", func() {
+					Expect(err).To(BeNil())
+
+					req, _ := client.InstallCCArgsForCall(0)
+					Expect(req.Package.Type).To(Equal(pb.ChaincodeSpec_JAVA))
+					Expect(packageFiles()).To(ConsistOf(
+						"build.gradle",
+						"src/main/java/org/example/MyCC.java",
+					))
+				})
+			})
+
+			Context("when an index definition is invalid", func() {
+				BeforeEach(func() {
+					impl.ChaincodeLang = "node"
+					impl.ChaincodePath = dir
+
+					writeFiles(map[string]string{
+						"package.json": `{"name": "mycc"}`,
+						"META-INF/statedb/couchdb/indexes/owner.json": `{"name": "ownerIndex"}`,
+					})
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid index definition 'META-INF/statedb/couchdb/indexes/owner.json': index fields not specified"))
+				})
+			})
+
+			Context("when a metadata file is not an index definition", func() {
+				BeforeEach(func() {
+					impl.ChaincodeLang = "node"
+					impl.ChaincodePath = dir
+
+					writeFiles(map[string]string{
+						"package.json":        `{"name": "mycc"}`,
+						"META-INF/notes.json": "{}",
+					})
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(HavePrefix("invalid metadata file 'META-INF/notes.json'"))
+				})
+			})
+		})
+
 		Context("when resmgmt client fails", func() {
 			BeforeEach(func() {
 				settings.Config = &environment.Config{
@@ -7,15 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/spf13/cobra"
 
 	"github.com/hyperledger/fabric-cli/cmd/commands/common"
 	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
 )
 
 // NewChaincodeInvokeCommand creates a new "fabric chaincode invoke" command
@@ -41,6 +47,8 @@ func NewChaincodeInvokeCommand(settings *environment.Settings) *cobra.Command {
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
 			return c.Run()
 		},
 	}
@@ -50,7 +58,37 @@ func NewChaincodeInvokeCommand(settings *environment.Settings) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&c.ChaincodeFcn, "fcn", "", "set the invoke function")
 	flags.StringArrayVar(&c.ChaincodeArgs, "args", []string{}, "set the invoke arguments")
+	flags.StringVarP(&c.Ctor, "ctor", "c", "", "set the invoke function and arguments as peer CLI JSON, e.g. {\"Args\":[\"fcn\",\"arg1\"]}")
+	flags.StringArrayVar(&c.NamedArgs, "arg", []string{},
+		"set a named invoke argument as name=value, ordered by the contract metadata (this option may be specified multiple times)")
+	flags.BoolVar(&c.ValidateArgs, "validate", false, "validate the arguments against the contract metadata before sending the proposal")
+	flags.StringVar(&c.ArgsFile, "args-file", "", "set the path to a JSON file containing an array of invoke arguments or a constructor")
+	flags.StringVar(&c.ArgEncoding, "arg-encoding", common.ArgEncodingUTF8, common.ArgEncodingUsage)
 	flags.BoolVar(&c.IsInit, "is-init", false, "indicates whether or not this invocation is meant to initialize the chaincode")
+	flags.StringArrayVar(&c.Transient, "transient", []string{}, "set a transient data entry as key=value (this option may be specified multiple times)")
+	flags.StringVar(&c.TransientFile, "transient-file", "", "set the path to a JSON file containing an object of transient data values")
+	flags.StringVar(&c.TransientEncoding, "transient-encoding", common.TransientEncodingUTF8,
+		"set the encoding of the transient data values (utf8, base64 or hex)")
+	flags.StringArrayVar(&c.Peers, "peer", []string{}, "set an endorsing peer (this option may be specified multiple times)")
+	flags.BoolVar(&c.EndorsersFromDiscovery, "endorsers-from-discovery", false, "request endorsements from all peers found by the discovery service")
+	flags.BoolVar(&c.Async, "async", false, "return the transaction ID once the transaction is sent to the orderer")
+	flags.BoolVar(&c.Wait, "wait", true, "wait for the transaction to be committed")
+	flags.DurationVar(&c.Timeout, "timeout", 0, "set the time to wait for the transaction to be committed (defaults to the network configuration)")
+	flags.BoolVar(&c.Explain, "explain", false, "show the read/write sets of each endorser and only submit the transaction if all endorsements match")
+	flags.BoolVar(&c.EndorseOnly, "endorse-only", false, "show the read/write sets of each endorser without submitting the transaction")
+	flags.StringVar(&c.Batch, "batch", "",
+		"set the path to a JSONL file ({\"fcn\":...,\"args\":[...],\"transient\":{...}} per line) or CSV file of transactions to invoke")
+	flags.StringVar(&c.BatchResults, "results", "",
+		"set the path of the JSONL results file of a batch (defaults to the batch file with suffix "+batchResultsSuffix+")")
+	flags.BoolVar(&c.Resume, "resume", false, "skip the batch lines which are committed according to the results file")
+	flags.IntVar(&c.Concurrency, "concurrency", 1, "set the number of concurrent batch transactions")
+	flags.Float64Var(&c.Rate, "rate", 0, "set the maximum number of batch transactions per second (0 for no limit)")
+	flags.IntVar(&c.MaxRetries, "max-retries", 0,
+		"set the number of times a batch transaction is retried after a transient failure "+
+			"(connection, endorsement mismatch or read conflict)")
+	flags.DurationVar(&c.RetryBackoff, "retry-backoff", time.Second,
+		"set the initial wait before retrying a failed batch transaction, doubled for each retry")
+	flags.StringVar(&c.OutputFormat, "output", "", "set the output format, 'json' or human-readable text if not set")
 
 	cmd.SetOutput(c.Settings.Streams.Out)
 
@@ -65,7 +103,44 @@ type InvokeCommand struct {
 
 	ChaincodeFcn  string
 	ChaincodeArgs []string
+	Ctor          string
+	ArgsFile      string
+	ArgEncoding   string
+	NamedArgs     []string
+	ValidateArgs  bool
 	IsInit        bool
+
+	Transient         []string
+	TransientFile     string
+	TransientEncoding string
+
+	Peers                  []string
+	EndorsersFromDiscovery bool
+	Async                  bool
+	Wait                   bool
+	Timeout                time.Duration
+	Explain                bool
+	EndorseOnly            bool
+	OutputFormat           string
+
+	Batch        string
+	BatchResults string
+	Resume       bool
+	Concurrency  int
+	Rate         float64
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+// invokeResult is the outcome of a chaincode invocation
+type invokeResult struct {
+	TxID           string   `json:"tx_id"`
+	ValidationCode string   `json:"validation_code,omitempty"`
+	BlockNumber    *uint64  `json:"block_number,omitempty"`
+	Endorsers      []string `json:"endorsers"`
+	Payload        string   `json:"payload"`
+
+	Explanation *explanation `json:"explanation,omitempty"`
 }
 
 // Validate checks the required parameters for run
@@ -74,29 +149,221 @@ func (c *InvokeCommand) Validate() error {
 		return errors.New("chaincode name not specified")
 	}
 
+	if err := validateNamedArgs(c.NamedArgs, c.ChaincodeFcn, c.ChaincodeArgs, c.Ctor, c.ArgsFile); err != nil {
+		return err
+	}
+
+	if c.Batch != "" {
+		return c.validateBatch()
+	}
+
+	if c.Explain && c.EndorseOnly {
+		return errors.New("--explain cannot be combined with --endorse-only")
+	}
+
+	if len(c.Peers) > 0 && c.EndorsersFromDiscovery {
+		return errors.New("--peer cannot be combined with --endorsers-from-discovery")
+	}
+
+	if c.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+func (c *InvokeCommand) validateBatch() error {
+	if c.ChaincodeFcn != "" || len(c.ChaincodeArgs) > 0 || c.Ctor != "" || c.ArgsFile != "" ||
+		len(c.Transient) > 0 || c.TransientFile != "" {
+		return errors.New("--batch cannot be combined with a function, arguments or transient data")
+	}
+
+	if len(c.Peers) > 0 || c.EndorsersFromDiscovery || c.Async || c.Explain || c.EndorseOnly || c.IsInit {
+		return errors.New("--batch cannot be combined with --peer, --endorsers-from-discovery, --async, --explain, --endorse-only or --is-init")
+	}
+
+	if !c.Wait || c.Timeout != 0 || c.OutputFormat != "" {
+		return errors.New("--batch cannot be combined with --wait, --timeout or --output")
+	}
+
+	if c.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+
+	if c.Rate < 0 {
+		return errors.New("rate must not be negative")
+	}
+
+	if c.MaxRetries < 0 {
+		return errors.New("max retries must not be negative")
+	}
+
 	return nil
 }
 
 // Run executes the command
 func (c *InvokeCommand) Run() error {
-	fcn := c.ChaincodeFcn
+	if c.Batch != "" {
+		return c.runBatch()
+	}
+
+	fcn, args, err := common.GetChaincodeArgs(c.ChaincodeFcn, c.ChaincodeArgs, c.Ctor, c.ArgsFile, c.ArgEncoding)
+	if err != nil {
+		return err
+	}
+
+	args, err = getContractArgs(c.Channel, c.ChaincodeName, &contractArgs{
+		fcn:      fcn,
+		args:     args,
+		named:    c.NamedArgs,
+		encoding: c.ArgEncoding,
+		validate: c.ValidateArgs,
+	})
+	if err != nil {
+		return err
+	}
+
 	if c.IsInit {
 		fcn = "Init"
 	}
 
+	transientMap, err := common.GetTransientMap(c.Transient, c.TransientFile, c.TransientEncoding)
+	if err != nil {
+		return err
+	}
+
 	req := channel.Request{
-		ChaincodeID: c.ChaincodeName,
-		Fcn:         fcn,
-		Args:        common.AsByteArgs(c.ChaincodeArgs),
-		IsInit:      c.IsInit,
+		ChaincodeID:  c.ChaincodeName,
+		Fcn:          fcn,
+		Args:         args,
+		TransientMap: transientMap,
+		IsInit:       c.IsInit,
+	}
+
+	if submitter, ok := c.Channel.(fabric.Submitter); ok {
+		return c.submit(submitter, req)
 	}
 
-	resp, err := c.Channel.Execute(req, channel.WithRetry(retry.DefaultChannelOpts))
+	options := []channel.RequestOption{channel.WithRetry(retry.DefaultChannelOpts)}
+	if len(c.Peers) > 0 {
+		options = append(options, channel.WithTargetEndpoints(c.Peers...))
+	}
+	if c.Timeout > 0 {
+		options = append(options, channel.WithTimeout(fab.Execute, c.Timeout))
+	}
+
+	submit := &submitHandler{wait: c.Wait && !c.Async}
+
+	handler := newInvokeHandler(c.EndorsersFromDiscovery, c.EndorseOnly, c.Explain, submit)
+
+	resp, err := c.Channel.InvokeHandler(handler, req, options...)
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintln(c.Settings.Streams.Out, string(resp.Payload))
+	result := &invokeResult{
+		TxID:      string(resp.TransactionID),
+		Endorsers: []string{},
+		Payload:   string(resp.Payload),
+	}
+
+	for _, r := range resp.Responses {
+		result.Endorsers = append(result.Endorsers, r.Endorser)
+	}
+
+	if c.Explain || c.EndorseOnly {
+		result.Explanation, err = explain(resp.Responses)
+		if err != nil {
+			return err
+		}
+	}
+
+	submitted := !c.Explain && !c.EndorseOnly || submit.sent
+
+	if submitted && submit.wait {
+		result.ValidationCode = resp.TxValidationCode.String()
+		result.BlockNumber = &submit.blockNumber
+	}
+
+	if err := c.print(result, submitted); err != nil {
+		return err
+	}
+
+	if result.Explanation != nil && len(result.Explanation.Differences) > 0 {
+		return fmt.Errorf("endorsements from %d peers do not match", len(result.Endorsers))
+	}
+
+	if result.BlockNumber != nil && resp.TxValidationCode != pb.TxValidationCode_VALID {
+		return fmt.Errorf("transaction '%s' is invalid: %s", result.TxID, result.ValidationCode)
+	}
+
+	return nil
+}
+
+// submit submits the transaction through a channel which selects the endorsers itself, such as the gateway
+func (c *InvokeCommand) submit(submitter fabric.Submitter, req channel.Request) error {
+	if len(c.Peers) > 0 || c.EndorsersFromDiscovery || c.Explain || c.EndorseOnly {
+		return errors.New("--peer, --endorsers-from-discovery, --explain and --endorse-only are not supported by the gateway")
+	}
+
+	wait := c.Wait && !c.Async
+
+	resp, blockNumber, err := submitter.Submit(req, wait, c.Timeout)
+	if err != nil {
+		return err
+	}
+
+	result := &invokeResult{
+		TxID:      string(resp.TransactionID),
+		Endorsers: []string{},
+		Payload:   string(resp.Payload),
+	}
+
+	if wait {
+		result.ValidationCode = resp.TxValidationCode.String()
+		result.BlockNumber = &blockNumber
+	}
+
+	if err := c.print(result, true); err != nil {
+		return err
+	}
+
+	if wait && resp.TxValidationCode != pb.TxValidationCode_VALID {
+		return fmt.Errorf("transaction '%s' is invalid: %s", result.TxID, result.ValidationCode)
+	}
+
+	return nil
+}
+
+func (c *InvokeCommand) print(result *invokeResult, submitted bool) error {
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		return json.NewEncoder(out).Encode(result)
+	}
+
+	if result.Explanation != nil {
+		result.Explanation.print(out)
+	}
+
+	fmt.Fprintf(out, "Transaction ID: %s\n", result.TxID)
+
+	switch {
+	case result.BlockNumber != nil:
+		fmt.Fprintf(out, "Validation code: %s\n", result.ValidationCode)
+		fmt.Fprintf(out, "Block number: %d\n", *result.BlockNumber)
+	case submitted:
+		fmt.Fprintln(out, "Status: submitted")
+	default:
+		fmt.Fprintln(out, "Status: not submitted")
+	}
+
+	fmt.Fprintf(out, "Endorsers: %s\n", strings.Join(result.Endorsers, ", "))
+	fmt.Fprintf(out, "Payload: %s\n", result.Payload)
 
 	return nil
 }
@@ -8,18 +8,31 @@ package chaincode_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
-
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 
 	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
 	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
 	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
-	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
 )
 
 var _ = Describe("ChaincodeInvokeCommand", func() {
@@ -57,6 +70,11 @@ var _ = Describe("ChaincodeInvokeCommand", func() {
 		Expect(cmd.HasSubCommands()).To(BeFalse())
 	})
 
+	It("should accept the peer CLI constructor shorthand", func() {
+		Expect(cmd.Flags().ShorthandLookup("c")).To(Equal(cmd.Flag("ctor")))
+		Expect(cmd.Flag("arg-encoding").DefValue).To(Equal("utf8"))
+	})
+
 	It("should provide a help prompt", func() {
 		os.Args = append(os.Args, "--help")
 
@@ -127,6 +145,86 @@ var _ = Describe("ChaincodeInvokeImplementation", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		Context("when --peer and --endorsers-from-discovery are set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.Peers = []string{"peer1"}
+				impl.EndorsersFromDiscovery = true
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--peer cannot be combined with --endorsers-from-discovery"))
+			})
+		})
+
+		Context("when --batch is combined with arguments", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.Batch = "input.jsonl"
+				impl.Concurrency = 1
+				impl.ChaincodeArgs = []string{"a"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--batch cannot be combined with a function, arguments or transient data"))
+			})
+		})
+
+		Context("when --batch is combined with --timeout", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.Batch = "input.jsonl"
+				impl.Concurrency = 1
+				impl.Wait = true
+				impl.Timeout = time.Minute
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--batch cannot be combined with --wait, --timeout or --output"))
+			})
+		})
+
+		Context("when --batch is combined with --wait=false", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.Batch = "input.jsonl"
+				impl.Concurrency = 1
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--batch cannot be combined with --wait, --timeout or --output"))
+			})
+		})
+
+		Context("when --batch has an invalid concurrency", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.Batch = "input.jsonl"
+				impl.Wait = true
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("concurrency must be at least 1"))
+			})
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.OutputFormat = "yaml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'yaml'"))
+			})
+		})
 	})
 
 	Describe("Run", func() {
@@ -144,7 +242,7 @@ var _ = Describe("ChaincodeInvokeImplementation", func() {
 
 		Context("when channel client succeeds", func() {
 			BeforeEach(func() {
-				client.ExecuteReturns(channel.Response{}, nil)
+				client.InvokeHandlerReturns(channel.Response{}, nil)
 			})
 
 			It("should successfully invoke chaincode", func() {
@@ -152,9 +250,553 @@ var _ = Describe("ChaincodeInvokeImplementation", func() {
 			})
 		})
 
+		Context("when the transaction is submitted asynchronously", func() {
+			BeforeEach(func() {
+				impl.Async = true
+				impl.Peers = []string{"peer1", "peer2"}
+
+				client.InvokeHandlerReturns(channel.Response{
+					TransactionID: "tx1",
+					Responses: []*fab.TransactionProposalResponse{
+						{Endorser: "peer1"},
+						{Endorser: "peer2"},
+					},
+					Payload: []byte("result"),
+				}, nil)
+			})
+
+			It("should print the transaction ID without waiting", func() {
+				Expect(err).To(BeNil())
+
+				_, _, opts := client.InvokeHandlerArgsForCall(0)
+				Expect(opts).To(HaveLen(2))
+				Expect(fmt.Sprint(out)).To(Equal("Transaction ID: tx1\nStatus: submitted\n" +
+					"Endorsers: peer1, peer2\nPayload: result\n"))
+			})
+		})
+
+		Context("when waiting for the transaction to be committed", func() {
+			BeforeEach(func() {
+				impl.Wait = true
+				impl.OutputFormat = "json"
+
+				client.InvokeHandlerReturns(channel.Response{
+					TransactionID: "tx1",
+					Responses: []*fab.TransactionProposalResponse{
+						{Endorser: "peer1"},
+					},
+					Payload: []byte("result"),
+				}, nil)
+			})
+
+			It("should print the validation code", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(MatchJSON(`{
+					"tx_id": "tx1",
+					"validation_code": "VALID",
+					"block_number": 0,
+					"endorsers": ["peer1"],
+					"payload": "result"
+				}`))
+			})
+		})
+
+		Context("when the committed transaction is invalid", func() {
+			BeforeEach(func() {
+				impl.Wait = true
+
+				client.InvokeHandlerReturns(channel.Response{
+					TransactionID:    "tx1",
+					TxValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT,
+				}, nil)
+			})
+
+			It("should fail with the validation code", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("transaction 'tx1' is invalid: MVCC_READ_CONFLICT"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Validation code: MVCC_READ_CONFLICT"))
+			})
+		})
+
+		Context("when --endorse-only is set", func() {
+			BeforeEach(func() {
+				impl.EndorseOnly = true
+
+				client.InvokeHandlerReturns(channel.Response{
+					TransactionID: "tx1",
+					Responses: []*fab.TransactionProposalResponse{
+						newProposalResponse("peer1", "a", "1"),
+						newProposalResponse("peer2", "a", "2"),
+					},
+				}, nil)
+			})
+
+			It("should show the writes of each peer", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("endorsements from 2 peers do not match"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Endorsement from peer1:\n  Status: 200\n  Payload: ok\n  Write: mycc/a = 1\n"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Endorsement from peer2:\n  Status: 200\n  Payload: ok\n  Write: mycc/a = 2\n"))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`KEY\s+peer1\s+peer2\n`))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`write mycc/a\s+1\s+2\n`))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Status: not submitted"))
+			})
+
+			Context("when the endorsements match", func() {
+				BeforeEach(func() {
+					client.InvokeHandlerReturns(channel.Response{
+						TransactionID: "tx1",
+						Responses: []*fab.TransactionProposalResponse{
+							newProposalResponse("peer1", "a", "1"),
+							newProposalResponse("peer2", "a", "1"),
+						},
+					}, nil)
+				})
+
+				It("should report no differences", func() {
+					Expect(err).To(BeNil())
+					Expect(fmt.Sprint(out)).To(ContainSubstring("All endorsements match"))
+				})
+			})
+
+			Context("when the output format is set to json", func() {
+				BeforeEach(func() {
+					impl.OutputFormat = "json"
+				})
+
+				It("should include the differences", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(fmt.Sprint(out)).To(ContainSubstring(`"differences":[{"key":"write mycc/a","values":{"peer1":"1","peer2":"2"}}]`))
+				})
+			})
+		})
+
+		Context("when the channel is a gateway and --explain is set", func() {
+			BeforeEach(func() {
+				impl.Explain = true
+				impl.Channel = fabric.NewGatewayChannel(nil, "mychannel", nil)
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("not supported by the gateway"))
+			})
+		})
+
+		Context("when --batch is set", func() {
+			var dir string
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "batch")
+				Expect(err).To(BeNil())
+
+				impl.Batch = filepath.Join(dir, "input.jsonl")
+				impl.Concurrency = 2
+				impl.TransientEncoding = "utf8"
+
+				Expect(ioutil.WriteFile(impl.Batch, []byte(
+					`{"fcn":"put","args":["a","1"]}`+"\n"+
+						`{"fcn":"put","args":["b","2"],"transient":{"secret":"s"}}`+"\n"+
+						"\n"+
+						`not json`+"\n"+
+						`{"fcn":"put","args":["c","3"]}`+"\n"), 0600)).To(Succeed())
+
+				client.ExecuteStub = func(req channel.Request, _ ...channel.RequestOption) (channel.Response, error) {
+					if string(req.Args[0]) == "c" {
+						return channel.Response{TransactionID: "txc"}, status.New(status.EventServerStatus,
+							int32(pb.TxValidationCode_MVCC_READ_CONFLICT), "received invalid transaction", nil)
+					}
+
+					return channel.Response{TransactionID: fab.TransactionID("tx" + string(req.Args[0]))}, nil
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			readResults := func() map[int]map[string]interface{} {
+				data, err := ioutil.ReadFile(impl.Batch + ".results.jsonl")
+				Expect(err).To(BeNil())
+
+				results := make(map[int]map[string]interface{})
+				for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+					var result map[string]interface{}
+					Expect(json.Unmarshal([]byte(line), &result)).To(Succeed())
+					results[int(result["line"].(float64))] = result
+				}
+
+				return results
+			}
+
+			It("should write the result of each line", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("2 of 4 transactions failed"))
+				Expect(client.ExecuteCallCount()).To(Equal(3))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Batch complete: 2 committed, 2 failed, 0 skipped"))
+
+				results := readResults()
+				Expect(results).To(HaveLen(4))
+				Expect(results[1]).To(Equal(map[string]interface{}{"line": 1.0, "tx_id": "txa", "status": "VALID", "attempts": 1.0}))
+				Expect(results[2]["status"]).To(Equal("VALID"))
+				Expect(results[4]["status"]).To(Equal("INVALID_INPUT"))
+				Expect(results[5]["status"]).To(Equal("MVCC_READ_CONFLICT"))
+				Expect(results[5]["tx_id"]).To(Equal("txc"))
+			})
+
+			It("should send the transient data", func() {
+				for i := 0; i < client.ExecuteCallCount(); i++ {
+					req, _ := client.ExecuteArgsForCall(i)
+					if string(req.Args[0]) == "b" {
+						Expect(req.TransientMap).To(Equal(map[string][]byte{"secret": []byte("s")}))
+					}
+				}
+			})
+
+			Context("when retries are set", func() {
+				BeforeEach(func() {
+					impl.MaxRetries = 2
+					impl.RetryBackoff = time.Millisecond
+				})
+
+				It("should retry failed transactions", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(client.ExecuteCallCount()).To(Equal(5))
+					Expect(readResults()[5]["attempts"]).To(Equal(3.0))
+				})
+
+				Context("when the transaction fails in the orderer", func() {
+					BeforeEach(func() {
+						client.ExecuteReturns(channel.Response{TransactionID: "tx"},
+							status.New(status.OrdererServerStatus, int32(common.Status_SERVICE_UNAVAILABLE), "broadcast failed", nil))
+						client.ExecuteStub = nil
+					})
+
+					It("should not retry", func() {
+						Expect(err).NotTo(BeNil())
+						Expect(client.ExecuteCallCount()).To(Equal(3))
+						Expect(readResults()[1]["attempts"]).To(Equal(1.0))
+					})
+				})
+
+				Context("when waiting for the commit times out", func() {
+					BeforeEach(func() {
+						client.ExecuteReturns(channel.Response{TransactionID: "tx"},
+							status.New(status.ClientStatus, status.Timeout.ToInt32(), "request timed out", nil))
+						client.ExecuteStub = nil
+					})
+
+					It("should not retry", func() {
+						Expect(err).NotTo(BeNil())
+						Expect(client.ExecuteCallCount()).To(Equal(3))
+					})
+				})
+			})
+
+			Context("when resuming a batch", func() {
+				BeforeEach(func() {
+					impl.Resume = true
+
+					Expect(ioutil.WriteFile(impl.Batch+".results.jsonl", []byte(
+						`{"line":1,"tx_id":"txa","status":"VALID","attempts":1}`+"\n"+
+							`{"line":5,"tx_id":"txc","status":"MVCC_READ_CONFLICT","attempts":1}`+"\n"), 0600)).To(Succeed())
+				})
+
+				It("should skip the committed lines", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(client.ExecuteCallCount()).To(Equal(2))
+					Expect(fmt.Sprint(out)).To(ContainSubstring("1 committed, 2 failed, 1 skipped"))
+
+					data, err := ioutil.ReadFile(impl.Batch + ".results.jsonl")
+					Expect(err).To(BeNil())
+					Expect(strings.Split(strings.TrimSpace(string(data)), "\n")).To(HaveLen(5))
+				})
+			})
+
+			Context("when the batch file is CSV", func() {
+				BeforeEach(func() {
+					impl.Batch = filepath.Join(dir, "input.csv")
+					Expect(ioutil.WriteFile(impl.Batch, []byte("put,a,1\nput,b,2\n"), 0600)).To(Succeed())
+				})
+
+				It("should invoke each row", func() {
+					Expect(err).To(BeNil())
+					Expect(client.ExecuteCallCount()).To(Equal(2))
+					Expect(readResults()).To(HaveLen(2))
+				})
+
+				Context("when the file has blank lines and multiline fields", func() {
+					BeforeEach(func() {
+						Expect(ioutil.WriteFile(impl.Batch, []byte("put,a,\"1\n2\"\n\nput,b,2\n"), 0600)).To(Succeed())
+					})
+
+					It("should report the line of each row", func() {
+						Expect(err).To(BeNil())
+
+						results := readResults()
+						Expect(results).To(HaveKey(1))
+						Expect(results).To(HaveKey(4))
+					})
+				})
+
+				Context("when a row is malformed", func() {
+					BeforeEach(func() {
+						Expect(ioutil.WriteFile(impl.Batch, []byte("put,a,\"1\n2\"\n\nput,b\"c,2\n"), 0600)).To(Succeed())
+					})
+
+					It("should report the line of the row", func() {
+						Expect(err).NotTo(BeNil())
+						Expect(err.Error()).To(HavePrefix("failed to read batch file: parse error on line 4,"))
+						Expect(client.ExecuteCallCount()).To(Equal(0))
+					})
+				})
+			})
+		})
+
+		Context("when named arguments are set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "CreateAsset"
+				impl.NamedArgs = []string{"size=5", "id=asset1", "color=red"}
+				impl.ValidateArgs = true
+
+				client.QueryReturns(channel.Response{Payload: []byte(contractMetadata)}, nil)
+				client.InvokeHandlerReturns(channel.Response{}, nil)
+			})
+
+			It("should send the arguments in the order of the parameters", func() {
+				Expect(err).To(BeNil())
+
+				_, req, _ := client.InvokeHandlerArgsForCall(0)
+				Expect(req.Fcn).To(Equal("CreateAsset"))
+				Expect(req.Args).To(Equal([][]byte{[]byte("asset1"), []byte("red"), []byte("5")}))
+			})
+
+			Context("when an argument is missing", func() {
+				BeforeEach(func() {
+					impl.NamedArgs = []string{"id=asset1", "color=red"}
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("argument 'size' of transaction 'CreateAsset' not specified"))
+				})
+			})
+
+			Context("when an argument is unknown", func() {
+				BeforeEach(func() {
+					impl.NamedArgs = append(impl.NamedArgs, "owner=alice")
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("transaction 'CreateAsset' has no parameter 'owner'"))
+				})
+			})
+		})
+
+		Context("when the arguments are validated", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "PutAsset"
+				impl.ChaincodeArgs = []string{`{"ID":"asset1","Size":2}`}
+				impl.ValidateArgs = true
+
+				client.QueryReturns(channel.Response{Payload: []byte(contractMetadata)}, nil)
+				client.InvokeHandlerReturns(channel.Response{}, nil)
+			})
+
+			It("should invoke the chaincode with valid arguments", func() {
+				Expect(err).To(BeNil())
+				Expect(client.InvokeHandlerCallCount()).To(Equal(1))
+			})
+
+			Context("when the pattern does not match", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "CreateAsset"
+					impl.ChaincodeArgs = []string{"car1", "red", "5"}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid argument 'id': must match pattern '^asset[0-9]+$'"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a value is not in the enum", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "CreateAsset"
+					impl.ChaincodeArgs = []string{"asset1", "green", "5"}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal(`invalid argument 'color': must be one of "red", "blue"`))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when an integer has a fraction", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "CreateAsset"
+					impl.ChaincodeArgs = []string{"asset1", "red", "5.5"}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid argument 'size': expected integer"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a number is below the minimum", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "CreateAsset"
+					impl.ChaincodeArgs = []string{"asset1", "red", "0"}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid argument 'size': must be at least 1"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when an object is not JSON", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "PutAsset"
+					impl.ChaincodeArgs = []string{"asset1"}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid argument 'asset': expected JSON object"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a required property is missing", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "PutAsset"
+					impl.ChaincodeArgs = []string{`{"Size":1}`}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid argument 'asset': missing property 'ID'"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a property has the wrong type", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "PutAsset"
+					impl.ChaincodeArgs = []string{`{"ID":"asset1","Size":"big"}`}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid argument 'asset.Size': expected integer"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the contract is unknown", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "TradeContract:Trade"
+					impl.ChaincodeArgs = []string{}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("contract 'TradeContract' not found"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the transaction is unknown", func() {
+				BeforeEach(func() {
+					impl.ChaincodeFcn = "DeleteAsset"
+					impl.ChaincodeArgs = []string{}
+				})
+
+				It("should fail without invoking the chaincode", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("transaction 'DeleteAsset' not found in contract 'AssetContract'"))
+					Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when a peer CLI constructor is set", func() {
+			BeforeEach(func() {
+				impl.Ctor = `{"Args":["move","a","b64:Yg=="]}`
+
+				client.InvokeHandlerReturns(channel.Response{}, nil)
+			})
+
+			It("should send the constructor function and arguments unchanged", func() {
+				Expect(err).To(BeNil())
+
+				_, req, _ := client.InvokeHandlerArgsForCall(0)
+				Expect(req.Fcn).To(Equal("move"))
+				Expect(req.Args).To(Equal([][]byte{[]byte("a"), []byte("b64:Yg==")}))
+			})
+
+			Context("when the arguments are prefixed", func() {
+				BeforeEach(func() {
+					impl.ArgEncoding = "prefixed"
+				})
+
+				It("should decode the arguments", func() {
+					Expect(err).To(BeNil())
+
+					_, req, _ := client.InvokeHandlerArgsForCall(0)
+					Expect(req.Args).To(Equal([][]byte{[]byte("a"), []byte("b")}))
+				})
+			})
+		})
+
+		Context("when the constructor is combined with arguments", func() {
+			BeforeEach(func() {
+				impl.Ctor = `{"Args":["move"]}`
+				impl.ChaincodeArgs = []string{"a"}
+			})
+
+			It("should fail without sending the request", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when transient data is set", func() {
+			BeforeEach(func() {
+				impl.Transient = []string{"key1=dmFsdWUx"}
+				impl.TransientEncoding = "base64"
+
+				client.InvokeHandlerReturns(channel.Response{}, nil)
+			})
+
+			It("should send the transient map", func() {
+				Expect(err).To(BeNil())
+
+				_, req, _ := client.InvokeHandlerArgsForCall(0)
+				Expect(req.TransientMap).To(Equal(map[string][]byte{"key1": []byte("value1")}))
+			})
+		})
+
+		Context("when transient data is invalid", func() {
+			BeforeEach(func() {
+				impl.Transient = []string{"key1"}
+			})
+
+			It("should fail without sending the request", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(client.InvokeHandlerCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when channel client fails", func() {
 			BeforeEach(func() {
-				client.ExecuteReturns(channel.Response{}, errors.New("invoke error"))
+				client.InvokeHandlerReturns(channel.Response{}, errors.New("invoke error"))
 			})
 
 			It("should fail to invoke chaincode invoke", func() {
@@ -164,3 +806,30 @@ var _ = Describe("ChaincodeInvokeImplementation", func() {
 		})
 	})
 })
+
+func newProposalResponse(peer, key, value string) *fab.TransactionProposalResponse {
+	kvRWSet, err := proto.Marshal(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: key, Value: []byte(value)}},
+	})
+	Expect(err).To(BeNil())
+
+	results, err := proto.Marshal(&rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{{Namespace: "mycc", Rwset: kvRWSet}},
+	})
+	Expect(err).To(BeNil())
+
+	action, err := proto.Marshal(&pb.ChaincodeAction{Results: results})
+	Expect(err).To(BeNil())
+
+	payload, err := proto.Marshal(&pb.ProposalResponsePayload{Extension: action})
+	Expect(err).To(BeNil())
+
+	return &fab.TransactionProposalResponse{
+		Endorser: peer,
+		Status:   200,
+		ProposalResponse: &pb.ProposalResponse{
+			Response: &pb.Response{Status: 200, Payload: []byte("ok")},
+			Payload:  payload,
+		},
+	}
+}
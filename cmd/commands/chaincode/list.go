@@ -7,10 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 
 	"github.com/hyperledger/fabric-cli/pkg/environment"
 )
@@ -24,9 +30,19 @@ func NewChaincodeListCommand(settings *environment.Settings) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all chaincodes",
-		Long:  "List all chaincodes in current context's peers",
+		Long: "List all chaincodes in current context's peers. Instantiated chaincodes and committed chaincode\n" +
+			"definitions are listed for the channel of the current context unless --channel or --all-channels is set.\n" +
+			"Without these flags, the channel section is skipped if the current context has no channel.",
 		PreRunE: func(_ *cobra.Command, _ []string) error {
-			return c.Complete()
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
 			return c.Run()
@@ -35,7 +51,10 @@ func NewChaincodeListCommand(settings *environment.Settings) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVar(&c.Installed, "installed", false, "Include chaincode installed on peer's filesystem")
-	flags.BoolVar(&c.Instantiated, "instantiated", false, "Include instantiated chaincode")
+	flags.BoolVar(&c.Instantiated, "instantiated", false, "Include instantiated chaincode and committed chaincode definitions")
+	flags.StringVar(&c.ChannelID, "channel", "", "List the chaincode of the given channel instead of the channel of the current context")
+	flags.BoolVar(&c.AllChannels, "all-channels", false, "List the chaincode of all channels the peer has joined")
+	flags.StringVar(&c.OutputFormat, "output", "", "Set the output format, 'json', 'yaml' or human-readable text if not set")
 
 	cmd.SetOutput(c.Settings.Streams.Out)
 
@@ -48,10 +67,71 @@ type ListCommand struct {
 
 	Installed    bool
 	Instantiated bool
+	ChannelID    string
+	AllChannels  bool
+	OutputFormat string
+}
+
+// errNoChannel is returned when neither a channel flag nor a context channel is set
+var errNoChannel = errors.New("channel not specified")
+
+// chaincodeList is the output of the chaincode list command
+type chaincodeList struct {
+	Installed []*chaincodeInfo        `json:"installed,omitempty" yaml:"installed,omitempty"`
+	Channels  []*channelChaincodeList `json:"channels,omitempty" yaml:"channels,omitempty"`
+
+	// channels were skipped because the context has no channel
+	noChannel bool
+}
+
+// channelChaincodeList contains the chaincode of a channel. The committed definitions are not
+// available on peers without the new lifecycle, in which case the query error is kept.
+type channelChaincodeList struct {
+	Channel        string                `json:"channel" yaml:"channel"`
+	Instantiated   []*chaincodeInfo      `json:"instantiated" yaml:"instantiated"`
+	Committed      []*committedChaincode `json:"committed" yaml:"committed"`
+	CommittedError string                `json:"committed_error,omitempty" yaml:"committed_error,omitempty"`
+}
+
+// chaincodeInfo is a chaincode installed or instantiated with the legacy lifecycle
+type chaincodeInfo struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
+	Escc    string `json:"escc,omitempty" yaml:"escc,omitempty"`
+	Vscc    string `json:"vscc,omitempty" yaml:"vscc,omitempty"`
+	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
+}
+
+// committedChaincode is a chaincode definition committed with the new lifecycle
+type committedChaincode struct {
+	Name              string `json:"name" yaml:"name"`
+	Version           string `json:"version" yaml:"version"`
+	Sequence          int64  `json:"sequence" yaml:"sequence"`
+	EndorsementPlugin string `json:"endorsement_plugin,omitempty" yaml:"endorsement_plugin,omitempty"`
+	ValidationPlugin  string `json:"validation_plugin,omitempty" yaml:"validation_plugin,omitempty"`
+	InitRequired      bool   `json:"init_required,omitempty" yaml:"init_required,omitempty"`
+}
+
+// Validate checks the required parameters for run
+func (c *ListCommand) Validate() error {
+	if c.ChannelID != "" && c.AllChannels {
+		return errors.New("--channel cannot be combined with --all-channels")
+	}
+
+	switch c.OutputFormat {
+	case "", jsonFormat, yamlFormat:
+	default:
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
 }
 
 // Run executes the command
 func (c *ListCommand) Run() error {
+	explicit := c.Instantiated || c.ChannelID != "" || c.AllChannels
+
 	if !c.Installed && !c.Instantiated {
 		c.Installed = true
 		c.Instantiated = true
@@ -66,29 +146,175 @@ func (c *ListCommand) Run() error {
 		resmgmt.WithTargetEndpoints(context.Peers...),
 	}
 
+	list := &chaincodeList{}
+
 	if c.Installed {
 		resp, err := c.ResourceManagement.QueryInstalledChaincodes(options...)
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprintln(c.Settings.Streams.Out, "Installed Chaincode:")
-		for _, chaincode := range resp.Chaincodes {
-			fmt.Fprintf(c.Settings.Streams.Out, " - %s\n", chaincode.Name)
-		}
+		list.Installed = newChaincodeInfos(resp)
 	}
 
 	if c.Instantiated {
-		resp, err := c.ResourceManagement.QueryInstalledChaincodes(options...)
+		channels, err := c.channels(context.Channel, options)
+		switch {
+		case err == errNoChannel && !explicit:
+			list.noChannel = true
+		case err != nil:
+			return err
+		}
+
+		for _, channel := range channels {
+			resp, err := c.ResourceManagement.QueryInstantiatedChaincodes(channel, options...)
+			if err != nil {
+				return err
+			}
+
+			channelList := &channelChaincodeList{
+				Channel:      channel,
+				Instantiated: newChaincodeInfos(resp),
+			}
+
+			defs, err := c.ResourceManagement.LifecycleQueryCommittedCC(channel, resmgmt.LifecycleQueryCommittedCCRequest{}, options...)
+			if err != nil {
+				channelList.CommittedError = err.Error()
+			} else {
+				channelList.Committed = newCommittedChaincodes(defs)
+			}
+
+			list.Channels = append(list.Channels, channelList)
+		}
+	}
+
+	return c.print(list)
+}
+
+// channels returns the channels to list, which are either the given, all joined or the context channel
+func (c *ListCommand) channels(contextChannel string, options []resmgmt.RequestOption) ([]string, error) {
+	if c.ChannelID != "" {
+		return []string{c.ChannelID}, nil
+	}
+
+	if !c.AllChannels {
+		if contextChannel == "" {
+			return nil, errNoChannel
+		}
+
+		return []string{contextChannel}, nil
+	}
+
+	resp, err := c.ResourceManagement.QueryChannels(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]string, 0, len(resp.Channels))
+	for _, channel := range resp.Channels {
+		channels = append(channels, channel.ChannelId)
+	}
+
+	return channels, nil
+}
+
+func newChaincodeInfos(resp *pb.ChaincodeQueryResponse) []*chaincodeInfo {
+	infos := make([]*chaincodeInfo, 0, len(resp.Chaincodes))
+	for _, cc := range resp.Chaincodes {
+		infos = append(infos, &chaincodeInfo{
+			Name:    cc.Name,
+			Version: cc.Version,
+			Path:    cc.Path,
+			Escc:    cc.Escc,
+			Vscc:    cc.Vscc,
+			ID:      hex.EncodeToString(cc.Id),
+		})
+	}
+
+	return infos
+}
+
+func newCommittedChaincodes(defs []resmgmt.LifecycleChaincodeDefinition) []*committedChaincode {
+	committed := make([]*committedChaincode, 0, len(defs))
+	for _, def := range defs {
+		committed = append(committed, &committedChaincode{
+			Name:              def.Name,
+			Version:           def.Version,
+			Sequence:          def.Sequence,
+			EndorsementPlugin: def.EndorsementPlugin,
+			ValidationPlugin:  def.ValidationPlugin,
+			InitRequired:      def.InitRequired,
+		})
+	}
+
+	return committed
+}
+
+func (c *ListCommand) print(list *chaincodeList) error {
+	out := c.Settings.Streams.Out
+
+	switch c.OutputFormat {
+	case jsonFormat:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(list)
+	case yamlFormat:
+		data, err := yaml.Marshal(list)
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprintln(c.Settings.Streams.Out, "Instantiated Chaincode:")
-		for _, chaincode := range resp.Chaincodes {
-			fmt.Fprintf(c.Settings.Streams.Out, " - %s\n", chaincode.Name)
+		_, err = out.Write(data)
+
+		return err
+	}
+
+	if c.Installed {
+		fmt.Fprintln(out, "Installed Chaincode:")
+		for _, cc := range list.Installed {
+			fmt.Fprintf(out, " - %s\n", cc)
+		}
+	}
+
+	if list.noChannel {
+		fmt.Fprintln(out, "No channel set in the current context, use --channel or --all-channels to list instantiated chaincode")
+	}
+
+	for _, channel := range list.Channels {
+		fmt.Fprintf(out, "Instantiated Chaincode on channel '%s':\n", channel.Channel)
+		for _, cc := range channel.Instantiated {
+			fmt.Fprintf(out, " - %s\n", cc)
+		}
+
+		if channel.CommittedError != "" {
+			fmt.Fprintf(out, "Committed Chaincode on channel '%s' not available: %s\n", channel.Channel, channel.CommittedError)
+			continue
+		}
+
+		fmt.Fprintf(out, "Committed Chaincode on channel '%s':\n", channel.Channel)
+		for _, cc := range channel.Committed {
+			fmt.Fprintf(out, " - %s (version: %s, sequence: %d)\n", cc.Name, cc.Version, cc.Sequence)
 		}
 	}
 
 	return nil
 }
+
+// String returns the name of the chaincode followed by its known details
+func (cc *chaincodeInfo) String() string {
+	details := []string{"version: " + cc.Version}
+
+	for _, detail := range []struct{ name, value string }{
+		{"path", cc.Path},
+		{"escc", cc.Escc},
+		{"vscc", cc.Vscc},
+		{"id", cc.ID},
+	} {
+		if detail.value != "" {
+			details = append(details, detail.name+": "+detail.value)
+		}
+	}
+
+	return fmt.Sprintf("%s (%s)", cc.Name, strings.Join(details, ", "))
+}
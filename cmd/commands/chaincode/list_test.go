@@ -8,12 +8,13 @@ package chaincode_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
-
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
@@ -98,6 +99,39 @@ var _ = Describe("ChaincodeListImplementation", func() {
 		Expect(impl).ShouldNot(BeNil())
 	})
 
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed without arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when a channel is combined with all channels", func() {
+			BeforeEach(func() {
+				impl.ChannelID = "mychannel"
+				impl.AllChannels = true
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--channel cannot be combined with --all-channels"))
+			})
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "xml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'xml'"))
+			})
+		})
+	})
+
 	Describe("Run", func() {
 		BeforeEach(func() {
 			impl.ResourceManagement = client
@@ -123,22 +157,184 @@ var _ = Describe("ChaincodeListImplementation", func() {
 				client.QueryInstalledChaincodesReturns(&pb.ChaincodeQueryResponse{
 					Chaincodes: []*pb.ChaincodeInfo{
 						{
-							Name: "mycc",
+							Name:    "mycc",
+							Version: "1.0",
+							Path:    "github.com/mycc",
+							Id:      []byte{0xab, 0xcd},
 						},
 					},
 				}, nil)
 				client.QueryInstantiatedChaincodesReturns(&pb.ChaincodeQueryResponse{
 					Chaincodes: []*pb.ChaincodeInfo{
 						{
-							Name: "mycc",
+							Name:    "mycc",
+							Version: "1.0",
+							Path:    "github.com/mycc",
+							Escc:    "escc",
+							Vscc:    "vscc",
+							Id:      []byte{0xab, 0xcd},
 						},
 					},
 				}, nil)
+				client.LifecycleQueryCommittedCCReturns([]resmgmt.LifecycleChaincodeDefinition{
+					{
+						Name:     "basic",
+						Version:  "2.0",
+						Sequence: 3,
+					},
+				}, nil)
 			})
 
 			It("should succeed with chaincode list", func() {
 				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(Equal(
+					"Installed Chaincode:\n" +
+						" - mycc (version: 1.0, path: github.com/mycc, id: abcd)\n" +
+						"No channel set in the current context, use --channel or --all-channels to list instantiated chaincode\n"))
+				Expect(client.QueryInstantiatedChaincodesCallCount()).To(Equal(0))
+			})
 
+			Context("when the context has a channel", func() {
+				BeforeEach(func() {
+					settings.Config.Contexts["foo"].Channel = "mychannel"
+				})
+
+				It("should succeed with chaincode list", func() {
+					Expect(err).To(BeNil())
+					Expect(fmt.Sprint(out)).To(Equal(
+						"Installed Chaincode:\n" +
+							" - mycc (version: 1.0, path: github.com/mycc, id: abcd)\n" +
+							"Instantiated Chaincode on channel 'mychannel':\n" +
+							" - mycc (version: 1.0, path: github.com/mycc, escc: escc, vscc: vscc, id: abcd)\n" +
+							"Committed Chaincode on channel 'mychannel':\n" +
+							" - basic (version: 2.0, sequence: 3)\n"))
+				})
+
+				It("should query the instantiated chaincode of the context channel", func() {
+					Expect(client.QueryInstantiatedChaincodesCallCount()).To(Equal(1))
+					channel, _ := client.QueryInstantiatedChaincodesArgsForCall(0)
+					Expect(channel).To(Equal("mychannel"))
+
+					channel, req, _ := client.LifecycleQueryCommittedCCArgsForCall(0)
+					Expect(channel).To(Equal("mychannel"))
+					Expect(req.Name).To(BeEmpty())
+				})
+
+				Context("when only instantiated chaincode is listed", func() {
+					BeforeEach(func() {
+						impl.Instantiated = true
+					})
+
+					It("should not query installed chaincode", func() {
+						Expect(err).To(BeNil())
+						Expect(client.QueryInstalledChaincodesCallCount()).To(Equal(0))
+						Expect(fmt.Sprint(out)).NotTo(ContainSubstring("Installed Chaincode"))
+					})
+				})
+
+				Context("when a channel is set", func() {
+					BeforeEach(func() {
+						impl.ChannelID = "otherchannel"
+					})
+
+					It("should query the given channel", func() {
+						Expect(err).To(BeNil())
+						channel, _ := client.QueryInstantiatedChaincodesArgsForCall(0)
+						Expect(channel).To(Equal("otherchannel"))
+					})
+				})
+
+				Context("when all channels are listed", func() {
+					BeforeEach(func() {
+						impl.AllChannels = true
+
+						client.QueryChannelsReturns(&pb.ChannelQueryResponse{
+							Channels: []*pb.ChannelInfo{
+								{ChannelId: "ch1"},
+								{ChannelId: "ch2"},
+							},
+						}, nil)
+					})
+
+					It("should query each joined channel", func() {
+						Expect(err).To(BeNil())
+						Expect(client.QueryInstantiatedChaincodesCallCount()).To(Equal(2))
+						channel, _ := client.QueryInstantiatedChaincodesArgsForCall(1)
+						Expect(channel).To(Equal("ch2"))
+						Expect(fmt.Sprint(out)).To(ContainSubstring("Instantiated Chaincode on channel 'ch1':"))
+						Expect(fmt.Sprint(out)).To(ContainSubstring("Committed Chaincode on channel 'ch2':"))
+					})
+				})
+
+				Context("when the output format is json", func() {
+					BeforeEach(func() {
+						impl.OutputFormat = "json"
+					})
+
+					It("should print the chaincode as JSON", func() {
+						Expect(err).To(BeNil())
+
+						var list map[string]interface{}
+						Expect(json.Unmarshal(out.Bytes(), &list)).To(Succeed())
+						Expect(list["installed"]).To(HaveLen(1))
+						Expect(list["channels"]).To(Equal([]interface{}{
+							map[string]interface{}{
+								"channel": "mychannel",
+								"instantiated": []interface{}{
+									map[string]interface{}{
+										"name": "mycc", "version": "1.0", "path": "github.com/mycc",
+										"escc": "escc", "vscc": "vscc", "id": "abcd",
+									},
+								},
+								"committed": []interface{}{
+									map[string]interface{}{"name": "basic", "version": "2.0", "sequence": 3.0},
+								},
+							},
+						}))
+					})
+				})
+
+				Context("when the output format is yaml", func() {
+					BeforeEach(func() {
+						impl.OutputFormat = "yaml"
+					})
+
+					It("should print the chaincode as YAML", func() {
+						Expect(err).To(BeNil())
+						Expect(fmt.Sprint(out)).To(ContainSubstring("channels:\n- channel: mychannel\n  instantiated:\n  - name: mycc\n"))
+						Expect(fmt.Sprint(out)).To(ContainSubstring("  committed:\n  - name: basic\n    version: \"2.0\"\n    sequence: 3\n"))
+					})
+				})
+
+				Context("when the committed definitions cannot be queried", func() {
+					BeforeEach(func() {
+						client.LifecycleQueryCommittedCCReturns(nil, errors.New("_lifecycle not found"))
+					})
+
+					It("should still list the instantiated chaincode", func() {
+						Expect(err).To(BeNil())
+						Expect(fmt.Sprint(out)).To(ContainSubstring(" - mycc (version: 1.0, path: github.com/mycc, escc: escc, vscc: vscc, id: abcd)\n"))
+						Expect(fmt.Sprint(out)).To(ContainSubstring("Committed Chaincode on channel 'mychannel' not available: _lifecycle not found\n"))
+					})
+				})
+			})
+		})
+
+		Context("when no channel is set", func() {
+			BeforeEach(func() {
+				impl.Instantiated = true
+
+				settings.Config = &environment.Config{
+					Contexts: map[string]*environment.Context{
+						"foo": {},
+					},
+					CurrentContext: "foo",
+				}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("channel not specified"))
 			})
 		})
 
@@ -168,12 +364,14 @@ var _ = Describe("ChaincodeListImplementation", func() {
 
 				settings.Config = &environment.Config{
 					Contexts: map[string]*environment.Context{
-						"foo": {},
+						"foo": {
+							Channel: "mychannel",
+						},
 					},
 					CurrentContext: "foo",
 				}
 
-				client.QueryInstalledChaincodesReturns(nil, errors.New("list error"))
+				client.QueryInstantiatedChaincodesReturns(nil, errors.New("list error"))
 			})
 
 			It("should fail to list instantiated chaincode", func() {
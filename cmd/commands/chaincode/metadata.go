@@ -0,0 +1,418 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+const (
+	// getMetadataFcn is the function of the system contract which returns the contract metadata
+	getMetadataFcn = "org.hyperledger.fabric:GetMetadata"
+
+	// systemContract is added to every chaincode by the contract API
+	systemContract = "org.hyperledger.fabric"
+
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// contractMetadata is the metadata of a chaincode written with the contract API
+type contractMetadata struct {
+	Info       *metadataInfo        `json:"info,omitempty"`
+	Contracts  map[string]*contract `json:"contracts"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas,omitempty"`
+	} `json:"components"`
+}
+
+type metadataInfo struct {
+	Title       string `json:"title,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type contract struct {
+	Info         *metadataInfo  `json:"info,omitempty"`
+	Name         string         `json:"name"`
+	Transactions []*transaction `json:"transactions"`
+	Default      bool           `json:"default,omitempty"`
+}
+
+type transaction struct {
+	Name       string       `json:"name"`
+	Tag        []string     `json:"tag,omitempty"`
+	Parameters []*parameter `json:"parameters,omitempty"`
+	Returns    *schema      `json:"returns,omitempty"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Schema      *schema `json:"schema"`
+}
+
+// schema is the subset of JSON schema used by the contract API
+type schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Items       *schema            `json:"items,omitempty"`
+	Properties  map[string]*schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+// queryMetadata returns the raw and decoded contract metadata of the given chaincode
+func queryMetadata(ch fabric.Channel, chaincode string) ([]byte, *contractMetadata, error) {
+	resp, err := ch.Query(channel.Request{ChaincodeID: chaincode, Fcn: getMetadataFcn}, channel.WithRetry(retry.DefaultChannelOpts))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query metadata of chaincode '%s': %s", chaincode, err)
+	}
+
+	var metadata contractMetadata
+	if err := json.Unmarshal(resp.Payload, &metadata); err != nil || metadata.Contracts == nil {
+		return nil, nil, fmt.Errorf("chaincode '%s' does not provide contract metadata", chaincode)
+	}
+
+	return resp.Payload, &metadata, nil
+}
+
+// contractNames returns the sorted names of the contracts, omitting the system contract
+func (m *contractMetadata) contractNames() []string {
+	var names []string
+	for name := range m.Contracts {
+		if name != systemContract {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// functionName returns the function which invokes the given transaction of the given contract
+func (m *contractMetadata) functionName(c *contract, tx *transaction) string {
+	if c.Default {
+		return tx.Name
+	}
+
+	return c.Name + ":" + tx.Name
+}
+
+// transaction returns the transaction invoked by the given function, which is either the name of a
+// transaction of the default contract or a transaction name qualified with its contract name. The
+// only contract of a chaincode, besides the system contract, is its default contract even if the
+// metadata doesn't mark it.
+func (m *contractMetadata) transaction(fcn string) (*transaction, error) {
+	var c *contract
+	name := fcn
+
+	if i := strings.LastIndex(fcn, ":"); i >= 0 {
+		c = m.Contracts[fcn[:i]]
+		if c == nil {
+			return nil, fmt.Errorf("contract '%s' not found", fcn[:i])
+		}
+
+		name = fcn[i+1:]
+	} else {
+		for _, candidate := range m.Contracts {
+			if candidate.Default {
+				c = candidate
+				break
+			}
+		}
+
+		if names := m.contractNames(); c == nil && len(names) == 1 {
+			c = m.Contracts[names[0]]
+		}
+
+		if c == nil {
+			return nil, fmt.Errorf("transaction '%s' not found, no default contract", fcn)
+		}
+	}
+
+	for _, tx := range c.Transactions {
+		if tx.Name == name {
+			return tx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction '%s' not found in contract '%s'", name, c.Name)
+}
+
+// resolve follows the reference of a schema to the component schemas
+func (m *contractMetadata) resolve(s *schema) (*schema, error) {
+	for s != nil && s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, schemaRefPrefix)
+
+		resolved, ok := m.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema '%s'", s.Ref)
+		}
+
+		s = resolved
+	}
+
+	return s, nil
+}
+
+// namedArgs orders "name=value" pairs by the parameters of the transaction
+func (tx *transaction) namedArgs(pairs []string) ([]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		i := strings.Index(pair, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid argument '%s', expected name=value", pair)
+		}
+
+		values[pair[:i]] = pair[i+1:]
+	}
+
+	args := make([]string, 0, len(tx.Parameters))
+	for _, p := range tx.Parameters {
+		value, ok := values[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("argument '%s' of transaction '%s' not specified", p.Name, tx.Name)
+		}
+
+		args = append(args, value)
+		delete(values, p.Name)
+	}
+
+	for name := range values {
+		return nil, fmt.Errorf("transaction '%s' has no parameter '%s'", tx.Name, name)
+	}
+
+	return args, nil
+}
+
+// validateArgs checks the arguments against the parameter schemas of the transaction. String
+// parameters are passed as they are, all other parameters as JSON.
+func (m *contractMetadata) validateArgs(tx *transaction, args [][]byte) error {
+	if len(args) != len(tx.Parameters) {
+		return fmt.Errorf("transaction '%s' expects %d arguments, got %d", tx.Name, len(tx.Parameters), len(args))
+	}
+
+	for i, p := range tx.Parameters {
+		s, err := m.resolve(p.Schema)
+		if err != nil {
+			return err
+		}
+
+		var value interface{} = string(args[i])
+		if s != nil && s.Type != "" && s.Type != "string" {
+			if err := json.Unmarshal(args[i], &value); err != nil {
+				return fmt.Errorf("invalid argument '%s': expected JSON %s", p.Name, typeName(s))
+			}
+		}
+
+		if err := m.validate(value, p.Schema, p.Name); err != nil {
+			return fmt.Errorf("invalid argument %s", err)
+		}
+	}
+
+	return nil
+}
+
+// validate checks a decoded JSON value against a schema, returning an error prefixed with the path
+func (m *contractMetadata) validate(value interface{}, s *schema, path string) error {
+	s, err := m.resolve(s)
+	if err != nil || s == nil {
+		return err
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		return fmt.Errorf("'%s': must be one of %s", path, enumString(s.Enum))
+	}
+
+	switch s.Type {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("'%s': expected string", path)
+		}
+
+		if s.Pattern != "" {
+			matched, err := regexp.MatchString(s.Pattern, str)
+			if err != nil {
+				return fmt.Errorf("'%s': invalid pattern '%s'", path, s.Pattern)
+			}
+
+			if !matched {
+				return fmt.Errorf("'%s': must match pattern '%s'", path, s.Pattern)
+			}
+		}
+	case "number", "integer":
+		n, ok := value.(float64)
+		if !ok || (s.Type == "integer" && n != math.Trunc(n)) {
+			return fmt.Errorf("'%s': expected %s", path, s.Type)
+		}
+
+		if s.Minimum != nil && n < *s.Minimum {
+			return fmt.Errorf("'%s': must be at least %v", path, *s.Minimum)
+		}
+
+		if s.Maximum != nil && n > *s.Maximum {
+			return fmt.Errorf("'%s': must be at most %v", path, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("'%s': expected boolean", path)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("'%s': expected array", path)
+		}
+
+		for i, item := range items {
+			if err := m.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("'%s': expected object", path)
+		}
+
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("'%s': missing property '%s'", path, name)
+			}
+		}
+
+		for name, prop := range s.Properties {
+			if v, ok := obj[name]; ok {
+				if err := m.validate(v, prop, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func enumString(values []interface{}) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		strs[i] = string(b)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// typeName returns a short description of the type of a schema, e.g. "string", "Asset" or "[]Asset"
+func typeName(s *schema) string {
+	switch {
+	case s == nil:
+		return "any"
+	case s.Ref != "":
+		return strings.TrimPrefix(s.Ref, schemaRefPrefix)
+	case s.Type == "array":
+		return "[]" + typeName(s.Items)
+	case s.Type == "":
+		return "any"
+	default:
+		return s.Type
+	}
+}
+
+// contractArgs are the arguments of a transaction which are resolved against the contract metadata
+type contractArgs struct {
+	fcn      string
+	args     [][]byte
+	named    []string
+	encoding string
+	validate bool
+}
+
+// getContractArgs resolves named arguments and optionally validates the arguments against the
+// contract metadata of the chaincode. The metadata is only queried if needed.
+func getContractArgs(ch fabric.Channel, chaincode string, a *contractArgs) ([][]byte, error) {
+	if len(a.named) == 0 && !a.validate {
+		return a.args, nil
+	}
+
+	_, metadata, err := queryMetadata(ch, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := metadata.transaction(a.fcn)
+	if err != nil {
+		return nil, err
+	}
+
+	args := a.args
+	if len(a.named) > 0 {
+		strArgs, err := tx.namedArgs(a.named)
+		if err != nil {
+			return nil, err
+		}
+
+		args, err = common.GetByteArgs(strArgs, a.encoding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if a.validate {
+		if err := metadata.validateArgs(tx, args); err != nil {
+			return nil, err
+		}
+	}
+
+	return args, nil
+}
+
+// validateNamedArgs checks that named arguments are not combined with positional arguments
+func validateNamedArgs(named []string, fcn string, args []string, ctor string, argsFile string) error {
+	if len(named) == 0 {
+		return nil
+	}
+
+	if len(args) > 0 || ctor != "" || argsFile != "" {
+		return errors.New("--arg cannot be combined with --args, --ctor or --args-file")
+	}
+
+	if fcn == "" {
+		return errors.New("--arg requires --fcn")
+	}
+
+	return nil
+}
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/hyperledger/fabric-cli/cmd/commands/common"
 	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
 )
 
 // NewChaincodeQueryCommand creates a new "fabric chaincode query" command
@@ -41,6 +44,8 @@ func NewChaincodeQueryCommand(settings *environment.Settings) *cobra.Command {
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
 			return c.Run()
 		},
 	}
@@ -50,6 +55,18 @@ func NewChaincodeQueryCommand(settings *environment.Settings) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&c.ChaincodeFcn, "fcn", "", "Set the invoke function")
 	flags.StringArrayVar(&c.ChaincodeArgs, "args", []string{}, "Set the invoke arguments")
+	flags.StringVarP(&c.Ctor, "ctor", "c", "", "Set the query function and arguments as peer CLI JSON, e.g. {\"Args\":[\"fcn\",\"arg1\"]}")
+	flags.StringArrayVar(&c.NamedArgs, "arg", []string{},
+		"Set a named query argument as name=value, ordered by the contract metadata (this option may be specified multiple times)")
+	flags.BoolVar(&c.ValidateArgs, "validate", false, "Validate the arguments against the contract metadata before sending the proposal")
+	flags.StringVar(&c.ArgsFile, "args-file", "", "Set the path to a JSON file containing an array of query arguments or a constructor")
+	flags.StringVar(&c.ArgEncoding, "arg-encoding", common.ArgEncodingUTF8, common.ArgEncodingUsage)
+	flags.StringArrayVar(&c.Transient, "transient", []string{}, "Set a transient data entry as key=value (this option may be specified multiple times)")
+	flags.StringVar(&c.TransientFile, "transient-file", "", "Set the path to a JSON file containing an object of transient data values")
+	flags.StringVar(&c.TransientEncoding, "transient-encoding", common.TransientEncodingUTF8,
+		"Set the encoding of the transient data values (utf8, base64 or hex)")
+	flags.StringArrayVar(&c.Peers, "peer", []string{}, "Set a target peer (this option may be specified multiple times)")
+	flags.BoolVar(&c.ComparePeers, "compare-peers", false, "Query each peer individually and fail if the results differ")
 
 	cmd.SetOutput(c.Settings.Streams.Out)
 
@@ -64,6 +81,24 @@ type QueryCommand struct {
 
 	ChaincodeFcn  string
 	ChaincodeArgs []string
+	Ctor          string
+	ArgsFile      string
+	ArgEncoding   string
+	NamedArgs     []string
+	ValidateArgs  bool
+
+	Transient         []string
+	TransientFile     string
+	TransientEncoding string
+
+	Peers        []string
+	ComparePeers bool
+}
+
+// peerResult groups the peers which returned the same query result
+type peerResult struct {
+	payload []byte
+	peers   []string
 }
 
 // Validate checks the required parameters for run
@@ -72,18 +107,57 @@ func (c *QueryCommand) Validate() error {
 		return errors.New("chaincode name not specified")
 	}
 
+	if err := validateNamedArgs(c.NamedArgs, c.ChaincodeFcn, c.ChaincodeArgs, c.Ctor, c.ArgsFile); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // Run executes the command
 func (c *QueryCommand) Run() error {
+	fcn, args, err := common.GetChaincodeArgs(c.ChaincodeFcn, c.ChaincodeArgs, c.Ctor, c.ArgsFile, c.ArgEncoding)
+	if err != nil {
+		return err
+	}
+
+	args, err = getContractArgs(c.Channel, c.ChaincodeName, &contractArgs{
+		fcn:      fcn,
+		args:     args,
+		named:    c.NamedArgs,
+		encoding: c.ArgEncoding,
+		validate: c.ValidateArgs,
+	})
+	if err != nil {
+		return err
+	}
+
+	transientMap, err := common.GetTransientMap(c.Transient, c.TransientFile, c.TransientEncoding)
+	if err != nil {
+		return err
+	}
+
 	req := channel.Request{
-		ChaincodeID: c.ChaincodeName,
-		Fcn:         c.ChaincodeFcn,
-		Args:        common.AsByteArgs(c.ChaincodeArgs),
+		ChaincodeID:  c.ChaincodeName,
+		Fcn:          fcn,
+		Args:         args,
+		TransientMap: transientMap,
 	}
 
-	resp, err := c.Channel.Query(req, channel.WithRetry(retry.DefaultChannelOpts))
+	if _, ok := c.Channel.(fabric.Submitter); ok && (c.ComparePeers || len(c.Peers) > 0) {
+		return errors.New("--peer and --compare-peers are not supported by the gateway")
+	}
+
+	if c.ComparePeers {
+		return c.compare(req)
+	}
+
+	options := []channel.RequestOption{channel.WithRetry(retry.DefaultChannelOpts)}
+	if len(c.Peers) > 0 {
+		options = append(options, channel.WithTargetEndpoints(c.Peers...))
+	}
+
+	resp, err := c.Channel.Query(req, options...)
 	if err != nil {
 		return err
 	}
@@ -92,3 +166,85 @@ func (c *QueryCommand) Run() error {
 
 	return nil
 }
+
+// compare evaluates the request on each peer individually and reports the peers which disagree
+func (c *QueryCommand) compare(req channel.Request) error {
+	peers, err := c.peers()
+	if err != nil {
+		return err
+	}
+
+	if len(peers) == 0 {
+		return errors.New("no peers to compare")
+	}
+
+	var results []*peerResult
+	var failed int
+
+	out := c.Settings.Streams.Out
+
+	for _, peer := range peers {
+		resp, err := c.Channel.Query(req, channel.WithRetry(retry.DefaultChannelOpts), channel.WithTargetEndpoints(peer))
+		if err != nil {
+			fmt.Fprintf(out, "Failed to query peer %s: %s\n", peer, err)
+			failed++
+			continue
+		}
+
+		found := false
+		for _, result := range results {
+			if bytes.Equal(result.payload, resp.Payload) {
+				result.peers = append(result.peers, peer)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			results = append(results, &peerResult{payload: resp.Payload, peers: []string{peer}})
+		}
+	}
+
+	switch len(results) {
+	case 0:
+	case 1:
+		fmt.Fprintf(out, "Results match on %d peers: %s\n", len(results[0].peers), strings.Join(results[0].peers, ", "))
+		fmt.Fprintln(out, string(results[0].payload))
+	default:
+		fmt.Fprintln(out, "Results differ between peers:")
+		for _, result := range results {
+			fmt.Fprintf(out, "- %s: %s\n", strings.Join(result.peers, ", "), string(result.payload))
+		}
+
+		return fmt.Errorf("query results differ between %d peers", len(peers)-failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to query %d of %d peers", failed, len(peers))
+	}
+
+	return nil
+}
+
+// peers returns the peers to compare, defaulting to the peers of the current context or organization
+func (c *QueryCommand) peers() ([]string, error) {
+	if len(c.Peers) > 0 {
+		return c.Peers, nil
+	}
+
+	context, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(context.Peers) > 0 {
+		return context.Peers, nil
+	}
+
+	sdk, err := c.Factory.SDK()
+	if err != nil {
+		return nil, err
+	}
+
+	return fabric.OrganizationPeers(sdk, context.Organization)
+}
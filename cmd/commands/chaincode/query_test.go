@@ -18,6 +18,7 @@ import (
 
 	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
 	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
 	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
 )
@@ -116,6 +117,32 @@ var _ = Describe("ChaincodeQueryImplementation", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		Context("when named arguments are combined with arguments", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.ChaincodeFcn = "ReadAsset"
+				impl.ChaincodeArgs = []string{"asset1"}
+				impl.NamedArgs = []string{"id=asset1"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--arg cannot be combined with --args, --ctor or --args-file"))
+			})
+		})
+
+		Context("when named arguments are set without a function", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.NamedArgs = []string{"id=asset1"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--arg requires --fcn"))
+			})
+		})
 	})
 
 	Describe("Run", func() {
@@ -141,6 +168,217 @@ var _ = Describe("ChaincodeQueryImplementation", func() {
 			})
 		})
 
+		Context("when --compare-peers is set", func() {
+			BeforeEach(func() {
+				impl.ComparePeers = true
+				impl.Peers = []string{"peer1", "peer2", "peer3"}
+
+				client.QueryReturnsOnCall(0, channel.Response{Payload: []byte("100")}, nil)
+				client.QueryReturnsOnCall(1, channel.Response{Payload: []byte("100")}, nil)
+				client.QueryReturnsOnCall(2, channel.Response{Payload: []byte("100")}, nil)
+			})
+
+			It("should query each peer individually", func() {
+				Expect(err).To(BeNil())
+				Expect(client.QueryCallCount()).To(Equal(3))
+
+				_, opts := client.QueryArgsForCall(1)
+				Expect(opts).To(HaveLen(2))
+				Expect(fmt.Sprint(out)).To(Equal("Results match on 3 peers: peer1, peer2, peer3\n100\n"))
+			})
+
+			Context("when a peer returns a different result", func() {
+				BeforeEach(func() {
+					client.QueryReturnsOnCall(1, channel.Response{Payload: []byte("90")}, nil)
+				})
+
+				It("should report the peers which disagree", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("query results differ between 3 peers"))
+					Expect(fmt.Sprint(out)).To(Equal("Results differ between peers:\n- peer1, peer3: 100\n- peer2: 90\n"))
+				})
+			})
+
+			Context("when a peer fails", func() {
+				BeforeEach(func() {
+					client.QueryReturnsOnCall(2, channel.Response{}, errors.New("query error"))
+				})
+
+				It("should report the failed peer", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("failed to query 1 of 3 peers"))
+					Expect(fmt.Sprint(out)).To(ContainSubstring("Failed to query peer peer3: query error"))
+				})
+			})
+
+			Context("when no peers are specified", func() {
+				BeforeEach(func() {
+					impl.Peers = nil
+
+					settings.Config = &environment.Config{
+						Contexts: map[string]*environment.Context{
+							"foo": {
+								Peers: []string{"peer1", "peer2"},
+							},
+						},
+						CurrentContext: "foo",
+					}
+				})
+
+				It("should compare the peers of the current context", func() {
+					Expect(err).To(BeNil())
+					Expect(client.QueryCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when the channel is a gateway and --compare-peers is set", func() {
+			BeforeEach(func() {
+				impl.ComparePeers = true
+				impl.Channel = fabric.NewGatewayChannel(nil, "mychannel", nil)
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--peer and --compare-peers are not supported by the gateway"))
+			})
+		})
+
+		Context("when named arguments are set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "ReadAsset"
+				impl.NamedArgs = []string{"id=asset1"}
+
+				client.QueryReturnsOnCall(0, channel.Response{Payload: []byte(contractMetadata)}, nil)
+				client.QueryReturnsOnCall(1, channel.Response{Payload: []byte("{}")}, nil)
+			})
+
+			It("should query the metadata and send the ordered arguments", func() {
+				Expect(err).To(BeNil())
+				Expect(client.QueryCallCount()).To(Equal(2))
+
+				req, _ := client.QueryArgsForCall(0)
+				Expect(req.Fcn).To(Equal("org.hyperledger.fabric:GetMetadata"))
+
+				req, _ = client.QueryArgsForCall(1)
+				Expect(req.Fcn).To(Equal("ReadAsset"))
+				Expect(req.Args).To(Equal([][]byte{[]byte("asset1")}))
+			})
+		})
+
+		Context("when the only contract is not marked as default", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "History"
+				impl.NamedArgs = []string{"id=asset1"}
+
+				client.QueryReturnsOnCall(0, channel.Response{Payload: []byte(`{
+					"contracts": {
+						"AuditContract": {
+							"name": "AuditContract",
+							"transactions": [{"name": "History", "parameters": [{"name": "id", "schema": {"type": "string"}}]}]
+						},
+						"org.hyperledger.fabric": {
+							"name": "org.hyperledger.fabric",
+							"transactions": [{"name": "GetMetadata"}]
+						}
+					}
+				}`)}, nil)
+				client.QueryReturnsOnCall(1, channel.Response{Payload: []byte("[]")}, nil)
+			})
+
+			It("should use the transaction of the contract", func() {
+				Expect(err).To(BeNil())
+
+				req, _ := client.QueryArgsForCall(1)
+				Expect(req.Fcn).To(Equal("History"))
+				Expect(req.Args).To(Equal([][]byte{[]byte("asset1")}))
+			})
+		})
+
+		Context("when the arguments are validated", func() {
+			BeforeEach(func() {
+				impl.ChaincodeFcn = "AuditContract:History"
+				impl.ChaincodeArgs = []string{"asset1", "asset2"}
+				impl.ValidateArgs = true
+
+				client.QueryReturns(channel.Response{Payload: []byte(contractMetadata)}, nil)
+			})
+
+			It("should fail without sending the request", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("transaction 'History' expects 1 arguments, got 2"))
+				Expect(client.QueryCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when a peer CLI constructor is set", func() {
+			BeforeEach(func() {
+				impl.Ctor = `{"Args":["move","a","b64:Yg=="]}`
+
+				client.QueryReturns(channel.Response{}, nil)
+			})
+
+			It("should send the constructor function and arguments unchanged", func() {
+				Expect(err).To(BeNil())
+
+				req, _ := client.QueryArgsForCall(0)
+				Expect(req.Fcn).To(Equal("move"))
+				Expect(req.Args).To(Equal([][]byte{[]byte("a"), []byte("b64:Yg==")}))
+			})
+
+			Context("when the arguments are prefixed", func() {
+				BeforeEach(func() {
+					impl.ArgEncoding = "prefixed"
+				})
+
+				It("should decode the arguments", func() {
+					Expect(err).To(BeNil())
+
+					req, _ := client.QueryArgsForCall(0)
+					Expect(req.Args).To(Equal([][]byte{[]byte("a"), []byte("b")}))
+				})
+			})
+		})
+
+		Context("when the constructor is combined with arguments", func() {
+			BeforeEach(func() {
+				impl.Ctor = `{"Args":["move"]}`
+				impl.ChaincodeArgs = []string{"a"}
+			})
+
+			It("should fail without sending the request", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(client.QueryCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when transient data is set", func() {
+			BeforeEach(func() {
+				impl.Transient = []string{"key1=dmFsdWUx"}
+				impl.TransientEncoding = "base64"
+
+				client.QueryReturns(channel.Response{}, nil)
+			})
+
+			It("should send the transient map", func() {
+				Expect(err).To(BeNil())
+
+				req, _ := client.QueryArgsForCall(0)
+				Expect(req.TransientMap).To(Equal(map[string][]byte{"key1": []byte("value1")}))
+			})
+		})
+
+		Context("when transient data is invalid", func() {
+			BeforeEach(func() {
+				impl.Transient = []string{"key1"}
+			})
+
+			It("should fail without sending the request", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(client.QueryCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when channel client fails", func() {
 			BeforeEach(func() {
 				client.QueryReturns(channel.Response{}, errors.New("query error"))
@@ -0,0 +1,249 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Command devcc is a chaincode for the "chaincode dev" tests. It speaks the chaincode protocol
+// directly so that it does not depend on the shim.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/grpc"
+)
+
+type chaincode struct {
+	stream pb.ChaincodeSupport_RegisterClient
+	tx     *pb.ChaincodeMessage
+	event  *pb.ChaincodeEvent
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	conn, err := grpc.Dial(os.Getenv("CORE_PEER_ADDRESS"), grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewChaincodeSupportClient(conn).Register(context.Background())
+	if err != nil {
+		return err
+	}
+
+	id, _ := proto.Marshal(&pb.ChaincodeID{Name: os.Getenv("CORE_CHAINCODE_ID_NAME")})
+	if err := stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER, Payload: id}); err != nil {
+		return err
+	}
+
+	for _, expected := range []pb.ChaincodeMessage_Type{pb.ChaincodeMessage_REGISTERED, pb.ChaincodeMessage_READY} {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if msg.Type != expected {
+			return fmt.Errorf("expected %s, received %s", expected, msg.Type)
+		}
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		cc := &chaincode{stream: stream, tx: msg}
+
+		resp, err := proto.Marshal(cc.invoke())
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&pb.ChaincodeMessage{
+			Type:           pb.ChaincodeMessage_COMPLETED,
+			Payload:        resp,
+			Txid:           msg.Txid,
+			ChannelId:      msg.ChannelId,
+			ChaincodeEvent: cc.event,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func (cc *chaincode) invoke() *pb.Response {
+	input := &pb.ChaincodeInput{}
+	if err := proto.Unmarshal(cc.tx.Payload, input); err != nil {
+		return &pb.Response{Status: 500, Message: err.Error()}
+	}
+
+	args := make([]string, len(input.Args))
+	for i, arg := range input.Args {
+		args[i] = string(arg)
+	}
+
+	payload, err := cc.call(args[0], args[1:])
+	if err != nil {
+		return &pb.Response{Status: 500, Message: err.Error()}
+	}
+
+	return &pb.Response{Status: 200, Payload: payload}
+}
+
+func (cc *chaincode) call(fcn string, args []string) ([]byte, error) {
+	switch fcn {
+	case "put":
+		if _, err := cc.request(pb.ChaincodeMessage_GET_STATE, &pb.GetState{Key: args[0]}); err != nil {
+			return nil, err
+		}
+
+		if _, err := cc.request(pb.ChaincodeMessage_PUT_STATE, &pb.PutState{Key: args[0], Value: []byte(args[1])}); err != nil {
+			return nil, err
+		}
+
+		cc.event = &pb.ChaincodeEvent{EventName: "put", Payload: []byte(args[0])}
+
+		return []byte(args[1]), nil
+	case "get":
+		return cc.request(pb.ChaincodeMessage_GET_STATE, &pb.GetState{Key: args[0]})
+	case "del":
+		return cc.request(pb.ChaincodeMessage_DEL_STATE, &pb.DelState{Key: args[0]})
+	case "putPrivate":
+		proposal, err := cc.proposal()
+		if err != nil {
+			return nil, err
+		}
+
+		payload := &pb.ChaincodeProposalPayload{}
+		if err := proto.Unmarshal(proposal.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return cc.request(pb.ChaincodeMessage_PUT_STATE, &pb.PutState{Collection: args[0], Key: args[1], Value: payload.TransientMap["value"]})
+	case "range":
+		resp, err := cc.query(pb.ChaincodeMessage_GET_STATE_BY_RANGE, &pb.GetStateByRange{StartKey: args[0], EndKey: args[1]})
+		if err != nil {
+			return nil, err
+		}
+
+		var keys []string
+		for _, result := range resp.Results {
+			kv := &queryresult.KV{}
+			if err := proto.Unmarshal(result.ResultBytes, kv); err != nil {
+				return nil, err
+			}
+
+			keys = append(keys, kv.Key+"="+string(kv.Value))
+		}
+
+		return []byte(strings.Join(keys, ",")), nil
+	case "history":
+		resp, err := cc.query(pb.ChaincodeMessage_GET_HISTORY_FOR_KEY, &pb.GetHistoryForKey{Key: args[0]})
+		if err != nil {
+			return nil, err
+		}
+
+		var values []string
+		for _, result := range resp.Results {
+			km := &queryresult.KeyModification{}
+			if err := proto.Unmarshal(result.ResultBytes, km); err != nil {
+				return nil, err
+			}
+
+			values = append(values, string(km.Value))
+		}
+
+		return []byte(strings.Join(values, ",")), nil
+	case "creator":
+		proposal, err := cc.proposal()
+		if err != nil {
+			return nil, err
+		}
+
+		header := &cb.Header{}
+		if err := proto.Unmarshal(proposal.Header, header); err != nil {
+			return nil, err
+		}
+
+		signatureHeader := &cb.SignatureHeader{}
+		if err := proto.Unmarshal(header.SignatureHeader, signatureHeader); err != nil {
+			return nil, err
+		}
+
+		identity := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(signatureHeader.Creator, identity); err != nil {
+			return nil, err
+		}
+
+		return []byte(identity.Mspid), nil
+	case "richQuery":
+		return cc.request(pb.ChaincodeMessage_GET_QUERY_RESULT, &pb.GetQueryResult{Query: args[0]})
+	case "queryNext":
+		return cc.request(pb.ChaincodeMessage_QUERY_STATE_NEXT, &pb.QueryStateNext{Id: args[0]})
+	default:
+		return nil, fmt.Errorf("unknown function '%s'", fcn)
+	}
+}
+
+func (cc *chaincode) proposal() (*pb.Proposal, error) {
+	proposal := &pb.Proposal{}
+	if err := proto.Unmarshal(cc.tx.Proposal.ProposalBytes, proposal); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+func (cc *chaincode) request(t pb.ChaincodeMessage_Type, req proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cc.stream.Send(&pb.ChaincodeMessage{Type: t, Payload: payload, Txid: cc.tx.Txid, ChannelId: cc.tx.ChannelId}); err != nil {
+		return nil, err
+	}
+
+	resp, err := cc.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Type == pb.ChaincodeMessage_ERROR {
+		return nil, errors.New(string(resp.Payload))
+	}
+
+	return resp.Payload, nil
+}
+
+func (cc *chaincode) query(t pb.ChaincodeMessage_Type, req proto.Message) (*pb.QueryResponse, error) {
+	payload, err := cc.request(t, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.QueryResponse{}
+	if err := proto.Unmarshal(payload, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
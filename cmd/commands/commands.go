@@ -12,9 +12,14 @@ import (
 	"github.com/hyperledger/fabric-cli/cmd/commands/chaincode"
 	"github.com/hyperledger/fabric-cli/cmd/commands/channel"
 	"github.com/hyperledger/fabric-cli/cmd/commands/context"
+	"github.com/hyperledger/fabric-cli/cmd/commands/discover"
 	"github.com/hyperledger/fabric-cli/cmd/commands/lifecycle"
 	"github.com/hyperledger/fabric-cli/cmd/commands/network"
+	"github.com/hyperledger/fabric-cli/cmd/commands/node"
+	"github.com/hyperledger/fabric-cli/cmd/commands/peer"
 	"github.com/hyperledger/fabric-cli/cmd/commands/plugin"
+	"github.com/hyperledger/fabric-cli/cmd/commands/policy"
+	"github.com/hyperledger/fabric-cli/cmd/commands/tx"
 	"github.com/hyperledger/fabric-cli/cmd/commands/version"
 	"github.com/hyperledger/fabric-cli/pkg/environment"
 )
@@ -43,5 +48,20 @@ func All(settings *environment.Settings) []*cobra.Command {
 
 		// fabric lifecycle [subcommand]
 		lifecycle.NewCommand(settings),
+
+		// fabric tx [subcommand]
+		tx.NewCommand(settings),
+
+		// fabric policy [subcommand]
+		policy.NewCommand(settings),
+
+		// fabric discover [subcommand]
+		discover.NewCommand(settings),
+
+		// fabric peer [subcommand]
+		peer.NewCommand(settings),
+
+		// fabric node [subcommand]
+		node.NewCommand(settings),
 	}
 }
@@ -1,28 +1,49 @@
 package common
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"regexp"
+	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"gopkg.in/yaml.v2"
 
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
 )
 
 // CollectionConfigJSON contains the parameters for a collection configuration
 type CollectionConfigJSON struct {
-	Name            string `json:"name"`
-	Policy          string `json:"policy"`
-	RequiredCount   int32  `json:"requiredPeerCount"`
-	MaxPeerCount    int32  `json:"maxPeerCount"`
-	BlockToLive     uint64 `json:"blockToLive"`
-	MemberOnlyRead  bool   `json:"memberOnlyRead"`
-	MemberOnlyWrite bool   `json:"memberOnlyWrite"`
+	Name              string                           `json:"name" yaml:"name"`
+	Policy            string                           `json:"policy" yaml:"policy"`
+	RequiredCount     int32                            `json:"requiredPeerCount" yaml:"requiredPeerCount"`
+	MaxPeerCount      int32                            `json:"maxPeerCount" yaml:"maxPeerCount"`
+	BlockToLive       uint64                           `json:"blockToLive" yaml:"blockToLive"`
+	MemberOnlyRead    bool                             `json:"memberOnlyRead" yaml:"memberOnlyRead"`
+	MemberOnlyWrite   bool                             `json:"memberOnlyWrite" yaml:"memberOnlyWrite"`
+	EndorsementPolicy *CollectionEndorsementPolicyJSON `json:"endorsementPolicy,omitempty" yaml:"endorsementPolicy,omitempty"`
 }
 
-// GetCollectionConfigFromFile returns the collection config from the given file
+// CollectionEndorsementPolicyJSON contains the endorsement policy of the keys of a collection,
+// which is either a signature policy or a reference to a channel config policy
+type CollectionEndorsementPolicyJSON struct {
+	SignaturePolicy     string `json:"signaturePolicy,omitempty" yaml:"signaturePolicy,omitempty"`
+	ChannelConfigPolicy string `json:"channelConfigPolicy,omitempty" yaml:"channelConfigPolicy,omitempty"`
+}
+
+// implicitCollectionPrefix is the name prefix of the implicit per-organization collections
+const implicitCollectionPrefix = "_implicit_org_"
+
+var collectionNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// GetCollectionConfigFromFile returns the collection config from the given JSON or YAML file
 func GetCollectionConfigFromFile(path string) ([]*pb.CollectionConfig, error) {
 	if len(path) == 0 {
 		return nil, nil
@@ -36,40 +57,271 @@ func GetCollectionConfigFromFile(path string) ([]*pb.CollectionConfig, error) {
 	return GetCollectionsConfigFromBytes(bytes)
 }
 
-// GetCollectionsConfigFromBytes returns the collection config from the given byte array
+// GetCollectionsConfigFromBytes returns the collection config from the given JSON or YAML
+// document. Errors are prefixed with the line of the offending collection.
 func GetCollectionsConfigFromBytes(bytes []byte) ([]*pb.CollectionConfig, error) {
-	var cconf []CollectionConfigJSON
-	if err := json.Unmarshal(bytes, &cconf); err != nil {
-		return nil, errors.New("error unmarshalling collections config")
+	var (
+		cconf []CollectionConfigJSON
+		lines []int
+	)
+
+	if isJSONArray(bytes) {
+		if err := json.Unmarshal(bytes, &cconf); err != nil {
+			return nil, fmt.Errorf("error unmarshalling collections config: %s", jsonErrorString(bytes, err))
+		}
+
+		lines = jsonItemLines(bytes)
+	} else {
+		if err := yaml.Unmarshal(bytes, &cconf); err != nil {
+			return nil, fmt.Errorf("error unmarshalling collections config: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		}
+
+		lines = yamlItemLines(bytes)
 	}
 
+	names := make(map[string]string, len(cconf))
+
 	ccarray := make([]*pb.CollectionConfig, 0, len(cconf))
-	for _, cconfitem := range cconf {
-		p, err := policydsl.FromString(cconfitem.Policy)
+	for i, cconfitem := range cconf {
+		location := fmt.Sprintf("collection %d", i+1)
+		if i < len(lines) {
+			location = fmt.Sprintf("line %d", lines[i])
+		}
+
+		if first, ok := names[cconfitem.Name]; ok && cconfitem.Name != "" {
+			return nil, fmt.Errorf("%s: duplicate collection name '%s' (first defined on %s)", location, cconfitem.Name, first)
+		}
+
+		names[cconfitem.Name] = location
+
+		cc, err := newCollectionConfig(cconfitem)
 		if err != nil {
-			return nil, err
+			if cconfitem.Name != "" {
+				return nil, fmt.Errorf("%s: collection '%s': %s", location, cconfitem.Name, err)
+			}
+
+			return nil, fmt.Errorf("%s: %s", location, err)
 		}
-		cpc := &pb.CollectionPolicyConfig{
-			Payload: &pb.CollectionPolicyConfig_SignaturePolicy{
-				SignaturePolicy: p,
+
+		ccarray = append(ccarray, cc)
+	}
+	return ccarray, nil
+}
+
+func newCollectionConfig(cconfitem CollectionConfigJSON) (*pb.CollectionConfig, error) {
+	switch {
+	case cconfitem.Name == "":
+		return nil, errors.New("collection name not specified")
+	case strings.HasPrefix(cconfitem.Name, implicitCollectionPrefix):
+		return nil, fmt.Errorf("collection name must not start with '%s', which is reserved for implicit collections", implicitCollectionPrefix)
+	case !collectionNameRegexp.MatchString(cconfitem.Name):
+		return nil, errors.New("collection name may only contain letters, digits, '_' and '-'")
+	case cconfitem.RequiredCount < 0:
+		return nil, fmt.Errorf("requiredPeerCount must not be negative, got %d", cconfitem.RequiredCount)
+	case cconfitem.MaxPeerCount < cconfitem.RequiredCount:
+		return nil, fmt.Errorf("maxPeerCount (%d) must not be less than requiredPeerCount (%d)", cconfitem.MaxPeerCount, cconfitem.RequiredCount)
+	}
+
+	if cconfitem.Policy == "" {
+		return nil, errors.New("policy not specified")
+	}
+
+	p, err := policydsl.FromString(cconfitem.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy: %s", strings.TrimSpace(err.Error()))
+	}
+	cpc := &pb.CollectionPolicyConfig{
+		Payload: &pb.CollectionPolicyConfig_SignaturePolicy{
+			SignaturePolicy: p,
+		},
+	}
+
+	ep, err := newCollectionEndorsementPolicy(cconfitem.EndorsementPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CollectionConfig{
+		Payload: &pb.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &pb.StaticCollectionConfig{
+				Name:              cconfitem.Name,
+				MemberOrgsPolicy:  cpc,
+				RequiredPeerCount: cconfitem.RequiredCount,
+				MaximumPeerCount:  cconfitem.MaxPeerCount,
+				MemberOnlyRead:    cconfitem.MemberOnlyRead,
+				MemberOnlyWrite:   cconfitem.MemberOnlyWrite,
+				BlockToLive:       cconfitem.BlockToLive,
+				EndorsementPolicy: ep,
 			},
+		},
+	}, nil
+}
+
+func newCollectionEndorsementPolicy(policy *CollectionEndorsementPolicyJSON) (*pb.ApplicationPolicy, error) {
+	switch {
+	case policy == nil:
+		return nil, nil
+	case policy.SignaturePolicy != "" && policy.ChannelConfigPolicy != "":
+		return nil, errors.New("endorsementPolicy cannot contain both signaturePolicy and channelConfigPolicy")
+	case policy.SignaturePolicy != "":
+		p, err := policydsl.FromString(policy.SignaturePolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endorsement signature policy: %s", strings.TrimSpace(err.Error()))
 		}
-		cc := &pb.CollectionConfig{
-			Payload: &pb.CollectionConfig_StaticCollectionConfig{
-				StaticCollectionConfig: &pb.StaticCollectionConfig{
-					Name:              cconfitem.Name,
-					MemberOrgsPolicy:  cpc,
-					RequiredPeerCount: cconfitem.RequiredCount,
-					MaximumPeerCount:  cconfitem.MaxPeerCount,
-					MemberOnlyRead:    cconfitem.MemberOnlyRead,
-					MemberOnlyWrite:   cconfitem.MemberOnlyWrite,
-					BlockToLive:       cconfitem.BlockToLive,
-				},
+
+		return &pb.ApplicationPolicy{
+			Type: &pb.ApplicationPolicy_SignaturePolicy{
+				SignaturePolicy: p,
+			},
+		}, nil
+	case policy.ChannelConfigPolicy != "":
+		return &pb.ApplicationPolicy{
+			Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+				ChannelConfigPolicyReference: policy.ChannelConfigPolicy,
 			},
+		}, nil
+	default:
+		return nil, errors.New("endorsementPolicy must contain either signaturePolicy or channelConfigPolicy")
+	}
+}
+
+// isJSONArray returns true if the given document is a JSON array rather than YAML
+func isJSONArray(bytes []byte) bool {
+	return strings.HasPrefix(strings.TrimSpace(string(bytes)), "[")
+}
+
+// jsonErrorString adds the line of a JSON syntax error to its message
+func jsonErrorString(bytes []byte, err error) string {
+	var offset int64
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+	default:
+		return err.Error()
+	}
+
+	if offset > int64(len(bytes)) {
+		offset = int64(len(bytes))
+	}
+
+	return fmt.Sprintf("line %d: %s", 1+strings.Count(string(bytes[:offset]), "\n"), err)
+}
+
+// jsonItemLines returns the line of each object in the top level array of a JSON document
+func jsonItemLines(bytes []byte) []int {
+	var (
+		lines    []int
+		line     = 1
+		depth    int
+		inString bool
+		escaped  bool
+	)
+
+	for _, b := range bytes {
+		switch {
+		case b == '\n':
+			line++
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+		case b == '"':
+			inString = true
+		case b == '[' || b == '{':
+			depth++
+			if depth == 2 {
+				lines = append(lines, line)
+			}
+		case b == ']' || b == '}':
+			depth--
 		}
-		ccarray = append(ccarray, cc)
 	}
-	return ccarray, nil
+
+	return lines
+}
+
+// yamlItemLines returns the line of each item in the top level sequence of a YAML document
+func yamlItemLines(bytes []byte) []int {
+	var lines []int
+
+	indent := -1
+	for i, l := range strings.Split(string(bytes), "\n") {
+		trimmed := strings.TrimLeft(l, " ")
+		if trimmed != "-" && !strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+
+		n := len(l) - len(trimmed)
+		if indent == -1 {
+			indent = n
+		}
+
+		if n == indent {
+			lines = append(lines, i+1)
+		}
+	}
+
+	return lines
+}
+
+// GetCollectionsConfigJSON returns the given collection config in the format read by
+// GetCollectionsConfigFromBytes
+func GetCollectionsConfigJSON(configs []*pb.CollectionConfig) ([]CollectionConfigJSON, error) {
+	cconf := make([]CollectionConfigJSON, 0, len(configs))
+	for _, config := range configs {
+		static := config.GetStaticCollectionConfig()
+		if static == nil {
+			return nil, errors.New("unsupported collection config type")
+		}
+
+		policy, err := GetPolicyString(static.GetMemberOrgsPolicy().GetSignaturePolicy())
+		if err != nil {
+			return nil, fmt.Errorf("invalid member orgs policy of collection '%s': %s", static.Name, err)
+		}
+
+		ep, err := getCollectionEndorsementPolicyJSON(static.EndorsementPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endorsement policy of collection '%s': %s", static.Name, err)
+		}
+
+		cconf = append(cconf, CollectionConfigJSON{
+			Name:              static.Name,
+			Policy:            policy,
+			RequiredCount:     static.RequiredPeerCount,
+			MaxPeerCount:      static.MaximumPeerCount,
+			BlockToLive:       static.BlockToLive,
+			MemberOnlyRead:    static.MemberOnlyRead,
+			MemberOnlyWrite:   static.MemberOnlyWrite,
+			EndorsementPolicy: ep,
+		})
+	}
+
+	return cconf, nil
+}
+
+func getCollectionEndorsementPolicyJSON(policy *pb.ApplicationPolicy) (*CollectionEndorsementPolicyJSON, error) {
+	switch t := policy.GetType().(type) {
+	case nil:
+		return nil, nil
+	case *pb.ApplicationPolicy_SignaturePolicy:
+		s, err := GetPolicyString(t.SignaturePolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CollectionEndorsementPolicyJSON{SignaturePolicy: s}, nil
+	case *pb.ApplicationPolicy_ChannelConfigPolicyReference:
+		return &CollectionEndorsementPolicyJSON{ChannelConfigPolicy: t.ChannelConfigPolicyReference}, nil
+	default:
+		return nil, errors.New("unsupported application policy type")
+	}
 }
 
 // GetChaincodePolicy returns the signature policy from the given policy string
@@ -85,6 +337,304 @@ func GetChaincodePolicy(policyString string) (*common.SignaturePolicyEnvelope, e
 	return policy, nil
 }
 
+// GetPolicyString returns the policy DSL for the given signature policy
+func GetPolicyString(policy *common.SignaturePolicyEnvelope) (string, error) {
+	if policy == nil || policy.Rule == nil {
+		return "", errors.New("signature policy has no rule")
+	}
+
+	return policyRuleString(policy.Rule, policy.Identities)
+}
+
+func policyRuleString(rule *common.SignaturePolicy, identities []*msp.MSPPrincipal) (string, error) {
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(identities) {
+			return "", fmt.Errorf("signature policy references unknown identity %d", t.SignedBy)
+		}
+
+		return principalString(identities[t.SignedBy])
+	case *common.SignaturePolicy_NOutOf_:
+		rules := make([]string, 0, len(t.NOutOf.Rules))
+		for _, r := range t.NOutOf.Rules {
+			s, err := policyRuleString(r, identities)
+			if err != nil {
+				return "", err
+			}
+
+			rules = append(rules, s)
+		}
+
+		if operator := NOutOfOperator(t.NOutOf); operator != "OutOf" {
+			return fmt.Sprintf("%s(%s)", operator, strings.Join(rules, ", ")), nil
+		}
+
+		return fmt.Sprintf("OutOf(%d, %s)", t.NOutOf.N, strings.Join(rules, ", ")), nil
+	default:
+		return "", errors.New("unsupported signature policy rule")
+	}
+}
+
+// NOutOfOperator returns the DSL operator of an n out of rule, which is OR, AND or OutOf
+func NOutOfOperator(n *common.SignaturePolicy_NOutOf) string {
+	switch {
+	case len(n.Rules) > 1 && n.N == 1:
+		return "OR"
+	case len(n.Rules) > 1 && int(n.N) == len(n.Rules):
+		return "AND"
+	default:
+		return "OutOf"
+	}
+}
+
+func principalString(principal *msp.MSPPrincipal) (string, error) {
+	role, err := PrincipalRole(principal)
+	if err != nil {
+		return "", err
+	}
+
+	return "'" + RoleString(role) + "'", nil
+}
+
+// PrincipalRole returns the MSP role of a principal, which is the only classification the DSL produces
+func PrincipalRole(principal *msp.MSPPrincipal) (*msp.MSPRole, error) {
+	if principal.PrincipalClassification != msp.MSPPrincipal_ROLE {
+		return nil, fmt.Errorf("unsupported principal classification '%s'", principal.PrincipalClassification)
+	}
+
+	role := &msp.MSPRole{}
+	if err := proto.Unmarshal(principal.Principal, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// RoleString returns a role in the "MSP.role" notation of the DSL
+func RoleString(role *msp.MSPRole) string {
+	return role.MspIdentifier + "." + strings.ToLower(role.Role.String())
+}
+
+// Transient value encodings
+const (
+	TransientEncodingUTF8   = "utf8"
+	TransientEncodingBase64 = "base64"
+	TransientEncodingHex    = "hex"
+)
+
+// GetTransientMap builds a transient map from a JSON file containing an object of string values
+// and from "key=value" pairs, which take precedence over the file. The values are decoded with
+// the given encoding. Errors never contain values since transient data is usually private.
+func GetTransientMap(pairs []string, path string, encoding string) (map[string][]byte, error) {
+	if len(pairs) == 0 && path == "" {
+		return nil, nil
+	}
+
+	values := make(map[string]string)
+
+	if path != "" {
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("error reading transient file")
+		}
+
+		if err := json.Unmarshal(bytes, &values); err != nil {
+			return nil, errors.New("error unmarshalling transient file, expected a JSON object of string values")
+		}
+	}
+
+	for _, pair := range pairs {
+		i := strings.Index(pair, "=")
+		if i <= 0 {
+			return nil, errors.New("invalid transient argument, expected key=value")
+		}
+
+		values[pair[:i]] = pair[i+1:]
+	}
+
+	return DecodeTransientMap(values, encoding)
+}
+
+// DecodeTransientMap decodes the given transient data values with the given encoding
+func DecodeTransientMap(values map[string]string, encoding string) (map[string][]byte, error) {
+	transientMap := make(map[string][]byte, len(values))
+	for key, value := range values {
+		decoded, err := decodeTransientValue(value, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding transient value for key '%s': %s", key, err)
+		}
+
+		transientMap[key] = decoded
+	}
+
+	return transientMap, nil
+}
+
+func decodeTransientValue(value string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", TransientEncodingUTF8:
+		return []byte(value), nil
+	case TransientEncodingBase64:
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, errors.New("invalid base64 value")
+		}
+
+		return decoded, nil
+	case TransientEncodingHex:
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, errors.New("invalid hex value")
+		}
+
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding '%s'", encoding)
+	}
+}
+
+// Chaincode argument encodings
+const (
+	// ArgEncodingUTF8 passes the arguments unchanged
+	ArgEncodingUTF8 = "utf8"
+	// ArgEncodingPrefixed decodes the arguments according to their prefix (see GetByteArgs)
+	ArgEncodingPrefixed = "prefixed"
+
+	// ArgEncodingUsage is the usage of the --arg-encoding flag of the commands taking chaincode arguments
+	ArgEncodingUsage = "set the encoding of the arguments, 'utf8' to pass them unchanged or 'prefixed' to decode arguments " +
+		"prefixed with b64:, hex: or @<file> (prefix a value with utf8: to keep it unchanged)"
+)
+
+// Chaincode argument prefixes
+const (
+	argPrefixBase64 = "b64:"
+	argPrefixHex    = "hex:"
+	argPrefixUTF8   = "utf8:"
+	argPrefixFile   = "@"
+)
+
+// ChaincodeCtor contains a chaincode function and its arguments in the JSON format of the peer CLI,
+// e.g. {"Args":["fcn","arg1"]} or {"function":"fcn","Args":["arg1"]}
+type ChaincodeCtor struct {
+	Function string   `json:"function,omitempty"`
+	Args     []string `json:"Args"`
+}
+
+// GetChaincodeArgs returns the chaincode function and arguments from either the given function
+// and arguments, a peer CLI constructor or an arguments file. The arguments file contains either
+// a constructor or a JSON array of arguments which are appended to the given arguments.
+// Arguments are converted according to the given encoding (see GetByteArgs).
+func GetChaincodeArgs(fcn string, args []string, ctor string, argsFile string, encoding string) (string, [][]byte, error) {
+	if ctor != "" && (fcn != "" || len(args) > 0 || argsFile != "") {
+		return "", nil, errors.New("constructor cannot be combined with function or arguments")
+	}
+
+	if ctor != "" {
+		return getCtorArgs([]byte(ctor), encoding)
+	}
+
+	if argsFile != "" {
+		bytes, err := ioutil.ReadFile(argsFile)
+		if err != nil {
+			return "", nil, errors.New("error reading arguments file")
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(string(bytes)), "{") {
+			if fcn != "" || len(args) > 0 {
+				return "", nil, errors.New("constructor cannot be combined with function or arguments")
+			}
+
+			return getCtorArgs(bytes, encoding)
+		}
+
+		var fileArgs []string
+		if err := json.Unmarshal(bytes, &fileArgs); err != nil {
+			return "", nil, errors.New("error unmarshalling arguments file, expected a JSON array of strings or a constructor")
+		}
+
+		args = append(append([]string{}, args...), fileArgs...)
+	}
+
+	byteArgs, err := GetByteArgs(args, encoding)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fcn, byteArgs, nil
+}
+
+func getCtorArgs(bytes []byte, encoding string) (string, [][]byte, error) {
+	var ctor ChaincodeCtor
+	if err := json.Unmarshal(bytes, &ctor); err != nil {
+		return "", nil, errors.New("error unmarshalling constructor, expected {\"Args\":[...]}")
+	}
+
+	args := ctor.Args
+	fcn := ctor.Function
+
+	if fcn == "" {
+		if len(args) == 0 {
+			return "", nil, errors.New("constructor contains no function")
+		}
+
+		fcn, args = args[0], args[1:]
+	}
+
+	byteArgs, err := GetByteArgs(args, encoding)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fcn, byteArgs, nil
+}
+
+// GetByteArgs converts the given arguments into byte arrays. With the utf8 encoding the arguments
+// are passed unchanged. With the prefixed encoding an argument prefixed with "b64:" or "hex:" is
+// decoded, an argument prefixed with "@" is replaced by the content of the named file and the
+// prefix "utf8:" passes the rest of an argument unchanged.
+func GetByteArgs(strArgs []string, encoding string) ([][]byte, error) {
+	switch encoding {
+	case "", ArgEncodingUTF8:
+		return AsByteArgs(strArgs), nil
+	case ArgEncodingPrefixed:
+	default:
+		return nil, fmt.Errorf("unsupported argument encoding '%s'", encoding)
+	}
+
+	args := make([][]byte, len(strArgs))
+	for i, arg := range strArgs {
+		switch {
+		case strings.HasPrefix(arg, argPrefixUTF8):
+			args[i] = []byte(strings.TrimPrefix(arg, argPrefixUTF8))
+		case strings.HasPrefix(arg, argPrefixBase64):
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, argPrefixBase64))
+			if err != nil {
+				return nil, fmt.Errorf("invalid base64 value for argument %d", i)
+			}
+
+			args[i] = decoded
+		case strings.HasPrefix(arg, argPrefixHex):
+			decoded, err := hex.DecodeString(strings.TrimPrefix(arg, argPrefixHex))
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex value for argument %d", i)
+			}
+
+			args[i] = decoded
+		case strings.HasPrefix(arg, argPrefixFile):
+			bytes, err := ioutil.ReadFile(strings.TrimPrefix(arg, argPrefixFile))
+			if err != nil {
+				return nil, fmt.Errorf("error reading file for argument %d", i)
+			}
+
+			args[i] = bytes
+		default:
+			args[i] = []byte(arg)
+		}
+	}
+
+	return args, nil
+}
+
 // AsByteArgs converts the given string array into an array of byte arrays so that they
 // may be passed as chaincode arguments.
 func AsByteArgs(strArgs []string) [][]byte {
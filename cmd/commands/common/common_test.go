@@ -1,8 +1,13 @@
 package common
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,6 +44,147 @@ func TestGetCollectionsConfigFromBytesError(t *testing.T) {
 	assert.Nil(t, config)
 }
 
+const sampleCollectionsConfigYAML = `# private data of the asset contract
+- name: foo
+  policy: OR('A.member', 'B.member')
+  requiredPeerCount: 1
+  maxPeerCount: 3
+  blockToLive: 10
+  memberOnlyRead: true
+  endorsementPolicy:
+    signaturePolicy: AND('A.peer', 'B.peer')
+- name: bar
+  policy: "OR('A.member')"
+  maxPeerCount: 1
+  endorsementPolicy:
+    channelConfigPolicy: /Channel/Application/Endorsement
+`
+
+func TestGetCollectionsConfigFromBytesYAML(t *testing.T) {
+	config, err := GetCollectionsConfigFromBytes([]byte(sampleCollectionsConfigYAML))
+	assert.Nil(t, err)
+	assert.Len(t, config, 2)
+
+	foo := config[0].GetStaticCollectionConfig()
+	assert.Equal(t, "foo", foo.Name)
+	assert.Equal(t, int32(1), foo.RequiredPeerCount)
+	assert.Equal(t, uint64(10), foo.BlockToLive)
+	assert.True(t, foo.MemberOnlyRead)
+	assert.NotNil(t, foo.EndorsementPolicy.GetSignaturePolicy())
+
+	bar := config[1].GetStaticCollectionConfig()
+	assert.Equal(t, "/Channel/Application/Endorsement", bar.EndorsementPolicy.GetChannelConfigPolicyReference())
+}
+
+func TestGetCollectionsConfigFromBytesErrors(t *testing.T) {
+	for _, tc := range []struct {
+		config   string
+		expected string
+	}{
+		{
+			config: "[\n  {\"name\": \"foo\",}\n]",
+			expected: "error unmarshalling collections config: line 2: invalid character '}' looking " +
+				"for beginning of object key string",
+		},
+		{
+			config:   "- name: foo\n  policy: [",
+			expected: "error unmarshalling collections config: line 2: did not find expected node content",
+		},
+		{
+			config:   "- name: foo\n  policy: barf\n",
+			expected: "line 1: collection 'foo': invalid policy: unrecognized token 'barf' in policy string",
+		},
+		{
+			config: "[{\"name\": \"foo\", \"policy\": \"OR('A.member')\"},\n" +
+				" {\"name\": \"foo\", \"policy\": \"OR('A.member')\"}]",
+			expected: "line 2: duplicate collection name 'foo' (first defined on line 1)",
+		},
+		{
+			config:   "- policy: \"OR('A.member')\"\n",
+			expected: "line 1: collection name not specified",
+		},
+		{
+			config: "- name: _implicit_org_A\n  policy: \"OR('A.member')\"\n",
+			expected: "line 1: collection '_implicit_org_A': collection name must not start with " +
+				"'_implicit_org_', which is reserved for implicit collections",
+		},
+		{
+			config:   "- name: foo bar\n  policy: \"OR('A.member')\"\n",
+			expected: "line 1: collection 'foo bar': collection name may only contain letters, digits, '_' and '-'",
+		},
+		{
+			config:   "- name: foo\n  policy: \"OR('A.member')\"\n- name: bar\n  requiredPeerCount: 2\n",
+			expected: "line 3: collection 'bar': maxPeerCount (0) must not be less than requiredPeerCount (2)",
+		},
+		{
+			config:   "- name: foo\n  requiredPeerCount: -1\n",
+			expected: "line 1: collection 'foo': requiredPeerCount must not be negative, got -1",
+		},
+		{
+			config:   "- name: foo\n",
+			expected: "line 1: collection 'foo': policy not specified",
+		},
+		{
+			config: "- name: foo\n  policy: \"OR('A.member')\"\n  endorsementPolicy:\n" +
+				"    signaturePolicy: \"OR('A.peer')\"\n    channelConfigPolicy: Endorsement\n",
+			expected: "line 1: collection 'foo': endorsementPolicy cannot contain both signaturePolicy " +
+				"and channelConfigPolicy",
+		},
+		{
+			config: "- name: foo\n  policy: \"OR('A.member')\"\n  endorsementPolicy: {}\n",
+			expected: "line 1: collection 'foo': endorsementPolicy must contain either signaturePolicy " +
+				"or channelConfigPolicy",
+		},
+	} {
+		cconf, err := GetCollectionsConfigFromBytes([]byte(tc.config))
+		assert.Nil(t, cconf)
+		if assert.NotNil(t, err, tc.config) {
+			assert.Equal(t, tc.expected, err.Error())
+		}
+	}
+}
+
+func TestGetCollectionsConfigFromBytesTypeError(t *testing.T) {
+	cconf, err := GetCollectionsConfigFromBytes([]byte("[\n  {\"name\": 1}\n]"))
+	assert.Nil(t, cconf)
+	assert.Contains(t, err.Error(), "error unmarshalling collections config: line 2: json: cannot unmarshal number")
+}
+
+func TestGetCollectionsConfigJSONEndorsementPolicy(t *testing.T) {
+	config, err := GetCollectionsConfigFromBytes([]byte(sampleCollectionsConfigYAML))
+	assert.Nil(t, err)
+
+	cconf, err := GetCollectionsConfigJSON(config)
+	assert.Nil(t, err)
+	assert.Equal(t, &CollectionEndorsementPolicyJSON{SignaturePolicy: "AND('A.peer', 'B.peer')"}, cconf[0].EndorsementPolicy)
+	assert.Equal(t, &CollectionEndorsementPolicyJSON{ChannelConfigPolicy: "/Channel/Application/Endorsement"}, cconf[1].EndorsementPolicy)
+}
+
+func TestGetCollectionsConfigJSON(t *testing.T) {
+	config, err := GetCollectionsConfigFromBytes([]byte(sampleCollectionsConfigGood))
+	assert.Nil(t, err)
+
+	cconf, err := GetCollectionsConfigJSON(config)
+	assert.Nil(t, err)
+	assert.Equal(t, []CollectionConfigJSON{
+		{
+			Name:            "foo",
+			Policy:          "OR('A.member', 'B.member')",
+			RequiredCount:   3,
+			MaxPeerCount:    483279847,
+			BlockToLive:     10,
+			MemberOnlyRead:  true,
+			MemberOnlyWrite: true,
+		},
+	}, cconf)
+}
+
+func TestGetCollectionsConfigJSONError(t *testing.T) {
+	cconf, err := GetCollectionsConfigJSON([]*pb.CollectionConfig{{}})
+	assert.NotNil(t, err)
+	assert.Nil(t, cconf)
+}
+
 func TestGetChaincodePolicy(t *testing.T) {
 	policy, err := GetChaincodePolicy("OR('MSP.member', 'MSP.WITH.DOTS.member', 'MSP-WITH-DASHES.member')")
 	assert.Nil(t, err)
@@ -57,3 +203,148 @@ func TestAsByteArgs(t *testing.T) {
 	assert.Equal(t, "arg1", string(args[0]))
 	assert.Equal(t, "arg2", string(args[1]))
 }
+
+func TestGetPolicyString(t *testing.T) {
+	for _, policyString := range []string{
+		"OR('Org1MSP.member', 'Org2MSP.peer')",
+		"AND('Org1MSP.admin', 'Org2MSP.client')",
+		"OutOf(2, 'Org1MSP.member', 'Org2MSP.member', 'Org3MSP.member')",
+		"AND('Org1MSP.member', OR('Org2MSP.member', 'Org3MSP.orderer'))",
+	} {
+		policy, err := GetChaincodePolicy(policyString)
+		assert.Nil(t, err)
+
+		s, err := GetPolicyString(policy)
+		assert.Nil(t, err)
+		assert.Equal(t, policyString, s)
+	}
+}
+
+func TestGetPolicyStringError(t *testing.T) {
+	s, err := GetPolicyString(&cb.SignaturePolicyEnvelope{})
+	assert.NotNil(t, err)
+	assert.Empty(t, s)
+}
+
+func TestGetTransientMap(t *testing.T) {
+	transientMap, err := GetTransientMap(nil, "", "")
+	assert.Nil(t, err)
+	assert.Nil(t, transientMap)
+
+	transientMap, err = GetTransientMap([]string{"key1=value1", "key2=a=b"}, "", TransientEncodingUTF8)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"key1": []byte("value1"), "key2": []byte("a=b")}, transientMap)
+
+	transientMap, err = GetTransientMap([]string{"key1=dmFsdWUx"}, "", TransientEncodingBase64)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value1"), transientMap["key1"])
+
+	transientMap, err = GetTransientMap([]string{"key1=76616c756531"}, "", TransientEncodingHex)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value1"), transientMap["key1"])
+}
+
+func TestGetTransientMapFromFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "transient")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{"key1": "value1", "key2": "value2"}`)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	transientMap, err := GetTransientMap([]string{"key2=override"}, f.Name(), "")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"key1": []byte("value1"), "key2": []byte("override")}, transientMap)
+}
+
+func TestGetTransientMapError(t *testing.T) {
+	_, err := GetTransientMap([]string{"novalue"}, "", "")
+	assert.NotNil(t, err)
+
+	_, err = GetTransientMap(nil, "path/to/transient.json", "")
+	assert.NotNil(t, err)
+
+	_, err = GetTransientMap([]string{"key1=secret!"}, "", TransientEncodingBase64)
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Contains(t, err.Error(), "key1")
+
+	_, err = GetTransientMap([]string{"key1=secret"}, "", "rot13")
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestGetChaincodeArgs(t *testing.T) {
+	fcn, args, err := GetChaincodeArgs("move", []string{"a", "b64:Yg==", "hex:63", "utf8:hex:d"}, "", "", ArgEncodingPrefixed)
+	assert.Nil(t, err)
+	assert.Equal(t, "move", fcn)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("hex:d")}, args)
+
+	fcn, args, err = GetChaincodeArgs("move", []string{"b64:Yg==", "@file"}, "", "", ArgEncodingUTF8)
+	assert.Nil(t, err)
+	assert.Equal(t, "move", fcn)
+	assert.Equal(t, [][]byte{[]byte("b64:Yg=="), []byte("@file")}, args)
+
+	_, args, err = GetChaincodeArgs("", nil, `{"Args":["move","@a"]}`, "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("@a")}, args)
+
+	fcn, args, err = GetChaincodeArgs("", nil, `{"Args":["move","a","b"]}`, "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "move", fcn)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, args)
+
+	fcn, args, err = GetChaincodeArgs("", nil, `{"function":"move","Args":["a","b"]}`, "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "move", fcn)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, args)
+}
+
+func TestGetChaincodeArgsFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "args")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	payload := filepath.Join(dir, "payload.json")
+	assert.Nil(t, ioutil.WriteFile(payload, []byte(`{"owner":"alice"}`), 0600))
+
+	argsFile := filepath.Join(dir, "args.json")
+	assert.Nil(t, ioutil.WriteFile(argsFile, []byte(`["b", "@`+payload+`"]`), 0600))
+
+	fcn, args, err := GetChaincodeArgs("create", []string{"a"}, "", argsFile, ArgEncodingPrefixed)
+	assert.Nil(t, err)
+	assert.Equal(t, "create", fcn)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte(`{"owner":"alice"}`)}, args)
+
+	ctorFile := filepath.Join(dir, "ctor.json")
+	assert.Nil(t, ioutil.WriteFile(ctorFile, []byte(`{"Args":["create","a"]}`), 0600))
+
+	fcn, args, err = GetChaincodeArgs("", nil, "", ctorFile, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "create", fcn)
+	assert.Equal(t, [][]byte{[]byte("a")}, args)
+}
+
+func TestGetChaincodeArgsError(t *testing.T) {
+	_, _, err := GetChaincodeArgs("move", nil, `{"Args":["move"]}`, "", "")
+	assert.NotNil(t, err)
+
+	_, _, err = GetChaincodeArgs("", nil, `{"Args":[]}`, "", "")
+	assert.NotNil(t, err)
+
+	_, _, err = GetChaincodeArgs("", nil, `not json`, "", "")
+	assert.NotNil(t, err)
+
+	_, _, err = GetChaincodeArgs("move", []string{"b64:!"}, "", "", ArgEncodingPrefixed)
+	assert.NotNil(t, err)
+
+	_, _, err = GetChaincodeArgs("move", []string{"@path/to/file"}, "", "", ArgEncodingPrefixed)
+	assert.NotNil(t, err)
+
+	_, _, err = GetChaincodeArgs("move", nil, "", "path/to/args.json", "")
+	assert.NotNil(t, err)
+
+	_, _, err = GetChaincodeArgs("move", []string{"a"}, "", "", "base32")
+	assert.NotNil(t, err)
+}
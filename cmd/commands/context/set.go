@@ -28,7 +28,9 @@ func NewContextSetCommand(settings *environment.Settings) *cobra.Command {
 		Short: "Set a context",
 		Long:  "Set a context into config.yaml",
 		Args:  c.ParseArgs(),
-		PreRunE: func(_ *cobra.Command, _ []string) error {
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			c.SetGateway = cmd.Flags().Changed("gateway")
+
 			return c.Validate()
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
@@ -44,6 +46,11 @@ func NewContextSetCommand(settings *environment.Settings) *cobra.Command {
 	flags.StringVar(&c.Context.Channel, "channel", "", "Set the channel context")
 	flags.StringVar(&c.Context.User, "user", "", "Set the user context")
 	flags.StringArrayVar(&c.Context.Peers, "peers", []string{}, "Set the peers context")
+	flags.BoolVar(&c.Context.Gateway, "gateway", false, "Send transactions through the Gateway service of a Fabric 2.4 peer")
+	flags.StringVar(&c.Context.GatewayEndpoint, "gateway-endpoint", "", "Set the gateway peer URL (defaults to the first peer of the context)")
+	flags.StringVar(&c.Context.GatewayTLSCACert, "gateway-tls-ca-cert", "", "Set the path to the TLS CA certificate of the gateway peer")
+	flags.StringVar(&c.Context.GatewayServerName, "gateway-server-name", "",
+		"Set the TLS server name of the gateway peer (defaults to the ssl-target-name-override of the first peer)")
 
 	cmd.SetOutput(c.Settings.Streams.Out)
 
@@ -56,6 +63,9 @@ type SetCommand struct {
 
 	Name    string
 	Context *environment.Context
+
+	// SetGateway is true when --gateway is given, which may also disable the gateway
+	SetGateway bool
 }
 
 // Validate checks the required parameters for run
@@ -63,7 +73,12 @@ func (c *SetCommand) Validate() error {
 	if c.Context == nil ||
 		(len(c.Context.Network) == 0 &&
 			len(c.Context.Organization) == 0 &&
-			len(c.Context.User) == 0) {
+			len(c.Context.User) == 0 &&
+			!c.Context.Gateway &&
+			!c.SetGateway &&
+			len(c.Context.GatewayEndpoint) == 0 &&
+			len(c.Context.GatewayTLSCACert) == 0 &&
+			len(c.Context.GatewayServerName) == 0) {
 		return errors.New("context details not specified")
 	}
 
@@ -81,7 +96,12 @@ func (c *SetCommand) Run() error {
 		c.Name = c.Settings.Config.CurrentContext
 	}
 
-	err := c.Settings.ModifyConfig(environment.SetContext(c.Name, c.Context))
+	actions := []environment.Action{environment.SetContext(c.Name, c.Context)}
+	if c.SetGateway {
+		actions = append(actions, environment.SetContextGateway(c.Name, c.Context.Gateway))
+	}
+
+	err := c.Settings.ModifyConfig(actions...)
 	if err != nil {
 		return err
 	}
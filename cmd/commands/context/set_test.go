@@ -96,6 +96,17 @@ var _ = Describe("SetContextImplementation", func() {
 			Expect(err).NotTo(BeNil())
 		})
 
+		Context("when only --gateway is set", func() {
+			BeforeEach(func() {
+				impl.Context = &environment.Context{}
+				impl.SetGateway = true
+			})
+
+			It("should successfully validate", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("when context network is set", func() {
 			BeforeEach(func() {
 				impl.Context = &environment.Context{
@@ -148,6 +159,25 @@ var _ = Describe("SetContextImplementation", func() {
 			})
 		})
 
+		Context("when the gateway is disabled", func() {
+			BeforeEach(func() {
+				settings.Config = &environment.Config{
+					Contexts: map[string]*environment.Context{
+						"bar": {Organization: "Org1", Gateway: true},
+					},
+					CurrentContext: "bar",
+				}
+				impl.Context = &environment.Context{}
+				impl.SetGateway = true
+			})
+
+			It("should switch the context back to the SDK", func() {
+				Expect(err).To(BeNil())
+				Expect(settings.Config.Contexts["bar"].Gateway).To(BeFalse())
+				Expect(settings.Config.Contexts["bar"].Organization).To(Equal("Org1"))
+			})
+		})
+
 		Context("when current context is not set", func() {
 			BeforeEach(func() {
 				settings.Config = &environment.Config{
@@ -0,0 +1,164 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewConfigCommand creates a new "fabric discover config" command
+func NewConfigCommand(settings *environment.Settings) *cobra.Command {
+	c := ConfigCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show the MSPs and orderers of a channel",
+		Long:  "Show the MSPs and orderer endpoints of a channel as seen by service discovery",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
+			return c.Run()
+		},
+	}
+
+	c.addFlags(cmd)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// ConfigCommand implements the discover config command
+type ConfigCommand struct {
+	BaseCommand
+}
+
+// configJSON is the channel config in the JSON output
+type configJSON struct {
+	MSPs     []mspJSON     `json:"msps"`
+	Orderers []ordererJSON `json:"orderers"`
+}
+
+// mspJSON is an MSP of the channel config with PEM encoded certificates
+type mspJSON struct {
+	ID                   string   `json:"id"`
+	RootCerts            []string `json:"root_certs"`
+	IntermediateCerts    []string `json:"intermediate_certs,omitempty"`
+	Admins               []string `json:"admins,omitempty"`
+	TLSRootCerts         []string `json:"tls_root_certs,omitempty"`
+	TLSIntermediateCerts []string `json:"tls_intermediate_certs,omitempty"`
+	NodeOUs              bool     `json:"node_ous"`
+}
+
+// ordererJSON are the orderer endpoints of an organization
+type ordererJSON struct {
+	MSPID     string   `json:"mspid"`
+	Endpoints []string `json:"endpoints"`
+}
+
+// Validate checks the required parameters for run
+func (c *ConfigCommand) Validate() error {
+	return c.validateOutputFormat()
+}
+
+// Run executes the command
+func (c *ConfigCommand) Run() error {
+	channel, err := c.channel()
+	if err != nil {
+		return err
+	}
+
+	if channel == "" {
+		return errors.New("channel not specified")
+	}
+
+	config, err := c.Discovery.Config(channel)
+	if err != nil {
+		return err
+	}
+
+	result := configJSON{MSPs: []mspJSON{}, Orderers: []ordererJSON{}}
+
+	for id, msp := range config.Msps {
+		result.MSPs = append(result.MSPs, mspJSON{
+			ID:                   id,
+			RootCerts:            pemStrings(msp.RootCerts),
+			IntermediateCerts:    pemStrings(msp.IntermediateCerts),
+			Admins:               pemStrings(msp.Admins),
+			TLSRootCerts:         pemStrings(msp.TlsRootCerts),
+			TLSIntermediateCerts: pemStrings(msp.TlsIntermediateCerts),
+			NodeOUs:              msp.GetFabricNodeOus().GetEnable(),
+		})
+	}
+
+	for mspID, endpoints := range config.Orderers {
+		orderer := ordererJSON{MSPID: mspID, Endpoints: []string{}}
+		for _, endpoint := range endpoints.Endpoint {
+			orderer.Endpoints = append(orderer.Endpoints, fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port))
+		}
+
+		result.Orderers = append(result.Orderers, orderer)
+	}
+
+	sort.Slice(result.MSPs, func(i, j int) bool { return result.MSPs[i].ID < result.MSPs[j].ID })
+	sort.Slice(result.Orderers, func(i, j int) bool { return result.Orderers[i].MSPID < result.Orderers[j].MSPID })
+
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		return json.NewEncoder(out).Encode(result)
+	}
+
+	w := tabwriter.NewWriter(out, 4, 4, 4, ' ', 0)
+
+	fmt.Fprintln(w, "MSP ID\tROOT CERTS\tINTERMEDIATE CERTS\tADMINS\tTLS ROOT CERTS\tNODE OUS")
+
+	for _, msp := range result.MSPs {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%t\n", msp.ID, len(msp.RootCerts), len(msp.IntermediateCerts),
+			len(msp.Admins), len(msp.TLSRootCerts), msp.NodeOUs)
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "ORDERER MSP ID\tENDPOINTS")
+
+	for _, orderer := range result.Orderers {
+		fmt.Fprintf(w, "%s\t%s\n", orderer.MSPID, strings.Join(orderer.Endpoints, ", "))
+	}
+
+	return w.Flush()
+}
+
+func pemStrings(certs [][]byte) []string {
+	var pems []string
+	for _, cert := range certs {
+		pems = append(pems, string(cert))
+	}
+
+	return pems
+}
@@ -0,0 +1,173 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/discover"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("DiscoverConfigCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = discover.NewConfigCommand(settings)
+	})
+
+	It("should create a discover config command", func() {
+		Expect(cmd.Name()).To(Equal("config"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+})
+
+var _ = Describe("DiscoverConfigImplementation", func() {
+	var (
+		impl      *discover.ConfigCommand
+		err       error
+		out       *bytes.Buffer
+		client    *mocks.Discovery
+		contextCh string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		client = &mocks.Discovery{}
+		contextCh = "mychannel"
+
+		impl = &discover.ConfigCommand{}
+		impl.Discovery = client
+
+		client.ConfigReturns(&discovery.ConfigResult{
+			Msps: map[string]*msp.FabricMSPConfig{
+				"Org2MSP": {Name: "Org2MSP", RootCerts: [][]byte{[]byte("root2")}},
+				"Org1MSP": {
+					Name:         "Org1MSP",
+					RootCerts:    [][]byte{[]byte("root1")},
+					Admins:       [][]byte{[]byte("admin1")},
+					TlsRootCerts: [][]byte{[]byte("tls1")},
+					FabricNodeOus: &msp.FabricNodeOUs{
+						Enable: true,
+					},
+				},
+			},
+			Orderers: map[string]*discovery.Endpoints{
+				"OrdererMSP": {
+					Endpoint: []*discovery.Endpoint{
+						{Host: "orderer0.example.com", Port: 7050},
+						{Host: "orderer1.example.com", Port: 8050},
+					},
+				},
+			},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {Channel: contextCh},
+				},
+				CurrentContext: "foo",
+			},
+		}
+
+		err = impl.Run()
+	})
+
+	It("should print the MSPs and orderers", func() {
+		Expect(err).To(BeNil())
+		Expect(client.ConfigArgsForCall(0)).To(Equal("mychannel"))
+		Expect(fmt.Sprint(out)).To(Equal(
+			"MSP ID     ROOT CERTS    INTERMEDIATE CERTS    ADMINS    TLS ROOT CERTS    NODE OUS\n" +
+				"Org1MSP    1             0                     1         1                 true\n" +
+				"Org2MSP    1             0                     0         0                 false\n" +
+				"\n" +
+				"ORDERER MSP ID    ENDPOINTS\n" +
+				"OrdererMSP        orderer0.example.com:7050, orderer1.example.com:8050\n"))
+	})
+
+	Context("when the output format is json", func() {
+		BeforeEach(func() {
+			impl.OutputFormat = "json"
+		})
+
+		It("should print the config as JSON", func() {
+			Expect(err).To(BeNil())
+
+			var config map[string]interface{}
+			Expect(json.Unmarshal(out.Bytes(), &config)).To(Succeed())
+			Expect(config["msps"]).To(HaveLen(2))
+			Expect(config["msps"].([]interface{})[0]).To(Equal(map[string]interface{}{
+				"id":             "Org1MSP",
+				"root_certs":     []interface{}{"root1"},
+				"admins":         []interface{}{"admin1"},
+				"tls_root_certs": []interface{}{"tls1"},
+				"node_ous":       true,
+			}))
+			Expect(config["orderers"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"mspid":     "OrdererMSP",
+					"endpoints": []interface{}{"orderer0.example.com:7050", "orderer1.example.com:8050"},
+				},
+			}))
+		})
+	})
+
+	Context("when no channel is set", func() {
+		BeforeEach(func() {
+			contextCh = ""
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("channel not specified"))
+		})
+	})
+
+	Context("when discovery fails", func() {
+		BeforeEach(func() {
+			client.ConfigReturns(nil, errors.New("access denied"))
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("access denied"))
+		})
+	})
+})
@@ -0,0 +1,190 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+const jsonFormat = "json"
+
+// NewCommand creates a new "fabric discover" command
+func NewCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "discover",
+		Short: "Query the service discovery of a peer",
+		Long: "Query the service discovery of a peer with peers|config|endorsers\n\n" +
+			"The queries are sent to the peer given by --peer or else to the first peer of the current\n" +
+			"context, with the identity of the user of the current context.",
+	}
+
+	cmd.AddCommand(
+		NewPeersCommand(settings),
+		NewConfigCommand(settings),
+		NewEndorsersCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// BaseCommand implements common discover command functions
+type BaseCommand struct {
+	common.Command
+
+	Factory   fabric.Factory
+	Discovery fabric.Discovery
+
+	Peer         string
+	ChannelID    string
+	OutputFormat string
+}
+
+// Complete initializes all clients needed for Run
+func (c *BaseCommand) Complete() error {
+	var err error
+
+	if c.Factory == nil {
+		c.Factory, err = fabric.NewFactory(c.Settings.Config)
+		if err != nil {
+			return err
+		}
+	}
+
+	context, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := c.Factory.SDK()
+	if err != nil {
+		return err
+	}
+
+	peer := c.Peer
+	if peer == "" && len(context.Peers) > 0 {
+		peer = context.Peers[0]
+	}
+
+	if peer == "" {
+		peers, err := fabric.OrganizationPeers(sdk, context.Organization)
+		if err != nil {
+			return err
+		}
+
+		if len(peers) == 0 {
+			return errors.New("no peer to query")
+		}
+
+		peer = peers[0]
+	}
+
+	ctx, err := sdk.Context(fabsdk.WithUser(context.User), fabsdk.WithOrg(context.Organization))()
+	if err != nil {
+		return err
+	}
+
+	c.Discovery, err = fabric.NewPeerDiscovery(ctx, peer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close releases the connection of the discovery client
+func (c *BaseCommand) Close() error {
+	if closer, ok := c.Discovery.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// validateOutputFormat checks that the output format is text or JSON
+func (c *BaseCommand) validateOutputFormat() error {
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// channel returns the channel given by --channel or else the channel of the current context
+func (c *BaseCommand) channel() (string, error) {
+	if c.ChannelID != "" {
+		return c.ChannelID, nil
+	}
+
+	context, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return "", err
+	}
+
+	return context.Channel, nil
+}
+
+// addFlags adds the flags shared by the discover commands
+func (c *BaseCommand) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&c.Peer, "peer", "", "Set the peer to query (defaults to the first peer of the current context)")
+	flags.StringVar(&c.ChannelID, "channel", "", "Set the channel to query instead of the channel of the current context")
+	flags.StringVar(&c.OutputFormat, "output", "", "Set the output format, 'json' or a human-readable table if not set")
+}
+
+// peerJSON is a discovered peer in the JSON output
+type peerJSON struct {
+	MSPID        string          `json:"mspid"`
+	Endpoint     string          `json:"endpoint"`
+	LedgerHeight uint64          `json:"ledger_height,omitempty"`
+	Chaincodes   []chaincodeJSON `json:"chaincodes,omitempty"`
+}
+
+// chaincodeJSON is a chaincode installed on a discovered peer
+type chaincodeJSON struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func newPeerJSON(peer *fabric.DiscoveredPeer) peerJSON {
+	p := peerJSON{
+		MSPID:        peer.MSPID,
+		Endpoint:     peer.Endpoint,
+		LedgerHeight: peer.LedgerHeight,
+	}
+
+	for _, cc := range peer.Chaincodes {
+		p.Chaincodes = append(p.Chaincodes, chaincodeJSON{Name: cc.Name, Version: cc.Version})
+	}
+
+	return p
+}
+
+// chaincodesString lists the chaincodes of a peer as name:version
+func chaincodesString(peer *fabric.DiscoveredPeer) string {
+	if len(peer.Chaincodes) == 0 {
+		return "-"
+	}
+
+	var names []string
+	for _, cc := range peer.Chaincodes {
+		names = append(names, cc.Name+":"+cc.Version)
+	}
+
+	return strings.Join(names, ", ")
+}
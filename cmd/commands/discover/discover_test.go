@@ -0,0 +1,135 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/discover"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+// closingDiscovery records whether the discovery client was closed
+type closingDiscovery struct {
+	mocks.Discovery
+	closed bool
+}
+
+func (d *closingDiscovery) Close() error {
+	d.closed = true
+	return nil
+}
+
+func TestDiscover(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Discover Suite")
+}
+
+var _ = Describe("DiscoverCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = discover.NewCommand(settings)
+	})
+
+	It("should create a discover command", func() {
+		Expect(cmd.Name()).To(Equal("discover"))
+		Expect(cmd.HasSubCommands()).To(BeTrue())
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("discover [command]"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("peers"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("config"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("endorsers"))
+	})
+})
+
+var _ = Describe("BaseDiscoverCommand", func() {
+	var (
+		c       *discover.BaseCommand
+		factory *mocks.Factory
+		err     error
+	)
+
+	BeforeEach(func() {
+		factory = &mocks.Factory{}
+
+		c = &discover.BaseCommand{}
+		c.Factory = factory
+		c.Settings = &environment.Settings{
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {Peers: []string{"peer0"}},
+				},
+				CurrentContext: "foo",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = c.Complete()
+	})
+
+	Context("when factory fails to create the sdk", func() {
+		BeforeEach(func() {
+			factory.SDKReturns(nil, errors.New("factory error"))
+		})
+
+		It("should fail with factory error", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("factory error"))
+		})
+	})
+
+	Context("when there is no current context", func() {
+		BeforeEach(func() {
+			c.Settings.Config.CurrentContext = ""
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
+
+var _ = Describe("BaseDiscoverCommand.Close", func() {
+	It("should close a discovery client which holds a connection", func() {
+		client := &closingDiscovery{}
+
+		c := &discover.BaseCommand{Discovery: client}
+		Expect(c.Close()).To(Succeed())
+		Expect(client.closed).To(BeTrue())
+	})
+
+	It("should ignore other discovery clients", func() {
+		c := &discover.BaseCommand{Discovery: &mocks.Discovery{}}
+		Expect(c.Close()).To(Succeed())
+	})
+})
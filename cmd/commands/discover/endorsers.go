@@ -0,0 +1,169 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewEndorsersCommand creates a new "fabric discover endorsers" command
+func NewEndorsersCommand(settings *environment.Settings) *cobra.Command {
+	c := EndorsersCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "endorsers <chaincode-name>",
+		Short: "Show the endorsement layouts of a chaincode",
+		Long: "Show the endorsement layouts of a chaincode as computed by service discovery. Each layout is a\n" +
+			"number of peers per group which together satisfy the endorsement policy of the chaincode and\n" +
+			"of the collections set by --collection.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodeName)
+
+	c.addFlags(cmd)
+	cmd.Flags().StringArrayVar(&c.Collections, "collection", []string{},
+		"Set a private data collection written by the chaincode (this option may be specified multiple times)")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// EndorsersCommand implements the discover endorsers command
+type EndorsersCommand struct {
+	BaseCommand
+
+	ChaincodeName string
+	Collections   []string
+}
+
+// endorsersJSON is the endorsement plan in the JSON output
+type endorsersJSON struct {
+	Chaincode string                `json:"chaincode"`
+	Layouts   []map[string]uint32   `json:"layouts"`
+	Groups    map[string][]peerJSON `json:"groups"`
+}
+
+// Validate checks the required parameters for run
+func (c *EndorsersCommand) Validate() error {
+	if len(c.ChaincodeName) == 0 {
+		return errors.New("chaincode name not specified")
+	}
+
+	return c.validateOutputFormat()
+}
+
+// Run executes the command
+func (c *EndorsersCommand) Run() error {
+	channel, err := c.channel()
+	if err != nil {
+		return err
+	}
+
+	if channel == "" {
+		return errors.New("channel not specified")
+	}
+
+	plan, err := c.Discovery.Endorsers(channel, c.ChaincodeName, c.Collections...)
+	if err != nil {
+		return err
+	}
+
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		result := endorsersJSON{
+			Chaincode: plan.Chaincode,
+			Layouts:   plan.Layouts,
+			Groups:    make(map[string][]peerJSON),
+		}
+
+		if result.Layouts == nil {
+			result.Layouts = []map[string]uint32{}
+		}
+
+		for group, peers := range plan.Groups {
+			result.Groups[group] = []peerJSON{}
+			for _, peer := range peers {
+				result.Groups[group] = append(result.Groups[group], newPeerJSON(peer))
+			}
+		}
+
+		return json.NewEncoder(out).Encode(result)
+	}
+
+	fmt.Fprintf(out, "Chaincode: %s\n", plan.Chaincode)
+
+	if len(plan.Layouts) == 0 {
+		fmt.Fprintln(out, "No endorsement layouts")
+	}
+
+	for i, layout := range plan.Layouts {
+		var groups []string
+		for group := range layout {
+			groups = append(groups, group)
+		}
+
+		sort.Strings(groups)
+
+		var quantities []string
+		for _, group := range groups {
+			quantities = append(quantities, fmt.Sprintf("%d of %s", layout[group], group))
+		}
+
+		fmt.Fprintf(out, "Layout %d: %s\n", i+1, strings.Join(quantities, ", "))
+	}
+
+	var groups []string
+	for group := range plan.Groups {
+		groups = append(groups, group)
+	}
+
+	sort.Strings(groups)
+
+	fmt.Fprintln(out, "")
+
+	w := tabwriter.NewWriter(out, 4, 4, 4, ' ', 0)
+
+	fmt.Fprintln(w, "GROUP\tMSP ID\tENDPOINT\tLEDGER HEIGHT\tCHAINCODES")
+
+	for _, group := range groups {
+		for _, peer := range plan.Groups[group] {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", group, peer.MSPID, peer.Endpoint, peer.LedgerHeight, chaincodesString(peer))
+		}
+	}
+
+	return w.Flush()
+}
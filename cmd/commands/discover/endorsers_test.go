@@ -0,0 +1,175 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/discover"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("DiscoverEndorsersCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = discover.NewEndorsersCommand(settings)
+	})
+
+	It("should create a discover endorsers command", func() {
+		Expect(cmd.Name()).To(Equal("endorsers"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+		Expect(cmd.Flag("collection")).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("DiscoverEndorsersImplementation", func() {
+	var (
+		impl   *discover.EndorsersCommand
+		err    error
+		out    *bytes.Buffer
+		client *mocks.Discovery
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		client = &mocks.Discovery{}
+
+		impl = &discover.EndorsersCommand{}
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {Channel: "mychannel"},
+				},
+				CurrentContext: "foo",
+			},
+		}
+		impl.Discovery = client
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should fail without chaincode name", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("chaincode name not specified"))
+		})
+
+		Context("when chaincode name is set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+			})
+
+			It("should succeed", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			impl.ChaincodeName = "mycc"
+			impl.Collections = []string{"coll1"}
+
+			client.EndorsersReturns(&fabric.EndorsementPlan{
+				Chaincode: "mycc",
+				Groups: map[string][]*fabric.DiscoveredPeer{
+					"G1": {
+						{MSPID: "Org2MSP", Endpoint: "peer0.org2.example.com:9051", LedgerHeight: 5},
+					},
+					"G0": {
+						{
+							MSPID:        "Org1MSP",
+							Endpoint:     "peer0.org1.example.com:7051",
+							LedgerHeight: 6,
+							Chaincodes:   []*gossip.Chaincode{{Name: "mycc", Version: "1.0"}},
+						},
+					},
+				},
+				Layouts: []map[string]uint32{
+					{"G0": 1, "G1": 1},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the layouts and groups", func() {
+			Expect(err).To(BeNil())
+
+			channel, chaincode, collections := client.EndorsersArgsForCall(0)
+			Expect(channel).To(Equal("mychannel"))
+			Expect(chaincode).To(Equal("mycc"))
+			Expect(collections).To(Equal([]string{"coll1"}))
+
+			Expect(fmt.Sprint(out)).To(Equal(
+				"Chaincode: mycc\n" +
+					"Layout 1: 1 of G0, 1 of G1\n" +
+					"\n" +
+					"GROUP    MSP ID     ENDPOINT                       LEDGER HEIGHT    CHAINCODES\n" +
+					"G0       Org1MSP    peer0.org1.example.com:7051    6                mycc:1.0\n" +
+					"G1       Org2MSP    peer0.org2.example.com:9051    5                -\n"))
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "json"
+			})
+
+			It("should print the endorsement plan as JSON", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(Equal(`{"chaincode":"mycc","layouts":[{"G0":1,"G1":1}],"groups":{` +
+					`"G0":[{"mspid":"Org1MSP","endpoint":"peer0.org1.example.com:7051","ledger_height":6,"chaincodes":[{"name":"mycc","version":"1.0"}]}],` +
+					`"G1":[{"mspid":"Org2MSP","endpoint":"peer0.org2.example.com:9051","ledger_height":5}]}}` + "\n"))
+			})
+		})
+
+		Context("when discovery fails", func() {
+			BeforeEach(func() {
+				client.EndorsersReturns(nil, errors.New("discovery failed: no endorsement plan"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("discovery failed: no endorsement plan"))
+			})
+		})
+	})
+})
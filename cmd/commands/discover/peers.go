@@ -0,0 +1,107 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewPeersCommand creates a new "fabric discover peers" command
+func NewPeersCommand(settings *environment.Settings) *cobra.Command {
+	c := PeersCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "peers",
+		Short: "Show the peers of a channel",
+		Long: "Show the endpoint, MSP, ledger height and chaincodes of the peers of a channel as seen by\n" +
+			"service discovery, or the peers known to the queried peer if no channel is set",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			defer c.Close()
+
+			return c.Run()
+		},
+	}
+
+	c.addFlags(cmd)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// PeersCommand implements the discover peers command
+type PeersCommand struct {
+	BaseCommand
+}
+
+// Validate checks the required parameters for run
+func (c *PeersCommand) Validate() error {
+	return c.validateOutputFormat()
+}
+
+// Run executes the command
+func (c *PeersCommand) Run() error {
+	channel, err := c.channel()
+	if err != nil {
+		return err
+	}
+
+	peers, err := c.Discovery.Peers(channel)
+	if err != nil {
+		return err
+	}
+
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		result := []peerJSON{}
+		for _, peer := range peers {
+			result = append(result, newPeerJSON(peer))
+		}
+
+		return json.NewEncoder(out).Encode(result)
+	}
+
+	if len(peers) == 0 {
+		fmt.Fprintln(out, "No peers found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 4, 4, 4, ' ', 0)
+
+	fmt.Fprintln(w, "MSP ID\tENDPOINT\tLEDGER HEIGHT\tCHAINCODES")
+
+	for _, peer := range peers {
+		height := "-"
+		if channel != "" {
+			height = fmt.Sprint(peer.LedgerHeight)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", peer.MSPID, peer.Endpoint, height, chaincodesString(peer))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,182 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/discover"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("DiscoverPeersCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = discover.NewPeersCommand(settings)
+	})
+
+	It("should create a discover peers command", func() {
+		Expect(cmd.Name()).To(Equal("peers"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+		Expect(cmd.Flag("channel")).NotTo(BeNil())
+		Expect(cmd.Flag("peer")).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("DiscoverPeersImplementation", func() {
+	var (
+		impl      *discover.PeersCommand
+		err       error
+		out       *bytes.Buffer
+		discovery *mocks.Discovery
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		discovery = &mocks.Discovery{}
+
+		impl = &discover.PeersCommand{}
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {Channel: "mychannel"},
+				},
+				CurrentContext: "foo",
+			},
+		}
+		impl.Discovery = discovery
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed without arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "xml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'xml'"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			discovery.PeersReturns([]*fabric.DiscoveredPeer{
+				{
+					MSPID:        "Org1MSP",
+					Endpoint:     "peer0.org1.example.com:7051",
+					LedgerHeight: 12,
+					Chaincodes:   []*gossip.Chaincode{{Name: "basic", Version: "1.0"}, {Name: "_lifecycle", Version: "1"}},
+				},
+				{
+					MSPID:        "Org2MSP",
+					Endpoint:     "peer0.org2.example.com:9051",
+					LedgerHeight: 11,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print a table of the peers of the context channel", func() {
+			Expect(err).To(BeNil())
+			Expect(discovery.PeersArgsForCall(0)).To(Equal("mychannel"))
+			Expect(fmt.Sprint(out)).To(Equal(
+				"MSP ID     ENDPOINT                       LEDGER HEIGHT    CHAINCODES\n" +
+					"Org1MSP    peer0.org1.example.com:7051    12               basic:1.0, _lifecycle:1\n" +
+					"Org2MSP    peer0.org2.example.com:9051    11               -\n"))
+		})
+
+		Context("when a channel is set", func() {
+			BeforeEach(func() {
+				impl.ChannelID = "otherchannel"
+			})
+
+			It("should query the given channel", func() {
+				Expect(err).To(BeNil())
+				Expect(discovery.PeersArgsForCall(0)).To(Equal("otherchannel"))
+			})
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "json"
+			})
+
+			It("should print the peers as JSON", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(Equal(`[{"mspid":"Org1MSP","endpoint":"peer0.org1.example.com:7051","ledger_height":12,` +
+					`"chaincodes":[{"name":"basic","version":"1.0"},{"name":"_lifecycle","version":"1"}]},` +
+					`{"mspid":"Org2MSP","endpoint":"peer0.org2.example.com:9051","ledger_height":11}]` + "\n"))
+			})
+		})
+
+		Context("when no channel is set", func() {
+			BeforeEach(func() {
+				impl.Settings.Config.Contexts["foo"].Channel = ""
+			})
+
+			It("should query the local peers", func() {
+				Expect(err).To(BeNil())
+				Expect(discovery.PeersArgsForCall(0)).To(Equal(""))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Org2MSP    peer0.org2.example.com:9051    -                -\n"))
+			})
+		})
+
+		Context("when discovery fails", func() {
+			BeforeEach(func() {
+				discovery.PeersReturns(nil, errors.New("discovery error"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("discovery error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,217 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreFileName is the file in the chaincode path listing the files excluded from a package
+const ignoreFileName = ".fabricignore"
+
+// ignoreRule is a single pattern of an ignore file. The syntax is a subset of .gitignore:
+// '#' starts a comment, '!' negates a pattern, a trailing '/' only matches directories,
+// a pattern containing a '/' is relative to the chaincode path and '**' matches any
+// number of directories.
+type ignoreRule struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+type ignoreRules []ignoreRule
+
+// loadIgnoreRules reads the ignore file in the given directory, if there is one
+func loadIgnoreRules(dir string) (ignoreRules, error) {
+	f, err := os.Open(filepath.Join(dir, ignoreFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseIgnoreRules(f)
+}
+
+func parseIgnoreRules(r io.Reader) (ignoreRules, error) {
+	var rules ignoreRules
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var rule ignoreRule
+
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimLeft(line, "/")
+		}
+
+		if line == "" {
+			continue
+		}
+
+		rule.segments = strings.Split(line, "/")
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ignored reports whether the file with the given slash separated path is excluded,
+// either directly or because one of its parent directories is excluded
+func (r ignoreRules) ignored(name string) bool {
+	if len(r) == 0 {
+		return false
+	}
+
+	segments := strings.Split(path.Clean(name), "/")
+
+	for i := 1; i < len(segments); i++ {
+		if r.match(segments[:i], true) {
+			return true
+		}
+	}
+
+	return r.match(segments, false)
+}
+
+// ignoredDir reports whether the directory with the given slash separated path is excluded
+func (r ignoreRules) ignoredDir(name string) bool {
+	return len(r) > 0 && r.match(strings.Split(path.Clean(name), "/"), true)
+}
+
+// copySource copies the files of the given directory which are not excluded to a new temporary
+// directory, without descending into excluded directories. Symbolic links are copied as links
+// to their resolved target.
+func copySource(dir string, rules ignoreRules) (string, error) {
+	tmp, err := ioutil.TempDir("", "fabric-package")
+	if err != nil {
+		return "", err
+	}
+
+	err = filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		name := filepath.ToSlash(rel)
+		target := filepath.Join(tmp, rel)
+
+		switch {
+		case info.IsDir():
+			if rules.ignoredDir(name) {
+				return filepath.SkipDir
+			}
+
+			return os.Mkdir(target, info.Mode().Perm()|0700)
+		case rules.ignored(name):
+			return nil
+		case info.Mode()&os.ModeSymlink != 0:
+			resolved, err := filepath.EvalSymlinks(file)
+			if err != nil {
+				return err
+			}
+
+			return os.Symlink(resolved, target)
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(target, data, info.Mode().Perm())
+	})
+	if err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+
+	return tmp, nil
+}
+
+// match returns the result of the last rule matching the given path
+func (r ignoreRules) match(segments []string, isDir bool) bool {
+	ignored := false
+
+	for _, rule := range r {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		var matched bool
+		if rule.anchored {
+			matched = matchSegments(rule.segments, segments)
+		} else {
+			matched = matchSegments(rule.segments, segments[len(segments)-1:])
+		}
+
+		if matched {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -10,6 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
@@ -28,7 +32,12 @@ func NewInstallCommand(settings *environment.Settings) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install <chaincode-label> <path>",
 		Short: "install a chaincode",
-		Args:  c.ParseArgs(),
+		Long: `install a chaincode
+
+When peers are specified, either with --peer or in the current context, the package is installed
+on each peer individually and concurrently. Peers that already have the package installed are
+skipped and the result for each peer is reported. The command fails if any of the peers fails.`,
+		Args: c.ParseArgs(),
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := c.Complete(); err != nil {
 				return err
@@ -48,17 +57,46 @@ func NewInstallCommand(settings *environment.Settings) *cobra.Command {
 	c.AddArg(&c.Label)
 	c.AddArg(&c.Path)
 
+	flags := cmd.Flags()
+	flags.StringArrayVar(&c.Peers, "peer", []string{},
+		"sets a peer on which to install the chaincode (this option may be specified multiple times)")
+	flags.IntVar(&c.Concurrency, "concurrency", defaultInstallConcurrency,
+		"sets the number of peers to install on concurrently (0 installs on one peer at a time)")
+
 	cmd.SetOutput(c.Settings.Streams.Out)
 
 	return cmd
 }
 
+const defaultInstallConcurrency = 4
+
+// install statuses reported per peer
+const (
+	installStatusInstalled        = "installed"
+	installStatusAlreadyInstalled = "already installed"
+	installStatusFailed           = "failed"
+)
+
 // InstallCommand implements the chaincode install command
 type InstallCommand struct {
 	BaseCommand
 
 	Label string
 	Path  string
+	Peers []string
+
+	// Concurrency is the number of peers to install on concurrently; when it is
+	// zero the peers are installed on one at a time
+	Concurrency int
+}
+
+// installResult contains the outcome of installing a package on a single peer
+type installResult struct {
+	peer     string
+	status   string
+	duration time.Duration
+	err      error
+	queryErr error
 }
 
 // Validate checks the required parameters for run
@@ -71,6 +109,10 @@ func (c *InstallCommand) Validate() error {
 		return errors.New("chaincode path not specified")
 	}
 
+	if c.Concurrency < 0 {
+		return errors.New("concurrency must not be negative")
+	}
+
 	return nil
 }
 
@@ -86,13 +128,27 @@ func (c *InstallCommand) Run() error {
 		return err
 	}
 
+	peers := c.Peers
+	if len(peers) == 0 {
+		peers = context.Peers
+	}
+
+	return c.install(pkg, peers)
+}
+
+// install installs the package on the given peers, or on all peers of the organization if none are given
+func (c *InstallCommand) install(pkg []byte, peers []string) error {
+	if len(peers) > 0 {
+		return c.installOnPeers(pkg, peers)
+	}
+
 	responses, err := c.ResourceManagement.LifecycleInstallCC(
 		resmgmt.LifecycleInstallCCRequest{
 			Label:   c.Label,
 			Package: pkg,
 		},
 		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
-		resmgmt.WithTargetEndpoints(context.Peers...),
+		resmgmt.WithTargetEndpoints(peers...),
 	)
 	if err != nil {
 		return err
@@ -107,3 +163,115 @@ func (c *InstallCommand) Run() error {
 
 	return nil
 }
+
+// installOnPeers installs the package on every peer concurrently and reports the result for each peer
+func (c *InstallCommand) installOnPeers(pkg []byte, peers []string) error {
+	packageID := lifecycle.ComputePackageID(c.Label, pkg)
+
+	concurrency := c.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(peers) {
+		concurrency = len(peers)
+	}
+
+	results := make([]installResult, len(peers))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, peer string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = c.installOnPeer(pkg, packageID, peer)
+		}(i, peer)
+	}
+
+	wg.Wait()
+
+	c.printf("Package ID '%s'\n", packageID)
+
+	w := tabwriter.NewWriter(c.Settings.Streams.Out, 4, 4, 4, ' ', 0)
+	fmt.Fprintln(w, "PEER\tSTATUS\tDURATION\tERROR")
+
+	var failed int
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.peer, result.status, result.duration.Round(time.Millisecond), result.message())
+	}
+
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to install chaincode '%s' on %d of %d peers", c.Label, failed, len(peers))
+	}
+
+	c.printf("successfully installed chaincode '%s' on %d peers\n", c.Label, len(peers))
+
+	return nil
+}
+
+func (c *InstallCommand) installOnPeer(pkg []byte, packageID, peer string) installResult {
+	start := time.Now()
+
+	result := installResult{
+		peer:   peer,
+		status: installStatusInstalled,
+	}
+
+	installed, err := c.ResourceManagement.LifecycleQueryInstalledCC(
+		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+		resmgmt.WithTargetEndpoints(peer),
+	)
+	if err != nil {
+		// the install itself tells whether the peer is usable, so only report the failed query
+		result.queryErr = err
+	}
+
+	for _, cc := range installed {
+		if cc.PackageID == packageID {
+			result.status = installStatusAlreadyInstalled
+			result.duration = time.Since(start)
+
+			return result
+		}
+	}
+
+	_, err = c.ResourceManagement.LifecycleInstallCC(
+		resmgmt.LifecycleInstallCCRequest{
+			Label:   c.Label,
+			Package: pkg,
+		},
+		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+		resmgmt.WithTargetEndpoints(peer),
+	)
+	if err != nil {
+		result.status = installStatusFailed
+		result.err = err
+	}
+
+	result.duration = time.Since(start)
+
+	return result
+}
+
+// message returns the errors of the result to report in the peer's row
+func (r installResult) message() string {
+	var msgs []string
+	if r.queryErr != nil {
+		msgs = append(msgs, fmt.Sprintf("failed to query installed chaincodes: %s", r.queryErr))
+	}
+	if r.err != nil {
+		msgs = append(msgs, r.err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
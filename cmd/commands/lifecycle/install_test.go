@@ -10,9 +10,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	lifecyclepkg "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
@@ -127,6 +129,17 @@ var _ = Describe("LifecycleChaincodeInstallImplementation", func() {
 			It("should succeed with all arguments", func() {
 				Expect(err).To(BeNil())
 			})
+
+			Context("when the concurrency is negative", func() {
+				BeforeEach(func() {
+					impl.Concurrency = -1
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("concurrency must not be negative"))
+				})
+			})
 		})
 	})
 
@@ -212,6 +225,108 @@ var _ = Describe("LifecycleChaincodeInstallImplementation", func() {
 			})
 		})
 
+		Context("when peers are specified", func() {
+			BeforeEach(func() {
+				settings.Config = &environment.Config{
+					Contexts: map[string]*environment.Context{
+						"foo": {
+							Peers: []string{"peer1", "peer2"},
+						},
+					},
+					CurrentContext: "foo",
+				}
+
+				client.LifecycleInstallCCReturns([]resmgmt.LifecycleInstallCCResponse{
+					{
+						PackageID: "pkg1",
+					},
+				}, nil)
+			})
+
+			It("should install on each peer", func() {
+				Expect(err).To(BeNil())
+				Expect(client.LifecycleQueryInstalledCCCallCount()).To(Equal(2))
+				Expect(client.LifecycleInstallCCCallCount()).To(Equal(2))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`peer1\s+installed`))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`peer2\s+installed`))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("successfully installed chaincode 'mycc' on 2 peers"))
+			})
+
+			Context("when --peer is set", func() {
+				BeforeEach(func() {
+					impl.Peers = []string{"peer3"}
+				})
+
+				It("should only install on the given peers", func() {
+					Expect(err).To(BeNil())
+					Expect(client.LifecycleInstallCCCallCount()).To(Equal(1))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer3\s+installed`))
+					Expect(fmt.Sprint(out)).NotTo(ContainSubstring("peer1"))
+				})
+			})
+
+			Context("when the package is already installed on a peer", func() {
+				BeforeEach(func() {
+					pkg, err := ioutil.ReadFile(impl.Path)
+					Expect(err).To(BeNil())
+
+					client.LifecycleQueryInstalledCCReturnsOnCall(0, []resmgmt.LifecycleInstalledCC{
+						{
+							PackageID: lifecyclepkg.ComputePackageID("mycc", pkg),
+						},
+					}, nil)
+					client.LifecycleQueryInstalledCCReturnsOnCall(1, nil, nil)
+				})
+
+				It("should skip the peer", func() {
+					Expect(err).To(BeNil())
+					Expect(client.LifecycleInstallCCCallCount()).To(Equal(1))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer1\s+already installed`))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer2\s+installed`))
+				})
+			})
+
+			Context("when peers are installed on concurrently", func() {
+				BeforeEach(func() {
+					impl.Concurrency = 2
+				})
+
+				It("should install on each peer", func() {
+					Expect(err).To(BeNil())
+					Expect(client.LifecycleInstallCCCallCount()).To(Equal(2))
+					Expect(fmt.Sprint(out)).To(ContainSubstring("successfully installed chaincode 'mycc' on 2 peers"))
+				})
+			})
+
+			Context("when querying the installed chaincodes fails on a peer", func() {
+				BeforeEach(func() {
+					client.LifecycleQueryInstalledCCReturnsOnCall(0, nil, errors.New("query error"))
+					client.LifecycleQueryInstalledCCReturnsOnCall(1, nil, nil)
+				})
+
+				It("should install and report the query error", func() {
+					Expect(err).To(BeNil())
+					Expect(client.LifecycleInstallCCCallCount()).To(Equal(2))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer1\s+installed\s+\S+\s+failed to query installed chaincodes: query error`))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer2\s+installed\s+\S+\s*\n`))
+				})
+			})
+
+			Context("when install fails on a peer", func() {
+				BeforeEach(func() {
+					client.LifecycleInstallCCReturnsOnCall(0, nil, errors.New("install error"))
+					client.LifecycleInstallCCReturnsOnCall(1, nil, nil)
+				})
+
+				It("should report the partial failure", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("failed to install chaincode 'mycc' on 1 of 2 peers"))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer1\s+failed\s+\S+\s+install error`))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`peer2\s+installed`))
+				})
+			})
+		})
+
 		Context("when resmgmt client fails", func() {
 			BeforeEach(func() {
 				settings.Config = &environment.Config{
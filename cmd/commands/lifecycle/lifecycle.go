@@ -41,6 +41,7 @@ func NewCommand(settings *environment.Settings) *cobra.Command {
 		NewQueryApprovedCommand(settings),
 		NewCheckCommitReadinessCommand(settings),
 		NewQueryCommittedCommand(settings),
+		NewMigrateCommand(settings),
 	)
 
 	cmd.SetOutput(settings.Streams.Out)
@@ -64,6 +64,7 @@ var _ = Describe("LifecycleChaincodeCommand", func() {
 			Expect(fmt.Sprint(out)).To(ContainSubstring("queryapproved"))
 			Expect(fmt.Sprint(out)).To(ContainSubstring("checkcommitreadiness"))
 			Expect(fmt.Sprint(out)).To(ContainSubstring("querycommitted"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("migrate"))
 		})
 	})
 })
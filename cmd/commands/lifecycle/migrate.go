@@ -0,0 +1,398 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	lifecyclepkg "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+const (
+	lsccName            = "lscc"
+	lsccGetCCDataFcn    = "getccdata"
+	noCollectionsErrMsg = "collections config not defined"
+)
+
+// versionNumberRegexp matches the last number of a chaincode version
+var versionNumberRegexp = regexp.MustCompile(`[0-9]+([^0-9]*)$`)
+
+// NewMigrateCommand creates a new "fabric lifecycle migrate" command
+func NewMigrateCommand(settings *environment.Settings) *cobra.Command {
+	c := MigrateCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "migrate <chaincode-name>",
+		Short: "migrate a legacy chaincode to the new lifecycle",
+		Long: `migrate a legacy chaincode to the new lifecycle
+
+Reads the definition of a chaincode instantiated with the legacy lifecycle on the current context's
+channel and creates a new lifecycle definition with the same name, endorsement policy, collections
+and plugins and a bumped version. The chaincode is then packaged, installed, approved for the
+current organization and, once enough organizations have approved it, committed. Since the name
+is unchanged, the chaincode keeps its existing state.`,
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Name)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.Version, "version", "", "sets the new version (default is the instantiated version bumped)")
+	flags.Int64Var(&c.Sequence, "sequence", 1, "sets the sequence of the new definition")
+	flags.StringVar(&c.Path, "path", "", "sets the chaincode path (default is the path of the instantiated chaincode)")
+	flags.StringVar(&c.Lang, "lang", "golang", "sets the chaincode language")
+	flags.StringVar(&c.Label, "label", "", "sets the package label (default is '<chaincode-name>_<version>')")
+	flags.StringVar(&c.PackageFile, "package", "",
+		"sets the path to an existing chaincode package, which is installed instead of packaging the chaincode")
+	flags.BoolVar(&c.DryRun, "dry-run", false, "only print the new definition")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// MigrateCommand implements the lifecycle migrate command
+type MigrateCommand struct {
+	BaseCommand
+
+	Name        string
+	Version     string
+	Sequence    int64
+	Path        string
+	Lang        string
+	Label       string
+	PackageFile string
+	DryRun      bool
+}
+
+// migrationDefinition contains the new lifecycle definition of a legacy chaincode
+type migrationDefinition struct {
+	Name              string
+	PreviousVersion   string
+	Version           string
+	Sequence          int64
+	Path              string
+	Label             string
+	SignaturePolicy   *cb.SignaturePolicyEnvelope
+	CollectionConfig  []*pb.CollectionConfig
+	EndorsementPlugin string
+	ValidationPlugin  string
+}
+
+// Validate checks the required parameters for run
+func (c *MigrateCommand) Validate() error {
+	if c.Name == "" {
+		return errors.New("chaincode name not specified")
+	}
+
+	if c.Sequence <= 0 {
+		return errors.New("sequence must be greater than 0")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *MigrateCommand) Run() error {
+	context, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+
+	def, err := c.definition(context)
+	if err != nil {
+		return err
+	}
+
+	c.printDefinition(def)
+
+	if c.DryRun {
+		return nil
+	}
+
+	pkg, err := c.packageBytes(def)
+	if err != nil {
+		return err
+	}
+
+	install := &InstallCommand{
+		BaseCommand: c.BaseCommand,
+		Label:       def.Label,
+		Concurrency: defaultInstallConcurrency,
+	}
+
+	if err := install.install(pkg, context.Peers); err != nil {
+		return err
+	}
+
+	options := []resmgmt.RequestOption{
+		resmgmt.WithTargetEndpoints(context.Peers...),
+		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+	}
+
+	_, err = c.ResourceManagement.LifecycleApproveCC(context.Channel, resmgmt.LifecycleApproveCCRequest{
+		Name:              def.Name,
+		Version:           def.Version,
+		PackageID:         lifecyclepkg.ComputePackageID(def.Label, pkg),
+		Sequence:          def.Sequence,
+		SignaturePolicy:   def.SignaturePolicy,
+		CollectionConfig:  def.CollectionConfig,
+		EndorsementPlugin: def.EndorsementPlugin,
+		ValidationPlugin:  def.ValidationPlugin,
+	}, options...)
+	if err != nil {
+		return err
+	}
+
+	c.printf("successfully approved chaincode '%s'\n", def.Name)
+
+	readiness, err := c.ResourceManagement.LifecycleCheckCCCommitReadiness(context.Channel, resmgmt.LifecycleCheckCCCommitReadinessRequest{
+		Name:              def.Name,
+		Version:           def.Version,
+		Sequence:          def.Sequence,
+		SignaturePolicy:   def.SignaturePolicy,
+		CollectionConfig:  def.CollectionConfig,
+		EndorsementPlugin: def.EndorsementPlugin,
+		ValidationPlugin:  def.ValidationPlugin,
+	}, options...)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for org, approved := range readiness.Approvals {
+		if !approved {
+			pending = append(pending, org)
+		}
+	}
+
+	if len(pending) > 0 {
+		sort.Strings(pending)
+
+		c.printf("chaincode '%s' is not committed yet, waiting for approval from: %s\n", def.Name, strings.Join(pending, ", "))
+
+		return nil
+	}
+
+	_, err = c.ResourceManagement.LifecycleCommitCC(context.Channel, resmgmt.LifecycleCommitCCRequest{
+		Name:              def.Name,
+		Version:           def.Version,
+		Sequence:          def.Sequence,
+		SignaturePolicy:   def.SignaturePolicy,
+		CollectionConfig:  def.CollectionConfig,
+		EndorsementPlugin: def.EndorsementPlugin,
+		ValidationPlugin:  def.ValidationPlugin,
+	}, options...)
+	if err != nil {
+		return err
+	}
+
+	c.printf("successfully migrated chaincode '%s' to version '%s'\n", def.Name, def.Version)
+
+	return nil
+}
+
+// definition builds the new lifecycle definition from the instantiated chaincode
+func (c *MigrateCommand) definition(context *environment.Context) (*migrationDefinition, error) {
+	resmgmtOptions := []resmgmt.RequestOption{
+		resmgmt.WithTargetEndpoints(context.Peers...),
+		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+	}
+
+	instantiated, err := c.ResourceManagement.QueryInstantiatedChaincodes(context.Channel, resmgmtOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var info *pb.ChaincodeInfo
+	for _, cc := range instantiated.GetChaincodes() {
+		if cc.Name == c.Name {
+			info = cc
+			break
+		}
+	}
+
+	if info == nil {
+		return nil, errors.Errorf("chaincode '%s' is not instantiated on channel '%s'", c.Name, context.Channel)
+	}
+
+	ccData, err := c.chaincodeData(context)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &cb.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(ccData.Policy, policy); err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal endorsement policy")
+	}
+
+	var collections []*pb.CollectionConfig
+
+	collectionsConfig, err := c.ResourceManagement.QueryCollectionsConfig(context.Channel, c.Name, resmgmtOptions...)
+	switch {
+	case err == nil:
+		collections = collectionsConfig.GetConfig()
+	case !collectionsNotDefined(err):
+		return nil, err
+	}
+
+	version := c.Version
+	if version == "" {
+		version = bumpVersion(info.Version)
+	}
+
+	path := c.Path
+	if path == "" {
+		path = info.Path
+	}
+
+	label := c.Label
+	if label == "" {
+		label = fmt.Sprintf("%s_%s", c.Name, version)
+	}
+
+	return &migrationDefinition{
+		Name:              c.Name,
+		PreviousVersion:   info.Version,
+		Version:           version,
+		Sequence:          c.Sequence,
+		Path:              path,
+		Label:             label,
+		SignaturePolicy:   policy,
+		CollectionConfig:  collections,
+		EndorsementPlugin: info.Escc,
+		ValidationPlugin:  info.Vscc,
+	}, nil
+}
+
+// collectionsNotDefined returns true if lscc responded that the chaincode has no collections,
+// which it reports as an error response of the chaincode rather than an empty config
+func collectionsNotDefined(err error) bool {
+	s, ok := status.FromError(err)
+
+	return ok && s.Group == status.ChaincodeStatus && strings.HasPrefix(s.Message, noCollectionsErrMsg)
+}
+
+// chaincodeData queries the legacy lifecycle system chaincode for the chaincode's data
+func (c *MigrateCommand) chaincodeData(context *environment.Context) (*ccprovider.ChaincodeData, error) {
+	options := []channel.RequestOption{
+		channel.WithRetry(retry.DefaultChannelOpts),
+	}
+
+	if len(context.Peers) > 0 {
+		options = append(options, channel.WithTargetEndpoints(context.Peers...))
+	}
+
+	resp, err := c.Channel.Query(channel.Request{
+		ChaincodeID: lsccName,
+		Fcn:         lsccGetCCDataFcn,
+		Args:        [][]byte{[]byte(context.Channel), []byte(c.Name)},
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ccData := &ccprovider.ChaincodeData{}
+	if err := proto.Unmarshal(resp.Payload, ccData); err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal chaincode data")
+	}
+
+	return ccData, nil
+}
+
+// packageBytes returns the package given with --package or packages the chaincode
+func (c *MigrateCommand) packageBytes(def *migrationDefinition) ([]byte, error) {
+	if c.PackageFile != "" {
+		return ioutil.ReadFile(c.PackageFile)
+	}
+
+	pkg := &PackageCommand{
+		Label: def.Label,
+		Type:  c.Lang,
+		Path:  def.Path,
+	}
+
+	if err := pkg.Validate(); err != nil {
+		return nil, err
+	}
+
+	pkgBytes, _, err := pkg.newSourcePackage()
+
+	return pkgBytes, err
+}
+
+// printDefinition prints the definition to migrate to. Policies which can't be expressed in the
+// policy language, e.g. with principals other than roles, are printed in their raw form.
+func (c *MigrateCommand) printDefinition(def *migrationDefinition) {
+	policy, err := common.GetPolicyString(def.SignaturePolicy)
+	if err != nil {
+		policy = fmt.Sprintf("unrenderable (%s): %s", err, proto.CompactTextString(def.SignaturePolicy))
+	}
+
+	c.printf("Name: %s, Version: %s (was %s), Sequence: %d, Label: %s, Path: %s\n",
+		def.Name, def.Version, def.PreviousVersion, def.Sequence, def.Label, def.Path)
+	c.printf("Endorsement Plugin: %s, Validation Plugin: %s, Signature Policy: %s\n",
+		def.EndorsementPlugin, def.ValidationPlugin, policy)
+
+	for _, collConfig := range def.CollectionConfig {
+		cfg := collConfig.GetStaticCollectionConfig()
+
+		c.printf("- Collection: %s, Blocks to Live: %d, Maximum Peer Count: %d,"+
+			" Required Peer Count: %d, MemberOnlyRead: %t, MemberOnlyWrite: %t\n",
+			cfg.Name, cfg.BlockToLive, cfg.MaximumPeerCount, cfg.RequiredPeerCount, cfg.MemberOnlyRead, cfg.MemberOnlyWrite)
+	}
+}
+
+// bumpVersion increments the last number of the given version, or appends ".1" if it has none
+func bumpVersion(version string) string {
+	loc := versionNumberRegexp.FindStringSubmatchIndex(version)
+	if loc == nil {
+		return version + ".1"
+	}
+
+	suffixStart := loc[2]
+	n, err := strconv.Atoi(version[loc[0]:suffixStart])
+	if err != nil {
+		return version + ".1"
+	}
+
+	return version[:loc[0]] + strconv.Itoa(n+1) + version[suffixStart:]
+}
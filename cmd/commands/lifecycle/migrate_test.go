@@ -0,0 +1,399 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/lifecycle"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("LifecycleChaincodeMigrateCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = lifecycle.NewMigrateCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a chaincode migrate command", func() {
+		Expect(cmd.Name()).To(Equal("migrate"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("migrate <chaincode-name>"))
+	})
+})
+
+var _ = Describe("LifecycleChaincodeMigrateImplementation", func() {
+	var (
+		impl          *lifecycle.MigrateCommand
+		err           error
+		out           *bytes.Buffer
+		settings      *environment.Settings
+		factory       *mocks.Factory
+		client        *mocks.ResourceManagement
+		channelClient *mocks.Channel
+		dir           string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		factory = &mocks.Factory{}
+		client = &mocks.ResourceManagement{}
+		channelClient = &mocks.Channel{}
+
+		impl = &lifecycle.MigrateCommand{}
+		impl.Settings = settings
+		impl.Factory = factory
+		impl.ResourceManagement = client
+		impl.Channel = channelClient
+	})
+
+	It("should not be nil", func() {
+		Expect(impl).ShouldNot(BeNil())
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should fail when name is not set", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("chaincode name not specified"))
+		})
+
+		Context("when sequence is not positive", func() {
+			BeforeEach(func() {
+				impl.Name = "mycc"
+			})
+
+			It("should fail with an invalid sequence", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("sequence must be greater than 0"))
+			})
+		})
+
+		Context("when all arguments are set", func() {
+			BeforeEach(func() {
+				impl.Name = "mycc"
+				impl.Sequence = 1
+			})
+
+			It("should succeed with all arguments", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			impl.Name = "mycc"
+			impl.Sequence = 1
+
+			settings.Config = &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {
+						Channel: "mychannel",
+						Peers:   []string{"peer1"},
+					},
+				},
+				CurrentContext: "foo",
+			}
+
+			client.QueryInstantiatedChaincodesReturns(&pb.ChaincodeQueryResponse{
+				Chaincodes: []*pb.ChaincodeInfo{
+					{
+						Name:    "mycc",
+						Version: "1.0",
+						Path:    "github.com/example/mycc",
+						Escc:    "escc",
+						Vscc:    "vscc",
+					},
+				},
+			}, nil)
+
+			policy, err := policydsl.FromString("AND('Org1MSP.member', 'Org2MSP.member')")
+			Expect(err).To(BeNil())
+
+			policyBytes, err := proto.Marshal(policy)
+			Expect(err).To(BeNil())
+
+			ccData, err := proto.Marshal(&ccprovider.ChaincodeData{
+				Name:    "mycc",
+				Version: "1.0",
+				Policy:  policyBytes,
+			})
+			Expect(err).To(BeNil())
+
+			channelClient.QueryReturns(channel.Response{Payload: ccData}, nil)
+
+			client.QueryCollectionsConfigReturns(nil, status.New(status.ChaincodeStatus, 500, "collections config not defined for chaincode mycc", nil))
+
+			dir, err = ioutil.TempDir("", "migrate")
+			Expect(err).To(BeNil())
+
+			impl.PackageFile = filepath.Join(dir, "mycc.tgz")
+			Expect(ioutil.WriteFile(impl.PackageFile, []byte("package"), 0644)).To(Succeed())
+
+			client.LifecycleQueryInstalledCCReturns(nil, nil)
+			client.LifecycleInstallCCReturns([]resmgmt.LifecycleInstallCCResponse{{PackageID: "mycc_1.1:1234"}}, nil)
+			client.LifecycleCheckCCCommitReadinessReturns(resmgmt.LifecycleCheckCCCommitReadinessResponse{
+				Approvals: map[string]bool{
+					"Org1MSP": true,
+					"Org2MSP": true,
+				},
+			}, nil)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should migrate the chaincode", func() {
+			Expect(err).To(BeNil())
+
+			fcnRequest, _ := channelClient.QueryArgsForCall(0)
+			Expect(fcnRequest.ChaincodeID).To(Equal("lscc"))
+			Expect(fcnRequest.Fcn).To(Equal("getccdata"))
+			Expect(fcnRequest.Args).To(Equal([][]byte{[]byte("mychannel"), []byte("mycc")}))
+
+			Expect(client.LifecycleInstallCCCallCount()).To(Equal(1))
+			installRequest, _ := client.LifecycleInstallCCArgsForCall(0)
+			Expect(installRequest.Label).To(Equal("mycc_1.1"))
+
+			Expect(client.LifecycleApproveCCCallCount()).To(Equal(1))
+			channelID, approveRequest, _ := client.LifecycleApproveCCArgsForCall(0)
+			Expect(channelID).To(Equal("mychannel"))
+			Expect(approveRequest.Name).To(Equal("mycc"))
+			Expect(approveRequest.Version).To(Equal("1.1"))
+			Expect(approveRequest.Sequence).To(Equal(int64(1)))
+			Expect(approveRequest.PackageID).To(HavePrefix("mycc_1.1:"))
+			Expect(approveRequest.EndorsementPlugin).To(Equal("escc"))
+			Expect(approveRequest.ValidationPlugin).To(Equal("vscc"))
+			Expect(approveRequest.SignaturePolicy).NotTo(BeNil())
+			Expect(approveRequest.CollectionConfig).To(BeEmpty())
+
+			Expect(client.LifecycleCommitCCCallCount()).To(Equal(1))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("Name: mycc, Version: 1.1 (was 1.0), Sequence: 1"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("Signature Policy: AND('Org1MSP.member', 'Org2MSP.member')"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("successfully migrated chaincode 'mycc' to version '1.1'"))
+		})
+
+		Context("when the chaincode has collections", func() {
+			BeforeEach(func() {
+				policy, err := policydsl.FromString("OR('Org1MSP.member')")
+				Expect(err).To(BeNil())
+
+				client.QueryCollectionsConfigReturns(&pb.CollectionConfigPackage{
+					Config: []*pb.CollectionConfig{
+						{
+							Payload: &pb.CollectionConfig_StaticCollectionConfig{
+								StaticCollectionConfig: &pb.StaticCollectionConfig{
+									Name: "coll1",
+									MemberOrgsPolicy: &pb.CollectionPolicyConfig{
+										Payload: &pb.CollectionPolicyConfig_SignaturePolicy{
+											SignaturePolicy: policy,
+										},
+									},
+									BlockToLive: 10,
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("should keep the collections", func() {
+				Expect(err).To(BeNil())
+
+				_, approveRequest, _ := client.LifecycleApproveCCArgsForCall(0)
+				Expect(approveRequest.CollectionConfig).To(HaveLen(1))
+				Expect(approveRequest.CollectionConfig[0].GetStaticCollectionConfig().Name).To(Equal("coll1"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("- Collection: coll1, Blocks to Live: 10"))
+			})
+		})
+
+		Context("when querying the collections fails", func() {
+			BeforeEach(func() {
+				client.QueryCollectionsConfigReturns(nil, errors.New("collections error"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("collections error"))
+				Expect(client.LifecycleApproveCCCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the collections error is not a response of lscc", func() {
+			BeforeEach(func() {
+				client.QueryCollectionsConfigReturns(nil, errors.New("collections config not defined for chaincode mycc"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(client.LifecycleApproveCCCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the policy has an identity principal", func() {
+			BeforeEach(func() {
+				policy, err := policydsl.FromString("OR('Org1MSP.member')")
+				Expect(err).To(BeNil())
+
+				policy.Identities[0] = &mb.MSPPrincipal{
+					PrincipalClassification: mb.MSPPrincipal_IDENTITY,
+					Principal:               []byte("identity"),
+				}
+
+				policyBytes, err := proto.Marshal(policy)
+				Expect(err).To(BeNil())
+
+				ccData, err := proto.Marshal(&ccprovider.ChaincodeData{
+					Name:    "mycc",
+					Version: "1.0",
+					Policy:  policyBytes,
+				})
+				Expect(err).To(BeNil())
+
+				channelClient.QueryReturns(channel.Response{Payload: ccData}, nil)
+			})
+
+			It("should print the raw policy and migrate", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Signature Policy: unrenderable ("))
+				Expect(client.LifecycleCommitCCCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the chaincode is not instantiated", func() {
+			BeforeEach(func() {
+				impl.Name = "othercc"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode 'othercc' is not instantiated on channel 'mychannel'"))
+			})
+		})
+
+		Context("when --version is set", func() {
+			BeforeEach(func() {
+				impl.Version = "2.0"
+			})
+
+			It("should use the given version", func() {
+				Expect(err).To(BeNil())
+
+				_, approveRequest, _ := client.LifecycleApproveCCArgsForCall(0)
+				Expect(approveRequest.Version).To(Equal("2.0"))
+			})
+		})
+
+		Context("when --dry-run is set", func() {
+			BeforeEach(func() {
+				impl.DryRun = true
+			})
+
+			It("should only print the definition", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Name: mycc, Version: 1.1 (was 1.0)"))
+				Expect(client.LifecycleInstallCCCallCount()).To(Equal(0))
+				Expect(client.LifecycleApproveCCCallCount()).To(Equal(0))
+				Expect(client.LifecycleCommitCCCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when other organizations have not approved", func() {
+			BeforeEach(func() {
+				client.LifecycleCheckCCCommitReadinessReturns(resmgmt.LifecycleCheckCCCommitReadinessResponse{
+					Approvals: map[string]bool{
+						"Org1MSP": true,
+						"Org2MSP": false,
+					},
+				}, nil)
+			})
+
+			It("should not commit", func() {
+				Expect(err).To(BeNil())
+				Expect(client.LifecycleCommitCCCallCount()).To(Equal(0))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("waiting for approval from: Org2MSP"))
+			})
+		})
+
+		Context("when approve fails", func() {
+			BeforeEach(func() {
+				client.LifecycleApproveCCReturns("", errors.New("approve error"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("approve error"))
+				Expect(client.LifecycleCommitCCCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -7,10 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lifecyclepkg "github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/lifecycle"
@@ -19,6 +30,23 @@ import (
 	"github.com/hyperledger/fabric-cli/pkg/environment"
 )
 
+const (
+	ccaasType    = "ccaas"
+	externalType = "external"
+
+	codePackageName       = "code.tar.gz"
+	metadataPackageName   = "metadata.json"
+	connectionPackageName = "connection.json"
+
+	defaultDialTimeout = 10 * time.Second
+
+	// the owner of every file in a source package
+	packageFileOwner = 500
+)
+
+// labelRegexp matches the labels accepted by the peer for chaincode packages
+var labelRegexp = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.+-]*$`)
+
 // NewPackageCommand creates a new "fabric lifecycle chaincode package" command
 func NewPackageCommand(settings *environment.Settings) *cobra.Command {
 	c := PackageCommand{}
@@ -26,9 +54,19 @@ func NewPackageCommand(settings *environment.Settings) *cobra.Command {
 	c.Settings = settings
 
 	cmd := &cobra.Command{
-		Use:   "package <chaincode-label> <chaincode-type> <path>",
+		Use:   "package <chaincode-label> <chaincode-type> [path]",
 		Short: "package a chaincode",
-		Args:  c.ParseArgs(),
+		Long: `package a chaincode
+
+The chaincode type is either a source type (golang, node, java) or one of 'ccaas' and 'external'.
+Source types package the chaincode found at [path]. The 'ccaas' and 'external' types package a
+connection.json for a chaincode that runs as an external service; [path] is not required for them.
+
+Source packages are deterministic: timestamps, ownership, permissions other than the execute bit
+and file order are normalized, and node and java packages record the name of the chaincode
+directory instead of [path], so that packaging the same source always results in the same package
+ID. Files matching the patterns in a '.fabricignore' file in [path] are excluded from the package.`,
+		Args: c.ParseArgs(),
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			return c.Validate()
 		},
@@ -41,6 +79,17 @@ func NewPackageCommand(settings *environment.Settings) *cobra.Command {
 	c.AddArg(&c.Type)
 	c.AddArg(&c.Path)
 
+	flags := cmd.Flags()
+	flags.StringVar(&c.Output, "output", "", "sets the path of the package file (default is './<chaincode-label>.tgz')")
+	flags.StringVar(&c.Address, "address", "", "sets the address of the chaincode service (ccaas and external types only)")
+	flags.DurationVar(&c.DialTimeout, "dial-timeout", defaultDialTimeout,
+		"sets the timeout for connecting to the chaincode service (ccaas and external types only)")
+	flags.BoolVar(&c.TLSRequired, "tls-required", false,
+		"indicates whether the chaincode service requires TLS (ccaas and external types only)")
+	flags.StringVar(&c.ClientCert, "client-cert", "", "sets the path to the TLS client certificate (ccaas and external types only)")
+	flags.StringVar(&c.ClientKey, "client-key", "", "sets the path to the TLS client key (ccaas and external types only)")
+	flags.StringVar(&c.RootCert, "root-cert", "", "sets the path to the TLS root certificate (ccaas and external types only)")
+
 	cmd.SetOutput(c.Settings.Streams.Out)
 
 	return cmd
@@ -50,9 +99,28 @@ func NewPackageCommand(settings *environment.Settings) *cobra.Command {
 type PackageCommand struct {
 	BaseCommand
 
-	Path  string
-	Label string
-	Type  string
+	Path   string
+	Label  string
+	Type   string
+	Output string
+
+	Address     string
+	DialTimeout time.Duration
+	TLSRequired bool
+	ClientCert  string
+	ClientKey   string
+	RootCert    string
+}
+
+// connection contains the parameters the peer uses to connect to a chaincode service
+type connection struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key,omitempty"`
+	ClientCert         string `json:"client_cert,omitempty"`
+	RootCert           string `json:"root_cert,omitempty"`
 }
 
 // Validate checks the required parameters for run
@@ -61,7 +129,7 @@ func (c *PackageCommand) Validate() error {
 		return errors.New("chaincode label not specified")
 	}
 
-	if c.Path == "" {
+	if c.Path == "" && !c.isExternal() {
 		return errors.New("chaincode path not specified")
 	}
 
@@ -69,6 +137,10 @@ func (c *PackageCommand) Validate() error {
 		return errors.New("chaincode type not specified")
 	}
 
+	if c.isExternal() {
+		return c.validateExternal()
+	}
+
 	ccType, ok := pb.ChaincodeSpec_Type_value[strings.ToUpper(c.Type)]
 	if !ok || ccType == int32(pb.ChaincodeSpec_UNDEFINED) {
 		return errors.New("unsupported chaincode type")
@@ -77,22 +149,453 @@ func (c *PackageCommand) Validate() error {
 	return nil
 }
 
+func (c *PackageCommand) validateExternal() error {
+	if !labelRegexp.MatchString(c.Label) {
+		return fmt.Errorf("invalid chaincode label '%s'", c.Label)
+	}
+
+	if c.Address == "" {
+		return errors.New("chaincode address not specified")
+	}
+
+	if c.DialTimeout <= 0 {
+		return errors.New("dial timeout must be greater than 0")
+	}
+
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return errors.New("client cert and client key must be specified together")
+	}
+
+	if c.TLSRequired && c.RootCert == "" {
+		return errors.New("root cert not specified")
+	}
+
+	if !c.TLSRequired && (c.ClientCert != "" || c.RootCert != "") {
+		return errors.New("TLS certificates require --tls-required")
+	}
+
+	return nil
+}
+
 // Run executes the command
 func (c *PackageCommand) Run() error {
+	var (
+		pkgBytes []byte
+		included []string
+		err      error
+	)
+
+	if c.isExternal() {
+		pkgBytes, err = c.newExternalPackage()
+	} else {
+		pkgBytes, included, err = c.newSourcePackage()
+	}
+	if err != nil {
+		return err
+	}
+
+	output := c.Output
+	if output == "" {
+		output = fmt.Sprintf("./%s.tgz", c.Label)
+	}
+
+	if err := ioutil.WriteFile(output, pkgBytes, 0644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "successfully packaged chaincode '%s'\n", c.Label)
+
+	if len(included) > 0 {
+		fmt.Fprintln(c.Settings.Streams.Out, "Included files:")
+
+		for _, name := range included {
+			fmt.Fprintf(c.Settings.Streams.Out, " - %s\n", name)
+		}
+	}
+
+	return nil
+}
+
+func (c *PackageCommand) isExternal() bool {
+	t := strings.ToLower(c.Type)
+
+	return t == ccaasType || t == externalType
+}
+
+// newSourcePackage creates a package from the chaincode source and rewrites its code package so
+// that the result only depends on the content of the included files. Excluded files are left out
+// before packaging where the chaincode path is self-contained, so that excluded directories are
+// not read at all, and are removed from the code package otherwise, e.g. for GOPATH chaincode.
+func (c *PackageCommand) newSourcePackage() ([]byte, []string, error) {
+	rules, err := loadIgnoreRules(c.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ccPath := c.Path
+	if len(rules) > 0 && c.isSelfContained() {
+		ccPath, err = copySource(c.Path, rules)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer os.RemoveAll(ccPath)
+	}
+
 	pkgBytes, err := lifecyclepkg.NewCCPackage(&lifecyclepkg.Descriptor{
-		Path:  c.Path,
+		Path:  ccPath,
 		Type:  pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value[strings.ToUpper(c.Type)]),
 		Label: c.Label,
 	})
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+
+	files, _, err := readTarGz(pkgBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := &lifecyclepkg.PackageMetadata{}
+	if err := json.Unmarshal(files[metadataPackageName], metadata); err != nil {
+		return nil, nil, err
+	}
+
+	codeFiles, codeDirs, err := readTarGz(files[codePackageName])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var included []string
+
+	// the packager drops the permissions of all files, so the execute bit is taken from the source
+	executable := make(map[string]bool)
+
+	for name := range codeFiles {
+		sourcePath := sourceRelativePath(name, metadata.Path)
+		if rules.ignored(sourcePath) {
+			delete(codeFiles, name)
+			continue
+		}
+
+		included = append(included, name)
+
+		info, err := os.Stat(filepath.Join(c.Path, filepath.FromSlash(sourcePath)))
+		if err == nil && info.Mode().IsRegular() && info.Mode()&0111 != 0 {
+			executable[name] = true
+		}
+	}
+
+	if len(included) == 0 {
+		return nil, nil, fmt.Errorf("no source files found in '%s'", c.Path)
+	}
+
+	sort.Strings(included)
+
+	codeBytes, err := tarGzWithDirs(codeFiles, codeDirs, executable)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.normalizeMetadataPath(metadata, ccPath); err != nil {
+		return nil, nil, err
+	}
+
+	files[metadataPackageName], err = json.Marshal(metadata)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if err := ioutil.WriteFile(fmt.Sprintf("./%s.tgz", c.Label), pkgBytes, 0644); err != nil {
+	pkgBytes, err = tarGz(map[string][]byte{
+		metadataPackageName: files[metadataPackageName],
+		codePackageName:     codeBytes,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pkgBytes, included, nil
+}
+
+// normalizeMetadataPath replaces the path recorded by the packager for node and java chaincode,
+// which is the path as given, with the name of the chaincode directory, so that the package ID
+// does not depend on where the source is checked out. The import path of Go chaincode is kept.
+func (c *PackageCommand) normalizeMetadataPath(metadata *lifecyclepkg.PackageMetadata, ccPath string) error {
+	if c.isGolang() {
+		if metadata.Path == ccPath {
+			metadata.Path = c.Path
+		}
+
+		return nil
+	}
+
+	abs, err := filepath.Abs(c.Path)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(c.Settings.Streams.Out, "successfully packaged chaincode '%s'\n", c.Label)
+	metadata.Path = filepath.Base(abs)
 
 	return nil
 }
+
+// isSelfContained returns true if the chaincode path is a directory containing all the source
+// that is packaged, which is not the case for Go chaincode outside of a module root or for a
+// module which replaces modules with directories outside of it
+func (c *PackageCommand) isSelfContained() bool {
+	if info, err := os.Stat(c.Path); err != nil || !info.IsDir() {
+		return false
+	}
+
+	if !c.isGolang() {
+		return true
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(c.Path, "go.mod"))
+	if err != nil {
+		return false
+	}
+
+	return !hasExternalReplace(data)
+}
+
+func (c *PackageCommand) isGolang() bool {
+	return strings.EqualFold(c.Type, pb.ChaincodeSpec_GOLANG.String())
+}
+
+// hasExternalReplace returns true if a go.mod replaces a module with a directory outside of the
+// module, such as "replace example.com/x => ../x"
+func hasExternalReplace(goMod []byte) bool {
+	inBlock := false
+
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case fields[0] == "replace" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "replace":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+
+		for i, field := range fields {
+			if field == "=>" && i+1 < len(fields) && isExternalDir(fields[i+1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isExternalDir returns true if a replacement is a relative directory outside of the module
+func isExternalDir(replacement string) bool {
+	if !strings.HasPrefix(replacement, "./") && !strings.HasPrefix(replacement, "../") && replacement != ".." {
+		return false
+	}
+
+	cleaned := path.Clean(replacement)
+
+	return cleaned == ".." || strings.HasPrefix(cleaned, "../")
+}
+
+// sourceRelativePath returns the path of a code package entry relative to the chaincode path
+func sourceRelativePath(name, ccPath string) string {
+	if !strings.HasPrefix(name, "src/") {
+		return name
+	}
+
+	name = strings.TrimPrefix(name, "src/")
+
+	// GOPATH chaincode is stored below its import path
+	if ccPath != "" && strings.HasPrefix(name, ccPath+"/") {
+		name = strings.TrimPrefix(name, ccPath+"/")
+	}
+
+	return name
+}
+
+// newExternalPackage creates a package containing the connection.json for a chaincode service
+func (c *PackageCommand) newExternalPackage() ([]byte, error) {
+	conn := connection{
+		Address:            c.Address,
+		DialTimeout:        c.DialTimeout.String(),
+		TLSRequired:        c.TLSRequired,
+		ClientAuthRequired: c.ClientCert != "",
+	}
+
+	for _, f := range []struct {
+		path  string
+		value *string
+	}{
+		{c.ClientCert, &conn.ClientCert},
+		{c.ClientKey, &conn.ClientKey},
+		{c.RootCert, &conn.RootCert},
+	} {
+		if f.path == "" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return nil, err
+		}
+
+		*f.value = string(data)
+	}
+
+	connBytes, err := json.Marshal(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	codeBytes, err := tarGz(map[string][]byte{connectionPackageName: connBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	metadataBytes, err := json.Marshal(&lifecyclepkg.PackageMetadata{
+		Type:  strings.ToLower(c.Type),
+		Label: c.Label,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tarGz(map[string][]byte{
+		metadataPackageName: metadataBytes,
+		codePackageName:     codeBytes,
+	})
+}
+
+// tarGz writes the given files into a gzipped tar in name order
+func tarGz(files map[string][]byte) ([]byte, error) {
+	return tarGzWithDirs(files, nil, nil)
+}
+
+// tarGzWithDirs writes the given files into a gzipped tar in name order. The given
+// directories are written ahead of the files if they contain at least one of them.
+// Executable files keep their execute bits, all other permissions are normalized.
+func tarGzWithDirs(files map[string][]byte, dirs []string, executable map[string]bool) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	used := make(map[string]bool)
+	for _, name := range names {
+		for dir := path.Dir(name); dir != "." && !used[dir]; dir = path.Dir(dir) {
+			used[dir] = true
+		}
+	}
+
+	var usedDirs []string
+	for _, dir := range dirs {
+		if used[strings.TrimSuffix(dir, "/")] {
+			usedDirs = append(usedDirs, strings.TrimSuffix(dir, "/")+"/")
+		}
+	}
+
+	sort.Strings(usedDirs)
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	for _, dir := range usedDirs {
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     dir,
+			Mode:     040755,
+			Uid:      packageFileOwner,
+			Gid:      packageFileOwner,
+			ModTime:  time.Unix(0, 0),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range names {
+		mode := int64(0100644)
+		if executable[name] {
+			mode = 0100755
+		}
+
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(files[name])),
+			Mode:     mode,
+			Uid:      packageFileOwner,
+			Gid:      packageFileOwner,
+			ModTime:  time.Unix(0, 0),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := tw.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return payload.Bytes(), nil
+}
+
+// readTarGz returns the regular files and the directories contained in a gzipped tar
+func readTarGz(data []byte) (map[string][]byte, []string, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[string][]byte)
+	var dirs []string
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			dirs = append(dirs, header.Name)
+		case tar.TypeReg:
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			files[header.Name] = content
+		}
+	}
+
+	return files, dirs, nil
+}
@@ -7,9 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle_test
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -58,7 +65,7 @@ var _ = Describe("LifecycleChaincodePackageCommand", func() {
 		os.Args = append(os.Args, "--help")
 
 		Expect(cmd.Execute()).Should(Succeed())
-		Expect(fmt.Sprint(out)).To(ContainSubstring("package <chaincode-label> <chaincode-type> <path>"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("package <chaincode-label> <chaincode-type> [path]"))
 	})
 })
 
@@ -120,6 +127,80 @@ var _ = Describe("LifecycleChaincodePackageImplementation", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		Context("when chaincode type is ccaas", func() {
+			BeforeEach(func() {
+				impl.Label = "mycc"
+				impl.Type = "ccaas"
+				impl.DialTimeout = 10 * time.Second
+			})
+
+			It("should fail without an address", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("chaincode address not specified"))
+			})
+
+			Context("when an address is set", func() {
+				BeforeEach(func() {
+					impl.Address = "mycc:9999"
+				})
+
+				It("should succeed without a chaincode path", func() {
+					Expect(err).To(BeNil())
+				})
+			})
+
+			Context("when the label is invalid", func() {
+				BeforeEach(func() {
+					impl.Label = "my cc"
+					impl.Address = "mycc:9999"
+				})
+
+				It("should fail with an invalid label", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("invalid chaincode label 'my cc'"))
+				})
+			})
+
+			Context("when TLS is required without a root cert", func() {
+				BeforeEach(func() {
+					impl.Address = "mycc:9999"
+					impl.TLSRequired = true
+				})
+
+				It("should fail without a root cert", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("root cert not specified"))
+				})
+			})
+
+			Context("when client cert is set without client key", func() {
+				BeforeEach(func() {
+					impl.Address = "mycc:9999"
+					impl.TLSRequired = true
+					impl.RootCert = "root.pem"
+					impl.ClientCert = "cert.pem"
+				})
+
+				It("should fail without a client key", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("client cert and client key must be specified together"))
+				})
+			})
+		})
+
+		Context("when chaincode type is unsupported", func() {
+			BeforeEach(func() {
+				impl.Label = "mycc"
+				impl.Path = "path"
+				impl.Type = "cobol"
+			})
+
+			It("should fail with an unsupported type", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("unsupported chaincode type"))
+			})
+		})
 	})
 
 	Describe("Run", func() {
@@ -148,5 +229,264 @@ var _ = Describe("LifecycleChaincodePackageImplementation", func() {
 				Expect(err).NotTo(BeNil())
 			})
 		})
+
+		Context("when chaincode type is node", func() {
+			var dir string
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "package")
+				Expect(err).To(BeNil())
+
+				for name, content := range map[string]string{
+					"chaincode/package.json":           `{"name": "mycc"}`,
+					"chaincode/index.js":               "module.exports = {};",
+					"chaincode/lib/contract.js":        "module.exports = {};",
+					"chaincode/test/contract.test.js":  "describe('contract');",
+					"chaincode/lib/contract.spec.js":   "describe('contract');",
+					"chaincode/coverage/lcov.info":     "TN:",
+					"chaincode/coverage/keep/notes.md": "notes",
+					"chaincode/.fabricignore":          "# tests\ntest/\n*.spec.js\n/coverage\n.fabricignore\n",
+				} {
+					p := filepath.Join(dir, name)
+					Expect(os.MkdirAll(filepath.Dir(p), 0755)).To(Succeed())
+					Expect(ioutil.WriteFile(p, []byte(content), 0644)).To(Succeed())
+				}
+
+				// excluded directories are not read, so a broken link in them does not fail packaging
+				Expect(os.Symlink(filepath.Join(dir, "missing.js"), filepath.Join(dir, "chaincode/coverage/broken.js"))).To(Succeed())
+
+				impl.Type = "node"
+				impl.Path = filepath.Join(dir, "chaincode")
+				impl.Output = filepath.Join(dir, "mycc.tgz")
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should exclude ignored files", func() {
+				Expect(err).To(BeNil())
+
+				files := readTarGz(impl.Output)
+				code := readTarGzBytes(files["code.tar.gz"])
+				Expect(code).To(HaveLen(3))
+				Expect(code).To(HaveKey("src/package.json"))
+				Expect(code).To(HaveKey("src/index.js"))
+				Expect(code).To(HaveKey("src/lib/contract.js"))
+
+				Expect(fmt.Sprint(out)).To(Equal("successfully packaged chaincode 'mycc'\n" +
+					"Included files:\n - src/index.js\n - src/lib/contract.js\n - src/package.json\n"))
+			})
+
+			It("should record the name of the chaincode directory in the metadata", func() {
+				Expect(err).To(BeNil())
+
+				files := readTarGz(impl.Output)
+				Expect(string(files["metadata.json"])).To(MatchJSON(`{"path":"chaincode","type":"NODE","label":"mycc"}`))
+			})
+
+			It("should normalize the file permissions", func() {
+				Expect(err).To(BeNil())
+
+				files := readTarGz(impl.Output)
+				Expect(readTarGzModes(files["code.tar.gz"])).To(HaveKeyWithValue("src/index.js", int64(0100644)))
+			})
+
+			Context("when a file is executable", func() {
+				BeforeEach(func() {
+					Expect(os.Chmod(filepath.Join(impl.Path, "index.js"), 0775)).To(Succeed())
+				})
+
+				It("should keep the execute bit", func() {
+					Expect(err).To(BeNil())
+
+					files := readTarGz(impl.Output)
+					modes := readTarGzModes(files["code.tar.gz"])
+					Expect(modes).To(HaveKeyWithValue("src/index.js", int64(0100755)))
+					Expect(modes).To(HaveKeyWithValue("src/package.json", int64(0100644)))
+				})
+			})
+
+			Context("when the chaincode is packaged from another checkout", func() {
+				It("should create the same package", func() {
+					Expect(err).To(BeNil())
+
+					first, err := ioutil.ReadFile(impl.Output)
+					Expect(err).To(BeNil())
+
+					other := filepath.Join(dir, "other", "chaincode")
+					Expect(os.MkdirAll(filepath.Dir(other), 0755)).To(Succeed())
+					Expect(os.Rename(impl.Path, other)).To(Succeed())
+					impl.Path = other
+					Expect(impl.Run()).To(Succeed())
+
+					second, err := ioutil.ReadFile(impl.Output)
+					Expect(err).To(BeNil())
+					Expect(second).To(Equal(first))
+				})
+			})
+
+			It("should create the same package regardless of file timestamps", func() {
+				Expect(err).To(BeNil())
+
+				first, err := ioutil.ReadFile(impl.Output)
+				Expect(err).To(BeNil())
+
+				later := time.Now().Add(time.Hour)
+				Expect(os.Chtimes(filepath.Join(impl.Path, "index.js"), later, later)).To(Succeed())
+				Expect(impl.Run()).To(Succeed())
+
+				second, err := ioutil.ReadFile(impl.Output)
+				Expect(err).To(BeNil())
+				Expect(second).To(Equal(first))
+			})
+		})
+
+		Context("when chaincode type is golang and the module replaces a module outside of it", func() {
+			var dir string
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "package")
+				Expect(err).To(BeNil())
+
+				for name, content := range map[string]string{
+					"cc/go.mod": "module example.com/cc\n\ngo 1.12\n\nrequire example.com/lib v0.0.0\n\n" +
+						"replace example.com/lib => ../lib // local copy\n",
+					"cc/main.go":       "package main\n\nimport \"example.com/lib\"\n\nfunc main() { lib.Run() }\n",
+					"cc/main_test.go":  "package main\n",
+					"cc/.fabricignore": "*_test.go\n",
+					"lib/go.mod":       "module example.com/lib\n\ngo 1.12\n",
+					"lib/lib.go":       "package lib\n\n// Run runs\nfunc Run() {}\n",
+				} {
+					p := filepath.Join(dir, name)
+					Expect(os.MkdirAll(filepath.Dir(p), 0755)).To(Succeed())
+					Expect(ioutil.WriteFile(p, []byte(content), 0644)).To(Succeed())
+				}
+
+				impl.Type = "golang"
+				impl.Path = filepath.Join(dir, "cc")
+				impl.Output = filepath.Join(dir, "mycc.tgz")
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should package the module in place and exclude ignored files", func() {
+				Expect(err).To(BeNil())
+
+				files := readTarGz(impl.Output)
+				code := readTarGzBytes(files["code.tar.gz"])
+				Expect(code).To(HaveKey("src/main.go"))
+				Expect(code).NotTo(HaveKey("src/main_test.go"))
+			})
+		})
+
+		Context("when chaincode type is external", func() {
+			var dir string
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "package")
+				Expect(err).To(BeNil())
+
+				rootCert := filepath.Join(dir, "root.pem")
+				Expect(ioutil.WriteFile(rootCert, []byte("root cert"), 0600)).To(Succeed())
+
+				impl.Type = "external"
+				impl.Address = "mycc:9999"
+				impl.DialTimeout = 5 * time.Second
+				impl.TLSRequired = true
+				impl.RootCert = rootCert
+				impl.Output = filepath.Join(dir, "mycc.tgz")
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should write the package to the output path", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(Equal("successfully packaged chaincode 'mycc'\n"))
+
+				files := readTarGz(impl.Output)
+				Expect(files).To(HaveKey("metadata.json"))
+				Expect(files).To(HaveKey("code.tar.gz"))
+				Expect(string(files["metadata.json"])).To(MatchJSON(`{"path":"","type":"external","label":"mycc"}`))
+
+				code := readTarGzBytes(files["code.tar.gz"])
+				Expect(code).To(HaveKey("connection.json"))
+
+				conn := make(map[string]interface{})
+				Expect(json.Unmarshal(code["connection.json"], &conn)).To(Succeed())
+				Expect(conn["address"]).To(Equal("mycc:9999"))
+				Expect(conn["dial_timeout"]).To(Equal("5s"))
+				Expect(conn["tls_required"]).To(BeTrue())
+				Expect(conn["client_auth_required"]).To(BeFalse())
+				Expect(conn["root_cert"]).To(Equal("root cert"))
+			})
+		})
+
+		Context("when a TLS certificate file does not exist", func() {
+			BeforeEach(func() {
+				impl.Type = "ccaas"
+				impl.Address = "mycc:9999"
+				impl.TLSRequired = true
+				impl.RootCert = "path/to/root.pem"
+			})
+
+			It("should fail to read the certificate", func() {
+				Expect(err).NotTo(BeNil())
+			})
+		})
 	})
 })
+
+func readTarGz(path string) map[string][]byte {
+	data, err := ioutil.ReadFile(path)
+	Expect(err).To(BeNil())
+
+	return readTarGzBytes(data)
+}
+
+func readTarGzBytes(data []byte) map[string][]byte {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	Expect(err).To(BeNil())
+
+	files := make(map[string][]byte)
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).To(BeNil())
+
+		content, err := ioutil.ReadAll(tr)
+		Expect(err).To(BeNil())
+
+		files[header.Name] = content
+	}
+
+	return files
+}
+
+func readTarGzModes(data []byte) map[string]int64 {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	Expect(err).To(BeNil())
+
+	modes := make(map[string]int64)
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).To(BeNil())
+
+		modes[header.Name] = header.Mode
+	}
+
+	return modes
+}
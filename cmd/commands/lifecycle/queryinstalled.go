@@ -7,12 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
 )
 
 // NewQueryInstalledCommand creates a new "fabric lifecycle queryinstalled" command
@@ -24,7 +30,13 @@ func NewQueryInstalledCommand(settings *environment.Settings) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "queryinstalled <peer>",
 		Short: "Query a peer for installed chaincodes",
-		Args:  c.ParseArgs(),
+		Long: `Query a peer for installed chaincodes
+
+With --all-peers, every peer of the current context is queried (or, if the context has no peers,
+every peer of the context's organization in the network config) and the result is shown as a
+package by peer matrix. Packages that are not referenced by any chaincode definition are marked
+as orphaned.`,
+		Args: c.ParseArgs(),
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := c.Complete(); err != nil {
 				return err
@@ -41,6 +53,7 @@ func NewQueryInstalledCommand(settings *environment.Settings) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVar(&c.OutputFormat, "output", "", outputFormatUsage)
+	flags.BoolVar(&c.AllPeers, "all-peers", false, "query all peers and show a package by peer matrix")
 
 	cmd.SetOutput(c.Settings.Streams.Out)
 
@@ -53,11 +66,28 @@ type QueryInstalledCommand struct {
 
 	Peer         string
 	OutputFormat string
+	AllPeers     bool
+}
+
+// installedMatrix contains the packages installed on a set of peers
+type installedMatrix struct {
+	Peers    []string            `json:"peers"`
+	Packages []*installedPackage `json:"packages"`
+	Errors   map[string]string   `json:"errors,omitempty"`
+}
+
+// installedPackage contains the peers a package is installed on and the definitions referencing it
+type installedPackage struct {
+	PackageID  string                           `json:"package_id"`
+	Label      string                           `json:"label"`
+	Peers      []string                         `json:"peers"`
+	References map[string][]resmgmt.CCReference `json:"references,omitempty"`
+	Orphaned   bool                             `json:"orphaned"`
 }
 
 // Validate checks the required parameters for run
 func (c *QueryInstalledCommand) Validate() error {
-	if c.Peer == "" {
+	if c.Peer == "" && !c.AllPeers {
 		return errors.New("peer not specified")
 	}
 
@@ -66,6 +96,10 @@ func (c *QueryInstalledCommand) Validate() error {
 
 // Run executes the command
 func (c *QueryInstalledCommand) Run() error {
+	if c.AllPeers {
+		return c.runAllPeers()
+	}
+
 	installedChaincodes, err := c.ResourceManagement.LifecycleQueryInstalledCC(
 		resmgmt.WithRetry(retry.DefaultResMgmtOpts),
 		resmgmt.WithTargetEndpoints(c.Peer),
@@ -108,3 +142,172 @@ func (c *QueryInstalledCommand) printReferences(refs map[string][]resmgmt.CCRefe
 		}
 	}
 }
+
+func (c *QueryInstalledCommand) runAllPeers() error {
+	peers, err := c.peers()
+	if err != nil {
+		return err
+	}
+
+	if len(peers) == 0 {
+		return errors.New("no peers found in the current context or network config")
+	}
+
+	matrix := &installedMatrix{
+		Peers:  peers,
+		Errors: make(map[string]string),
+	}
+
+	packages := make(map[string]*installedPackage)
+
+	for _, peer := range peers {
+		installedChaincodes, err := c.ResourceManagement.LifecycleQueryInstalledCC(
+			resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+			resmgmt.WithTargetEndpoints(peer),
+		)
+		if err != nil {
+			matrix.Errors[peer] = err.Error()
+			continue
+		}
+
+		for _, cc := range installedChaincodes {
+			pkg, ok := packages[cc.PackageID]
+			if !ok {
+				pkg = &installedPackage{
+					PackageID:  cc.PackageID,
+					Label:      cc.Label,
+					References: make(map[string][]resmgmt.CCReference),
+				}
+				packages[cc.PackageID] = pkg
+				matrix.Packages = append(matrix.Packages, pkg)
+			}
+
+			pkg.Peers = append(pkg.Peers, peer)
+			pkg.addReferences(cc.References)
+		}
+	}
+
+	for _, pkg := range matrix.Packages {
+		pkg.Orphaned = len(pkg.References) == 0
+	}
+
+	sort.Slice(matrix.Packages, func(i, j int) bool {
+		return matrix.Packages[i].PackageID < matrix.Packages[j].PackageID
+	})
+
+	if c.OutputFormat == jsonFormat {
+		if err := c.printJSONResponse(matrix); err != nil {
+			return err
+		}
+	} else {
+		c.printMatrix(matrix)
+	}
+
+	if len(matrix.Errors) > 0 {
+		return fmt.Errorf("failed to query %d of %d peers", len(matrix.Errors), len(peers))
+	}
+
+	return nil
+}
+
+// peers returns the peers of the current context, falling back to the peers of the
+// context's organization in the network config
+func (c *QueryInstalledCommand) peers() ([]string, error) {
+	context, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(context.Peers) > 0 {
+		return context.Peers, nil
+	}
+
+	sdk, err := c.Factory.SDK()
+	if err != nil {
+		return nil, err
+	}
+
+	return fabric.OrganizationPeers(sdk, context.Organization)
+}
+
+func (c *QueryInstalledCommand) printMatrix(matrix *installedMatrix) {
+	if len(matrix.Packages) == 0 {
+		c.println("No installed chaincodes")
+	} else {
+		w := tabwriter.NewWriter(c.Settings.Streams.Out, 4, 4, 4, ' ', 0)
+
+		fmt.Fprintf(w, "PACKAGE ID\tLABEL\t%s\tREFERENCES\n", strings.Join(matrix.Peers, "\t"))
+
+		for _, pkg := range matrix.Packages {
+			installed := make(map[string]bool)
+			for _, peer := range pkg.Peers {
+				installed[peer] = true
+			}
+
+			cells := make([]string, len(matrix.Peers))
+			for i, peer := range matrix.Peers {
+				switch {
+				case installed[peer]:
+					cells[i] = "X"
+				case matrix.Errors[peer] != "":
+					cells[i] = "?"
+				default:
+					cells[i] = "-"
+				}
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pkg.PackageID, pkg.Label, strings.Join(cells, "\t"), pkg.referencesString())
+		}
+
+		w.Flush()
+	}
+
+	for _, peer := range matrix.Peers {
+		if msg, ok := matrix.Errors[peer]; ok {
+			c.printf("Failed to query peer %s: %s\n", peer, msg)
+		}
+	}
+}
+
+// addReferences merges the given references into the package's references
+func (p *installedPackage) addReferences(refs map[string][]resmgmt.CCReference) {
+	for channelID, channelRefs := range refs {
+		for _, ref := range channelRefs {
+			if !containsReference(p.References[channelID], ref) {
+				p.References[channelID] = append(p.References[channelID], ref)
+			}
+		}
+	}
+}
+
+func (p *installedPackage) referencesString() string {
+	if len(p.References) == 0 {
+		return "ORPHANED"
+	}
+
+	channelIDs := make([]string, 0, len(p.References))
+	for channelID := range p.References {
+		channelIDs = append(channelIDs, channelID)
+	}
+
+	sort.Strings(channelIDs)
+
+	var refs []string
+	for _, channelID := range channelIDs {
+		for _, ref := range p.References[channelID] {
+			refs = append(refs, fmt.Sprintf("%s/%s:%s", channelID, ref.Name, ref.Version))
+		}
+	}
+
+	return strings.Join(refs, ", ")
+}
+
+func containsReference(refs []resmgmt.CCReference, ref resmgmt.CCReference) bool {
+	for _, r := range refs {
+		if r.Name == ref.Name && r.Version == ref.Version {
+			return true
+		}
+	}
+
+	return false
+}
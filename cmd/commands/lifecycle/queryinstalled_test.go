@@ -115,6 +115,16 @@ var _ = Describe("LifecycleChaincodeQueryInstalledImplementation", func() {
 			})
 		})
 
+		Context("when --all-peers is set", func() {
+			BeforeEach(func() {
+				impl.AllPeers = true
+			})
+
+			It("should succeed without peer", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("when all arguments are set", func() {
 			BeforeEach(func() {
 				impl.Peer = "peer1"
@@ -215,6 +225,110 @@ var _ = Describe("LifecycleChaincodeQueryInstalledImplementation", func() {
 			})
 		})
 
+		Context("when --all-peers is set", func() {
+			BeforeEach(func() {
+				impl.Peer = ""
+				impl.AllPeers = true
+
+				settings.Config = &environment.Config{
+					Contexts: map[string]*environment.Context{
+						"foo": {
+							Peers: []string{"peer1", "peer2"},
+						},
+					},
+					CurrentContext: "foo",
+				}
+
+				client.LifecycleQueryInstalledCCReturnsOnCall(0, []resmgmt.LifecycleInstalledCC{
+					{
+						PackageID: "pkg1",
+						Label:     "label1",
+						References: map[string][]resmgmt.CCReference{
+							"channel1": {
+								{
+									Name:    "cc1",
+									Version: "v1",
+								},
+							},
+						},
+					},
+					{
+						PackageID: "pkg2",
+						Label:     "label2",
+					},
+				}, nil)
+				client.LifecycleQueryInstalledCCReturnsOnCall(1, []resmgmt.LifecycleInstalledCC{
+					{
+						PackageID: "pkg1",
+						Label:     "label1",
+					},
+				}, nil)
+			})
+
+			It("should print a package by peer matrix", func() {
+				Expect(err).To(BeNil())
+				Expect(client.LifecycleQueryInstalledCCCallCount()).To(Equal(2))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`PACKAGE ID\s+LABEL\s+peer1\s+peer2\s+REFERENCES`))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`pkg1\s+label1\s+X\s+X\s+channel1/cc1:v1`))
+				Expect(fmt.Sprint(out)).To(MatchRegexp(`pkg2\s+label2\s+X\s+-\s+ORPHANED`))
+			})
+
+			When("the output format is set to json", func() {
+				BeforeEach(func() {
+					impl.OutputFormat = "json"
+				})
+
+				It("should succeed with JSON response", func() {
+					Expect(err).To(BeNil())
+					Expect(fmt.Sprint(out)).To(MatchJSON(`{
+						"peers": ["peer1", "peer2"],
+						"packages": [
+							{
+								"package_id": "pkg1",
+								"label": "label1",
+								"peers": ["peer1", "peer2"],
+								"references": {"channel1": [{"name": "cc1", "version": "v1"}]},
+								"orphaned": false
+							},
+							{
+								"package_id": "pkg2",
+								"label": "label2",
+								"peers": ["peer1"],
+								"orphaned": true
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when a peer fails", func() {
+				BeforeEach(func() {
+					client.LifecycleQueryInstalledCCReturnsOnCall(1, nil, errors.New("query installed error"))
+				})
+
+				It("should report the failed peer", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("failed to query 1 of 2 peers"))
+					Expect(fmt.Sprint(out)).To(MatchRegexp(`pkg1\s+label1\s+X\s+\?`))
+					Expect(fmt.Sprint(out)).To(ContainSubstring("Failed to query peer peer2: query installed error"))
+				})
+			})
+
+			Context("when the context has no peers", func() {
+				BeforeEach(func() {
+					settings.Config.Contexts["foo"].Peers = nil
+
+					factory.SDKReturns(nil, errors.New("sdk error"))
+				})
+
+				It("should look up the peers in the network config", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("sdk error"))
+					Expect(factory.SDKCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when resmgmt client fails", func() {
 			BeforeEach(func() {
 				settings.Config = &environment.Config{
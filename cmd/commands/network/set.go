@@ -9,6 +9,7 @@ package network
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric-cli/cmd/common"
 	"github.com/hyperledger/fabric-cli/pkg/environment"
@@ -26,8 +27,11 @@ func NewNetworkSetCommand(settings *environment.Settings) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set <network-name> <config-file-path>",
 		Short: "Set a network",
-		Long:  "Set a network, path indicates the Fabric Go SDK' config path",
-		Args:  c.ParseArgs(),
+		Long: `Set a network, path indicates the Fabric Go SDK' config path
+
+The operations listeners of the peers and orderers, used by the node commands, are set with
+--operations-node, e.g. --operations-node peer0.org1.example.com=https://localhost:9443`,
+		Args: c.ParseArgs(),
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			return c.Validate()
 		},
@@ -39,6 +43,13 @@ func NewNetworkSetCommand(settings *environment.Settings) *cobra.Command {
 	c.AddArg(&c.Name)
 	c.AddArg(&c.Network.ConfigPath)
 
+	flags := cmd.Flags()
+	flags.StringArrayVar(&c.OperationsNodes, "operations-node", []string{},
+		"Set the operations listener URL of a node as <node-name>=<url> (this option may be specified multiple times)")
+	flags.StringVar(&c.OperationsTLSCACert, "operations-tls-ca-cert", "", "Set the path to the CA certificate of the operations listeners")
+	flags.StringVar(&c.OperationsTLSClientCert, "operations-tls-client-cert", "", "Set the path to the client certificate for the operations listeners")
+	flags.StringVar(&c.OperationsTLSClientKey, "operations-tls-client-key", "", "Set the path to the client key for the operations listeners")
+
 	cmd.SetOutput(c.Settings.Streams.Out)
 
 	return cmd
@@ -50,6 +61,11 @@ type SetCommand struct {
 
 	Name    string
 	Network *environment.Network
+
+	OperationsNodes         []string
+	OperationsTLSCACert     string
+	OperationsTLSClientCert string
+	OperationsTLSClientKey  string
 }
 
 // Validate checks the required parameters for run
@@ -62,11 +78,30 @@ func (c *SetCommand) Validate() error {
 		return errors.New("network configuration path not specified")
 	}
 
+	for _, node := range c.OperationsNodes {
+		if name, address := splitOperationsNode(node); len(name) == 0 || len(address) == 0 {
+			return fmt.Errorf("invalid operations node '%s', expected <node-name>=<url>", node)
+		}
+	}
+
+	if len(c.OperationsNodes) == 0 &&
+		(len(c.OperationsTLSCACert) > 0 || len(c.OperationsTLSClientCert) > 0 || len(c.OperationsTLSClientKey) > 0) {
+		return errors.New("--operations-tls-* flags require --operations-node")
+	}
+
+	if (len(c.OperationsTLSClientCert) == 0) != (len(c.OperationsTLSClientKey) == 0) {
+		return errors.New("--operations-tls-client-cert and --operations-tls-client-key must be set together")
+	}
+
 	return nil
 }
 
 // Run executes the command
 func (c *SetCommand) Run() error {
+	if c.Network != nil {
+		c.Network.Operations = c.operations()
+	}
+
 	err := c.Settings.ModifyConfig(environment.SetNetwork(c.Name, c.Network))
 	if err != nil {
 		return err
@@ -78,3 +113,51 @@ func (c *SetCommand) Run() error {
 
 	return nil
 }
+
+// operations merges the operations flags into the operations config of the existing network,
+// so that setting a network again keeps the nodes which are not given
+func (c *SetCommand) operations() *environment.Operations {
+	operations := &environment.Operations{Nodes: make(map[string]string)}
+
+	if c.Settings.Config != nil {
+		if network, ok := c.Settings.Config.Networks[c.Name]; ok && network.Operations != nil {
+			for name, address := range network.Operations.Nodes {
+				operations.Nodes[name] = address
+			}
+
+			operations.TLSCACert = network.Operations.TLSCACert
+			operations.TLSClientCert = network.Operations.TLSClientCert
+			operations.TLSClientKey = network.Operations.TLSClientKey
+		}
+	}
+
+	for _, node := range c.OperationsNodes {
+		name, address := splitOperationsNode(node)
+		operations.Nodes[name] = address
+	}
+
+	if len(c.OperationsTLSCACert) > 0 {
+		operations.TLSCACert = c.OperationsTLSCACert
+	}
+
+	if len(c.OperationsTLSClientCert) > 0 {
+		operations.TLSClientCert = c.OperationsTLSClientCert
+		operations.TLSClientKey = c.OperationsTLSClientKey
+	}
+
+	if len(operations.Nodes) == 0 {
+		return nil
+	}
+
+	return operations
+}
+
+// splitOperationsNode splits a <node-name>=<url> flag value
+func splitOperationsNode(node string) (string, string) {
+	parts := strings.SplitN(node, "=", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
@@ -92,6 +92,50 @@ var _ = Describe("SetNetworkImplementation", func() {
 			err = impl.Validate()
 		})
 
+		Context("when an operations node is invalid", func() {
+			BeforeEach(func() {
+				impl.Name = "foo"
+				impl.Network = &environment.Network{
+					ConfigPath: "foo/bar",
+				}
+				impl.OperationsNodes = []string{"peer0"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid operations node 'peer0', expected <node-name>=<url>"))
+			})
+		})
+
+		Context("when the client certificate is set without a key", func() {
+			BeforeEach(func() {
+				impl.Name = "foo"
+				impl.Network = &environment.Network{
+					ConfigPath: "foo/bar",
+				}
+				impl.OperationsTLSClientCert = "client.pem"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+			})
+		})
+
+		Context("when TLS flags are set without operations nodes", func() {
+			BeforeEach(func() {
+				impl.Name = "foo"
+				impl.Network = &environment.Network{
+					ConfigPath: "foo/bar",
+				}
+				impl.OperationsTLSCACert = "ca.pem"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--operations-tls-* flags require --operations-node"))
+			})
+		})
+
 		It("should fail without network name", func() {
 			Expect(err).NotTo(BeNil())
 		})
@@ -140,6 +184,68 @@ var _ = Describe("SetNetworkImplementation", func() {
 			})
 		})
 
+		Context("when operations nodes are set", func() {
+			BeforeEach(func() {
+				impl.Name = "bar"
+				impl.Network = &environment.Network{
+					ConfigPath: "foo/bar",
+				}
+				impl.OperationsNodes = []string{"peer0=https://localhost:9443"}
+				impl.OperationsTLSCACert = "ca.pem"
+			})
+
+			It("should set the operations of the network", func() {
+				Expect(err).To(BeNil())
+				Expect(settings.Config.Networks["bar"].Operations).To(Equal(&environment.Operations{
+					Nodes:     map[string]string{"peer0": "https://localhost:9443"},
+					TLSCACert: "ca.pem",
+				}))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("peer0:"))
+			})
+		})
+
+		Context("when the network has operations nodes", func() {
+			BeforeEach(func() {
+				impl.Name = "bar"
+				impl.Network = &environment.Network{
+					ConfigPath: "foo/bar",
+				}
+
+				settings.Config.Networks["bar"] = &environment.Network{
+					ConfigPath: "foo/bar",
+					Operations: &environment.Operations{
+						Nodes:     map[string]string{"peer0": "https://localhost:9443"},
+						TLSCACert: "ca.pem",
+					},
+				}
+			})
+
+			It("should keep the operations of the network", func() {
+				Expect(err).To(BeNil())
+				Expect(settings.Config.Networks["bar"].Operations).To(Equal(&environment.Operations{
+					Nodes:     map[string]string{"peer0": "https://localhost:9443"},
+					TLSCACert: "ca.pem",
+				}))
+			})
+
+			Context("when another operations node is set", func() {
+				BeforeEach(func() {
+					impl.OperationsNodes = []string{"orderer0=https://localhost:8443"}
+				})
+
+				It("should add the node", func() {
+					Expect(err).To(BeNil())
+					Expect(settings.Config.Networks["bar"].Operations).To(Equal(&environment.Operations{
+						Nodes: map[string]string{
+							"peer0":    "https://localhost:9443",
+							"orderer0": "https://localhost:8443",
+						},
+						TLSCACert: "ca.pem",
+					}))
+				})
+			})
+		})
+
 		Context("when network file is invalid", func() {
 			BeforeEach(func() {
 				data, _ := yaml.Marshal(struct {
@@ -0,0 +1,78 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewHealthCommand creates a new "fabric node health" command
+func NewHealthCommand(settings *environment.Settings) *cobra.Command {
+	c := HealthCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "health <node>",
+		Short: "Check the health of a node",
+		Long:  "Check the health of a node and list the components which failed their health checks",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Complete()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Node)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// HealthCommand implements the node health command
+type HealthCommand struct {
+	BaseCommand
+}
+
+// Run executes the command
+func (c *HealthCommand) Run() error {
+	status, err := c.Operations.Health()
+	if err != nil {
+		return err
+	}
+
+	out := c.Settings.Streams.Out
+
+	fmt.Fprintf(out, "Status: %s\n", status.Status)
+
+	if len(status.FailedChecks) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out, "")
+
+	w := tabwriter.NewWriter(out, 4, 4, 4, ' ', 0)
+
+	fmt.Fprintln(w, "COMPONENT\tREASON")
+	for _, check := range status.FailedChecks {
+		fmt.Fprintf(w, "%s\t%s\n", check.Component, check.Reason)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("node '%s' is not healthy", c.Node)
+}
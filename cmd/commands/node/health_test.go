@@ -0,0 +1,122 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/node"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("NodeHealthCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = node.NewHealthCommand(settings)
+	})
+
+	It("should create a node health command", func() {
+		Expect(cmd.Name()).To(Equal("health"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+})
+
+var _ = Describe("NodeHealthImplementation", func() {
+	var (
+		impl       *node.HealthCommand
+		err        error
+		out        *bytes.Buffer
+		operations *mocks.Operations
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		operations = &mocks.Operations{}
+		operations.HealthReturns(&fabric.HealthStatus{Status: "OK"}, nil)
+
+		impl = &node.HealthCommand{}
+		impl.Node = "peer0"
+		impl.Operations = operations
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	It("should not be nil", func() {
+		Expect(impl).ShouldNot(BeNil())
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the status", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal("Status: OK\n"))
+		})
+
+		Context("when health checks failed", func() {
+			BeforeEach(func() {
+				operations.HealthReturns(&fabric.HealthStatus{
+					Status: "Service Unavailable",
+					FailedChecks: []fabric.FailedCheck{
+						{Component: "docker", Reason: "failed to ping docker"},
+					},
+				}, nil)
+			})
+
+			It("should print the failed checks and fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("node 'peer0' is not healthy"))
+				Expect(fmt.Sprint(out)).To(Equal(
+					"Status: Service Unavailable\n\n" +
+						"COMPONENT    REASON\n" +
+						"docker       failed to ping docker\n"))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				operations.HealthReturns(nil, errors.New("connection refused"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("connection refused"))
+			})
+		})
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewLogSpecCommand creates a new "fabric node logspec" command
+func NewLogSpecCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logspec",
+		Short: "Manage the logging specification of a node",
+		Long:  "Manage the logging specification of a node with get|set",
+	}
+
+	cmd.AddCommand(
+		NewLogSpecGetCommand(settings),
+		NewLogSpecSetCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// NewLogSpecGetCommand creates a new "fabric node logspec get" command
+func NewLogSpecGetCommand(settings *environment.Settings) *cobra.Command {
+	c := LogSpecGetCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "get <node>",
+		Short: "Show the logging specification of a node",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Complete()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Node)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// LogSpecGetCommand implements the node logspec get command
+type LogSpecGetCommand struct {
+	BaseCommand
+}
+
+// Run executes the command
+func (c *LogSpecGetCommand) Run() error {
+	spec, err := c.Operations.LogSpec()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.Settings.Streams.Out, spec)
+
+	return nil
+}
+
+// NewLogSpecSetCommand creates a new "fabric node logspec set" command
+func NewLogSpecSetCommand(settings *environment.Settings) *cobra.Command {
+	c := LogSpecSetCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "set <node> <spec>",
+		Short: "Change the logging specification of a node",
+		Long: "Change the logging specification of a node, e.g. 'info:gossip,msp=debug' to log the gossip\n" +
+			"and msp loggers at debug level. The change lasts until the node is restarted.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Node)
+	c.AddArg(&c.Spec)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// LogSpecSetCommand implements the node logspec set command
+type LogSpecSetCommand struct {
+	BaseCommand
+
+	Spec string
+}
+
+// Validate checks the required parameters for run
+func (c *LogSpecSetCommand) Validate() error {
+	if len(c.Spec) == 0 {
+		return errors.New("log spec not specified")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *LogSpecSetCommand) Run() error {
+	if err := c.Operations.SetLogSpec(c.Spec); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "successfully set log spec of node '%s' to '%s'\n", c.Node, c.Spec)
+
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/node"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("NodeLogSpecCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = node.NewLogSpecCommand(settings)
+	})
+
+	It("should create a node logspec command", func() {
+		Expect(cmd.Name()).To(Equal("logspec"))
+		Expect(cmd.HasSubCommands()).To(BeTrue())
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("get"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("set"))
+	})
+})
+
+var _ = Describe("NodeLogSpecGetImplementation", func() {
+	var (
+		impl       *node.LogSpecGetCommand
+		err        error
+		out        *bytes.Buffer
+		operations *mocks.Operations
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		operations = &mocks.Operations{}
+		operations.LogSpecReturns("info:gossip=debug", nil)
+
+		impl = &node.LogSpecGetCommand{}
+		impl.Node = "peer0"
+		impl.Operations = operations
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the log spec", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal("info:gossip=debug\n"))
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				operations.LogSpecReturns("", errors.New("connection refused"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("connection refused"))
+			})
+		})
+	})
+})
+
+var _ = Describe("NodeLogSpecSetImplementation", func() {
+	var (
+		impl       *node.LogSpecSetCommand
+		err        error
+		out        *bytes.Buffer
+		operations *mocks.Operations
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		operations = &mocks.Operations{}
+
+		impl = &node.LogSpecSetCommand{}
+		impl.Node = "peer0"
+		impl.Spec = "debug"
+		impl.Operations = operations
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the spec is not specified", func() {
+			BeforeEach(func() {
+				impl.Spec = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("log spec not specified"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should set the log spec", func() {
+			Expect(err).To(BeNil())
+			Expect(operations.SetLogSpecArgsForCall(0)).To(Equal("debug"))
+			Expect(fmt.Sprint(out)).To(Equal("successfully set log spec of node 'peer0' to 'debug'\n"))
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				operations.SetLogSpecReturns(errors.New("PUT /logspec failed: invalid logging specification"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(out)).To(BeEmpty())
+			})
+		})
+	})
+})
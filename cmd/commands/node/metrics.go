@@ -0,0 +1,73 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewMetricsCommand creates a new "fabric node metrics" command
+func NewMetricsCommand(settings *environment.Settings) *cobra.Command {
+	c := MetricsCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "metrics <node>",
+		Short: "Show the Prometheus metrics of a node",
+		Long:  "Show the Prometheus metrics of a node, which requires the node's metrics provider to be prometheus",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Complete()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Node)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.Filter, "filter", "", "Only show the lines that contain the given text, e.g. a metric name")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// MetricsCommand implements the node metrics command
+type MetricsCommand struct {
+	BaseCommand
+
+	Filter string
+}
+
+// Run executes the command
+func (c *MetricsCommand) Run() error {
+	metrics, err := c.Operations.Metrics()
+	if err != nil {
+		return err
+	}
+
+	if len(c.Filter) == 0 {
+		fmt.Fprint(c.Settings.Streams.Out, metrics)
+		return nil
+	}
+
+	for _, line := range strings.Split(metrics, "\n") {
+		if strings.Contains(line, c.Filter) {
+			fmt.Fprintln(c.Settings.Streams.Out, line)
+		}
+	}
+
+	return nil
+}
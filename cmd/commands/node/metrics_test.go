@@ -0,0 +1,112 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/node"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("NodeMetricsCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = node.NewMetricsCommand(settings)
+	})
+
+	It("should create a node metrics command", func() {
+		Expect(cmd.Name()).To(Equal("metrics"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+		Expect(cmd.Flag("filter")).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("NodeMetricsImplementation", func() {
+	var (
+		impl       *node.MetricsCommand
+		err        error
+		out        *bytes.Buffer
+		operations *mocks.Operations
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		operations = &mocks.Operations{}
+		operations.MetricsReturns("# TYPE ledger_blockchain_height gauge\n"+
+			"ledger_blockchain_height{channel=\"mychannel\"} 12\n"+
+			"gossip_state_height{channel=\"mychannel\"} 12\n", nil)
+
+		impl = &node.MetricsCommand{}
+		impl.Node = "peer0"
+		impl.Operations = operations
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the metrics", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(ContainSubstring("gossip_state_height"))
+			Expect(fmt.Sprint(out)).To(ContainSubstring("ledger_blockchain_height"))
+		})
+
+		Context("when a filter is set", func() {
+			BeforeEach(func() {
+				impl.Filter = "ledger_blockchain_height"
+			})
+
+			It("should print the matching lines", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(Equal("# TYPE ledger_blockchain_height gauge\n" +
+					"ledger_blockchain_height{channel=\"mychannel\"} 12\n"))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				operations.MetricsReturns("", errors.New("GET /metrics failed: 404 Not Found"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,71 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+// NewCommand creates a new "fabric node" command
+func NewCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Query the operations service of a peer or orderer",
+		Long: "Query the operations service of a peer or orderer with health|metrics|logspec|version\n\n" +
+			"The operations listeners of the nodes and the client TLS settings are taken from the network\n" +
+			"of the current context, as set by 'fabric network set --operations-node'.",
+	}
+
+	cmd.AddCommand(
+		NewHealthCommand(settings),
+		NewMetricsCommand(settings),
+		NewLogSpecCommand(settings),
+		NewVersionCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// BaseCommand implements common node command functions
+type BaseCommand struct {
+	common.Command
+
+	Operations fabric.Operations
+
+	Node string
+}
+
+// Complete initializes the operations client of the node
+func (c *BaseCommand) Complete() error {
+	if len(c.Node) == 0 {
+		return errors.New("node not specified")
+	}
+
+	if c.Operations != nil {
+		return nil
+	}
+
+	network, err := c.Settings.Config.GetCurrentContextNetwork()
+	if err != nil {
+		return err
+	}
+
+	c.Operations, err = fabric.NewOperations(network.Operations, c.Node)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/node"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+func TestNode(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Node Suite")
+}
+
+var _ = Describe("NodeCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = node.NewCommand(settings)
+	})
+
+	It("should create a node command", func() {
+		Expect(cmd.Name()).To(Equal("node"))
+		Expect(cmd.HasSubCommands()).To(BeTrue())
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("node [command]"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("health"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("metrics"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("logspec"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("version"))
+	})
+})
+
+var _ = Describe("BaseNodeCommand", func() {
+	var (
+		c   *node.BaseCommand
+		err error
+	)
+
+	BeforeEach(func() {
+		c = &node.BaseCommand{}
+		c.Node = "peer0"
+		c.Settings = &environment.Settings{
+			Config: &environment.Config{
+				Networks: map[string]*environment.Network{
+					"foo": {
+						Operations: &environment.Operations{
+							Nodes: map[string]string{"peer0": "http://localhost:9443"},
+						},
+					},
+				},
+				Contexts: map[string]*environment.Context{
+					"foo": {Network: "foo"},
+				},
+				CurrentContext: "foo",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = c.Complete()
+	})
+
+	It("should create the operations client", func() {
+		Expect(err).To(BeNil())
+		Expect(c.Operations).NotTo(BeNil())
+	})
+
+	Context("when the operations client is set", func() {
+		var operations *mocks.Operations
+
+		BeforeEach(func() {
+			operations = &mocks.Operations{}
+			c.Operations = operations
+		})
+
+		It("should keep it", func() {
+			Expect(err).To(BeNil())
+			Expect(c.Operations).To(Equal(operations))
+		})
+	})
+
+	Context("when the node is not specified", func() {
+		BeforeEach(func() {
+			c.Node = ""
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("node not specified"))
+		})
+	})
+
+	Context("when the node has no operations endpoint", func() {
+		BeforeEach(func() {
+			c.Node = "orderer0"
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("operations endpoint of node 'orderer0' not found"))
+		})
+	})
+
+	Context("when the network has no operations", func() {
+		BeforeEach(func() {
+			c.Settings.Config.Networks["foo"].Operations = nil
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("no operations endpoints are set for the network"))
+		})
+	})
+
+	Context("when there is no current context", func() {
+		BeforeEach(func() {
+			c.Settings.Config.CurrentContext = ""
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,58 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewVersionCommand creates a new "fabric node version" command
+func NewVersionCommand(settings *environment.Settings) *cobra.Command {
+	c := VersionCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "version <node>",
+		Short: "Show the version of a node",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Complete()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Node)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// VersionCommand implements the node version command
+type VersionCommand struct {
+	BaseCommand
+}
+
+// Run executes the command
+func (c *VersionCommand) Run() error {
+	version, err := c.Operations.Version()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "Version: %s\n", version.Version)
+	fmt.Fprintf(c.Settings.Streams.Out, "Commit SHA: %s\n", version.CommitSHA)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/node"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("NodeVersionCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = node.NewVersionCommand(settings)
+	})
+
+	It("should create a node version command", func() {
+		Expect(cmd.Name()).To(Equal("version"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+})
+
+var _ = Describe("NodeVersionImplementation", func() {
+	var (
+		impl       *node.VersionCommand
+		err        error
+		out        *bytes.Buffer
+		operations *mocks.Operations
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		operations = &mocks.Operations{}
+		operations.VersionReturns(&fabric.VersionInfo{Version: "2.2.1", CommitSHA: "344fda6"}, nil)
+
+		impl = &node.VersionCommand{}
+		impl.Node = "peer0"
+		impl.Operations = operations
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the version", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal("Version: 2.2.1\nCommit SHA: 344fda6\n"))
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				operations.VersionReturns(nil, errors.New("connection refused"))
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("connection refused"))
+			})
+		})
+	})
+})
@@ -0,0 +1,317 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/ledger"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/resmgmt"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+// NewListCommand creates a new "fabric peer list" command
+func NewListCommand(settings *environment.Settings) *cobra.Command {
+	c := ListCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the ledger heights of the peers by channel",
+		Long: `List the ledger heights of the peers by channel
+
+Every peer of the network config, or each peer set by --peer, is asked for the channels it has
+joined and for the ledger height of each of them. The heights are shown as a peer by channel
+table, in which a peer that is behind the highest peer of a channel is marked with its lag.
+With --watch the table is refreshed at the given interval.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVar(&c.Peers, "peer", []string{},
+		"Set a peer to list (this option may be specified multiple times, defaults to all peers of the network config)")
+	flags.DurationVar(&c.Watch, "watch", 0, "Refresh the ledger heights at the given interval, e.g. 5s")
+	flags.IntVar(&c.Count, "count", 0, "Set the number of refreshes with --watch (0 to refresh until interrupted)")
+	flags.StringVar(&c.OutputFormat, "output", "", "Set the output format, 'json' or a human-readable table if not set")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// ListCommand implements the peer list command
+type ListCommand struct {
+	BaseCommand
+
+	Peers        []string
+	Watch        time.Duration
+	Count        int
+	OutputFormat string
+
+	ledgers map[string]fabric.Ledger
+}
+
+// heightMatrix contains the ledger heights of a set of peers by channel
+type heightMatrix struct {
+	Time       time.Time         `json:"time"`
+	Channels   []string          `json:"channels"`
+	MaxHeights map[string]uint64 `json:"max_heights"`
+	Peers      []*peerHeights    `json:"peers"`
+}
+
+// peerHeights contains the ledger heights of the channels joined by a peer and their lag
+// behind the highest peer of each channel
+type peerHeights struct {
+	Peer          string            `json:"peer"`
+	Heights       map[string]uint64 `json:"heights"`
+	Lags          map[string]uint64 `json:"lags"`
+	Error         string            `json:"error,omitempty"`
+	ChannelErrors map[string]string `json:"channel_errors,omitempty"`
+}
+
+// Validate checks the required parameters for run
+func (c *ListCommand) Validate() error {
+	if c.Watch < 0 {
+		return errors.New("watch interval must not be negative")
+	}
+
+	if c.Count < 0 {
+		return errors.New("count must not be negative")
+	}
+
+	if c.Count > 0 && c.Watch == 0 {
+		return errors.New("--count requires --watch")
+	}
+
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *ListCommand) Run() error {
+	peers, err := c.peers()
+	if err != nil {
+		return err
+	}
+
+	if len(peers) == 0 {
+		return errors.New("no peers found in the network config")
+	}
+
+	for i := 1; ; i++ {
+		matrix := c.query(peers)
+
+		if err := c.print(matrix); err != nil {
+			return err
+		}
+
+		if c.Watch == 0 {
+			return matrix.err()
+		}
+
+		if c.Count > 0 && i >= c.Count {
+			return nil
+		}
+
+		time.Sleep(c.Watch)
+	}
+}
+
+// peers returns the peers given by --peer, falling back to all peers of the network config
+func (c *ListCommand) peers() ([]string, error) {
+	if len(c.Peers) > 0 {
+		return c.Peers, nil
+	}
+
+	sdk, err := c.Factory.SDK()
+	if err != nil {
+		return nil, err
+	}
+
+	return fabric.AllPeers(sdk)
+}
+
+// query collects the ledger heights of the channels joined by each peer
+func (c *ListCommand) query(peers []string) *heightMatrix {
+	matrix := &heightMatrix{
+		Time:       time.Now().UTC(),
+		Channels:   []string{},
+		MaxHeights: make(map[string]uint64),
+	}
+
+	for _, peer := range peers {
+		heights := &peerHeights{
+			Peer:          peer,
+			Heights:       make(map[string]uint64),
+			Lags:          make(map[string]uint64),
+			ChannelErrors: make(map[string]string),
+		}
+
+		matrix.Peers = append(matrix.Peers, heights)
+
+		channels, err := c.ResourceManagement.QueryChannels(
+			resmgmt.WithRetry(retry.DefaultResMgmtOpts),
+			resmgmt.WithTargetEndpoints(peer),
+		)
+		if err != nil {
+			heights.Error = err.Error()
+			continue
+		}
+
+		for _, ch := range channels.GetChannels() {
+			channelID := ch.GetChannelId()
+
+			if _, ok := matrix.MaxHeights[channelID]; !ok {
+				matrix.MaxHeights[channelID] = 0
+				matrix.Channels = append(matrix.Channels, channelID)
+			}
+
+			height, err := c.height(channelID, peer)
+			if err != nil {
+				heights.ChannelErrors[channelID] = err.Error()
+				continue
+			}
+
+			heights.Heights[channelID] = height
+
+			if height > matrix.MaxHeights[channelID] {
+				matrix.MaxHeights[channelID] = height
+			}
+		}
+	}
+
+	sort.Strings(matrix.Channels)
+
+	for _, heights := range matrix.Peers {
+		for channelID, height := range heights.Heights {
+			heights.Lags[channelID] = matrix.MaxHeights[channelID] - height
+		}
+	}
+
+	return matrix
+}
+
+// height queries the ledger height of a channel on a peer
+func (c *ListCommand) height(channelID string, peer string) (uint64, error) {
+	if c.ledgers == nil {
+		c.ledgers = make(map[string]fabric.Ledger)
+	}
+
+	client, ok := c.ledgers[channelID]
+	if !ok {
+		var err error
+		client, err = c.Factory.ChannelLedger(channelID)
+		if err != nil {
+			return 0, err
+		}
+
+		c.ledgers[channelID] = client
+	}
+
+	info, err := client.QueryInfo(ledger.WithTargetEndpoints(peer))
+	if err != nil {
+		return 0, err
+	}
+
+	return info.BCI.GetHeight(), nil
+}
+
+func (c *ListCommand) print(matrix *heightMatrix) error {
+	out := c.Settings.Streams.Out
+
+	if c.OutputFormat == jsonFormat {
+		return json.NewEncoder(out).Encode(matrix)
+	}
+
+	if c.Watch > 0 {
+		fmt.Fprintf(out, "%s\n", matrix.Time.Format(time.RFC3339))
+	}
+
+	w := tabwriter.NewWriter(out, 4, 4, 4, ' ', 0)
+
+	fmt.Fprintf(w, "PEER\t%s\n", strings.Join(matrix.Channels, "\t"))
+
+	for _, heights := range matrix.Peers {
+		cells := make([]string, len(matrix.Channels))
+		for i, channelID := range matrix.Channels {
+			height, ok := heights.Heights[channelID]
+			switch {
+			case ok && heights.Lags[channelID] > 0:
+				cells[i] = fmt.Sprintf("%d (-%d)", height, heights.Lags[channelID])
+			case ok:
+				cells[i] = fmt.Sprint(height)
+			case heights.Error != "" || heights.ChannelErrors[channelID] != "":
+				cells[i] = "?"
+			default:
+				cells[i] = "-"
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", heights.Peer, strings.Join(cells, "\t"))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, heights := range matrix.Peers {
+		if heights.Error != "" {
+			fmt.Fprintf(out, "Failed to query peer %s: %s\n", heights.Peer, heights.Error)
+		}
+
+		for _, channelID := range matrix.Channels {
+			if msg, ok := heights.ChannelErrors[channelID]; ok {
+				fmt.Fprintf(out, "Failed to query channel %s on peer %s: %s\n", channelID, heights.Peer, msg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// err returns an error if any of the peers or channels could not be queried
+func (m *heightMatrix) err() error {
+	failed := 0
+	for _, heights := range m.Peers {
+		if heights.Error != "" || len(heights.ChannelErrors) > 0 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to query %d of %d peers", failed, len(m.Peers))
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/peer"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+func blockchainInfo(height uint64) *fab.BlockchainInfoResponse {
+	return &fab.BlockchainInfoResponse{BCI: &cb.BlockchainInfo{Height: height}}
+}
+
+func channelQueryResponse(channels ...string) *pb.ChannelQueryResponse {
+	resp := &pb.ChannelQueryResponse{}
+	for _, ch := range channels {
+		resp.Channels = append(resp.Channels, &pb.ChannelInfo{ChannelId: ch})
+	}
+
+	return resp
+}
+
+var _ = Describe("PeerListCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = peer.NewListCommand(settings)
+	})
+
+	It("should create a peer list command", func() {
+		Expect(cmd.Name()).To(Equal("list"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+		Expect(cmd.Flag("peer")).NotTo(BeNil())
+		Expect(cmd.Flag("watch")).NotTo(BeNil())
+		Expect(cmd.Flag("count")).NotTo(BeNil())
+		Expect(cmd.Flag("output")).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("PeerListImplementation", func() {
+	var (
+		impl     *peer.ListCommand
+		err      error
+		out      *bytes.Buffer
+		factory  *mocks.Factory
+		resmgmt  *mocks.ResourceManagement
+		ledger1  *mocks.Ledger
+		ledger2  *mocks.Ledger
+		channels map[string]*mocks.Ledger
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		factory = &mocks.Factory{}
+		resmgmt = &mocks.ResourceManagement{}
+		ledger1 = &mocks.Ledger{}
+		ledger2 = &mocks.Ledger{}
+		channels = map[string]*mocks.Ledger{"channel1": ledger1, "channel2": ledger2}
+
+		factory.ChannelLedgerCalls(func(channelID string) (fabric.Ledger, error) {
+			return channels[channelID], nil
+		})
+
+		resmgmt.QueryChannelsReturnsOnCall(0, channelQueryResponse("channel2", "channel1"), nil)
+		resmgmt.QueryChannelsReturnsOnCall(1, channelQueryResponse("channel1"), nil)
+
+		ledger1.QueryInfoReturnsOnCall(0, blockchainInfo(12), nil)
+		ledger1.QueryInfoReturnsOnCall(1, blockchainInfo(10), nil)
+		ledger2.QueryInfoReturns(blockchainInfo(5), nil)
+
+		impl = &peer.ListCommand{}
+		impl.Factory = factory
+		impl.ResourceManagement = resmgmt
+		impl.Peers = []string{"peer0", "peer1"}
+		impl.Settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	It("should not be nil", func() {
+		Expect(impl).ShouldNot(BeNil())
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with defaults", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "yaml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'yaml'"))
+			})
+		})
+
+		Context("when count is set without watch", func() {
+			BeforeEach(func() {
+				impl.Count = 2
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("--count requires --watch"))
+			})
+		})
+
+		Context("when the watch interval is negative", func() {
+			BeforeEach(func() {
+				impl.Watch = -time.Second
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the heights with the lag of each peer", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal(
+				"PEER     channel1    channel2\n" +
+					"peer0    12          5\n" +
+					"peer1    10 (-2)     -\n"))
+			Expect(factory.ChannelLedgerCallCount()).To(Equal(2))
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "json"
+			})
+
+			It("should print the heights as json", func() {
+				Expect(err).To(BeNil())
+
+				var matrix struct {
+					Channels   []string          `json:"channels"`
+					MaxHeights map[string]uint64 `json:"max_heights"`
+					Peers      []struct {
+						Peer    string            `json:"peer"`
+						Heights map[string]uint64 `json:"heights"`
+						Lags    map[string]uint64 `json:"lags"`
+					} `json:"peers"`
+				}
+				Expect(json.Unmarshal(out.Bytes(), &matrix)).To(Succeed())
+				Expect(matrix.Channels).To(Equal([]string{"channel1", "channel2"}))
+				Expect(matrix.MaxHeights).To(Equal(map[string]uint64{"channel1": 12, "channel2": 5}))
+				Expect(matrix.Peers).To(HaveLen(2))
+				Expect(matrix.Peers[1].Peer).To(Equal("peer1"))
+				Expect(matrix.Peers[1].Heights).To(Equal(map[string]uint64{"channel1": 10}))
+				Expect(matrix.Peers[1].Lags).To(Equal(map[string]uint64{"channel1": 2}))
+			})
+		})
+
+		Context("when a peer cannot be queried", func() {
+			BeforeEach(func() {
+				resmgmt.QueryChannelsReturnsOnCall(1, nil, errors.New("connection refused"))
+			})
+
+			It("should print the error and fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("failed to query 1 of 2 peers"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("peer1    ?"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Failed to query peer peer1: connection refused"))
+			})
+		})
+
+		Context("when a channel ledger cannot be queried", func() {
+			BeforeEach(func() {
+				ledger2.QueryInfoReturns(nil, errors.New("access denied"))
+			})
+
+			It("should print the error and fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("failed to query 1 of 2 peers"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Failed to query channel channel2 on peer peer0: access denied"))
+			})
+		})
+
+		Context("when watching", func() {
+			BeforeEach(func() {
+				impl.Watch = time.Millisecond
+				impl.Count = 2
+			})
+
+			It("should refresh the heights", func() {
+				Expect(err).To(BeNil())
+				Expect(resmgmt.QueryChannelsCallCount()).To(Equal(4))
+				Expect(factory.ChannelLedgerCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when no peer is given", func() {
+			BeforeEach(func() {
+				impl.Peers = nil
+				factory.SDKReturns(nil, errors.New("sdk error"))
+			})
+
+			It("should fail to list the network config peers", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("sdk error"))
+			})
+		})
+	})
+})
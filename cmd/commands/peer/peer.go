@@ -0,0 +1,61 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+const jsonFormat = "json"
+
+// NewCommand creates a new "fabric peer" command
+func NewCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "peer",
+		Short: "Inspect the peers of the network",
+		Long:  "Inspect the peers of the network with list",
+	}
+
+	cmd.AddCommand(
+		NewListCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// BaseCommand implements common peer command functions
+type BaseCommand struct {
+	common.Command
+
+	Factory            fabric.Factory
+	ResourceManagement fabric.ResourceManagement
+}
+
+// Complete initializes all clients needed for Run
+func (c *BaseCommand) Complete() error {
+	var err error
+
+	if c.Factory == nil {
+		c.Factory, err = fabric.NewFactory(c.Settings.Config)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.ResourceManagement, err = c.Factory.ResourceManagement()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/peer"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+func TestPeer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Peer Suite")
+}
+
+var _ = Describe("PeerCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = peer.NewCommand(settings)
+	})
+
+	It("should create a peer command", func() {
+		Expect(cmd.Name()).To(Equal("peer"))
+		Expect(cmd.HasSubCommands()).To(BeTrue())
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("peer [command]"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("list"))
+	})
+})
+
+var _ = Describe("BasePeerCommand", func() {
+	var (
+		c       *peer.BaseCommand
+		factory *mocks.Factory
+		err     error
+	)
+
+	BeforeEach(func() {
+		factory = &mocks.Factory{}
+
+		c = &peer.BaseCommand{}
+		c.Factory = factory
+	})
+
+	JustBeforeEach(func() {
+		err = c.Complete()
+	})
+
+	It("should create the resource management client", func() {
+		Expect(err).To(BeNil())
+		Expect(factory.ResourceManagementCallCount()).To(Equal(1))
+	})
+
+	Context("when factory fails to create the resource management client", func() {
+		BeforeEach(func() {
+			factory.ResourceManagementReturns(nil, errors.New("factory error"))
+		})
+
+		It("should fail with factory error", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("factory error"))
+		})
+	})
+})
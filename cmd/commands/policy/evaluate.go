@@ -0,0 +1,258 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewEvaluateCommand creates a new "fabric policy evaluate" command
+func NewEvaluateCommand(settings *environment.Settings) *cobra.Command {
+	c := EvaluateCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "evaluate <policy>",
+		Short: "Evaluate a signature policy against a set of signers",
+		Long: "Evaluate a signature policy against a set of signers given as MSP ID and role, e.g.\n" +
+			"--signer Org1MSP.member --signer Org2MSP.peer. Like the peer, each signer satisfies at most\n" +
+			"one principal of the policy and a member principal is satisfied by any role of its MSP.\n" +
+			"The command fails when the policy is not satisfied.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Validate()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Policy)
+
+	flags := cmd.Flags()
+	flags.StringArrayVar(&c.Signers, "signer", []string{}, "add a signer as MSP.role, where role is member, admin, client, peer or orderer")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// EvaluateCommand implements the policy evaluate command
+type EvaluateCommand struct {
+	BaseCommand
+
+	Policy  string
+	Signers []string
+
+	signers []*msp.MSPRole
+}
+
+// Validate checks the required parameters for run
+func (c *EvaluateCommand) Validate() error {
+	if len(c.Policy) == 0 {
+		return errors.New("policy not specified")
+	}
+
+	c.signers = nil
+	for _, s := range c.Signers {
+		signer, err := parseSigner(s)
+		if err != nil {
+			return err
+		}
+
+		c.signers = append(c.signers, signer)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *EvaluateCommand) Run() error {
+	policy, err := parsePolicy(c.Policy)
+	if err != nil {
+		return err
+	}
+
+	dsl, err := common.GetPolicyString(policy)
+	if err != nil {
+		return err
+	}
+
+	e := &evaluator{identities: policy.Identities, signers: c.signers}
+
+	result, err := e.evaluate(policy.Rule, make([]bool, len(c.signers)))
+	if err != nil {
+		return err
+	}
+
+	out := c.Settings.Streams.Out
+
+	fmt.Fprintf(out, "Policy: %s\n", dsl)
+	if result.satisfied {
+		fmt.Fprintln(out, "Result: satisfied")
+	} else {
+		fmt.Fprintln(out, "Result: not satisfied")
+	}
+
+	result.print(out, 1)
+
+	if !result.satisfied {
+		return errors.New("policy not satisfied")
+	}
+
+	return nil
+}
+
+// parseSigner parses a signer in the "MSP.role" notation, where the MSP ID may contain dots
+func parseSigner(s string) (*msp.MSPRole, error) {
+	i := strings.LastIndex(s, ".")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid signer '%s', expected MSP.role", s)
+	}
+
+	var roleType msp.MSPRole_MSPRoleType
+	switch s[i+1:] {
+	case "member":
+		roleType = msp.MSPRole_MEMBER
+	case "admin":
+		roleType = msp.MSPRole_ADMIN
+	case "client":
+		roleType = msp.MSPRole_CLIENT
+	case "peer":
+		roleType = msp.MSPRole_PEER
+	case "orderer":
+		roleType = msp.MSPRole_ORDERER
+	default:
+		return nil, fmt.Errorf("invalid role '%s' of signer '%s', expected member, admin, client, peer or orderer", s[i+1:], s)
+	}
+
+	return &msp.MSPRole{MspIdentifier: s[:i], Role: roleType}, nil
+}
+
+// evaluator evaluates signature policy rules the way the peer does, where a signer that
+// satisfied a principal is used up for the rest of the evaluation
+type evaluator struct {
+	identities []*msp.MSPPrincipal
+	signers    []*msp.MSPRole
+}
+
+// evaluation is the result of a rule with the reason it is or is not satisfied
+type evaluation struct {
+	rule      string
+	satisfied bool
+	reason    string
+	children  []*evaluation
+}
+
+func (e *evaluator) evaluate(rule *cb.SignaturePolicy, used []bool) (*evaluation, error) {
+	switch t := rule.GetType().(type) {
+	case *cb.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(e.identities) {
+			return nil, fmt.Errorf("signature policy references unknown identity %d", t.SignedBy)
+		}
+
+		principal, err := common.PrincipalRole(e.identities[t.SignedBy])
+		if err != nil {
+			return nil, err
+		}
+
+		return e.evaluateSignedBy(principal, used), nil
+	case *cb.SignaturePolicy_NOutOf_:
+		result := &evaluation{rule: common.NOutOfOperator(t.NOutOf)}
+		if result.rule == "OutOf" {
+			result.rule = fmt.Sprintf("OutOf(%d)", t.NOutOf.N)
+		}
+
+		verified := 0
+		ruleUsed := make([]bool, len(used))
+		for _, r := range t.NOutOf.Rules {
+			copy(ruleUsed, used)
+
+			child, err := e.evaluate(r, ruleUsed)
+			if err != nil {
+				return nil, err
+			}
+
+			if child.satisfied {
+				verified++
+				copy(used, ruleUsed)
+			}
+
+			result.children = append(result.children, child)
+		}
+
+		result.satisfied = verified >= int(t.NOutOf.N)
+		result.reason = fmt.Sprintf("%d of %d rules satisfied, %d required", verified, len(t.NOutOf.Rules), t.NOutOf.N)
+
+		return result, nil
+	default:
+		return nil, errors.New("unsupported signature policy rule")
+	}
+}
+
+func (e *evaluator) evaluateSignedBy(principal *msp.MSPRole, used []bool) *evaluation {
+	result := &evaluation{rule: "'" + common.RoleString(principal) + "'"}
+
+	var usedSigners []string
+	for i, signer := range e.signers {
+		if !satisfies(signer, principal) {
+			continue
+		}
+
+		if used[i] {
+			usedSigners = append(usedSigners, common.RoleString(signer))
+			continue
+		}
+
+		used[i] = true
+		result.satisfied = true
+		result.reason = "signed by " + common.RoleString(signer)
+
+		return result
+	}
+
+	if len(usedSigners) > 0 {
+		result.reason = fmt.Sprintf("matching signer %s already used by another principal", strings.Join(usedSigners, ", "))
+	} else {
+		result.reason = "no matching signer"
+	}
+
+	return result
+}
+
+// satisfies returns true if a signer satisfies a principal, where any role satisfies a member
+func satisfies(signer *msp.MSPRole, principal *msp.MSPRole) bool {
+	if signer.MspIdentifier != principal.MspIdentifier {
+		return false
+	}
+
+	return principal.Role == msp.MSPRole_MEMBER || principal.Role == signer.Role
+}
+
+func (r *evaluation) print(out io.Writer, depth int) {
+	status := "not satisfied"
+	if r.satisfied {
+		status = "satisfied"
+	}
+
+	fmt.Fprintf(out, "%s%s: %s (%s)\n", strings.Repeat("  ", depth), r.rule, status, r.reason)
+
+	for _, child := range r.children {
+		child.print(out, depth+1)
+	}
+}
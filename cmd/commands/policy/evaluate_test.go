@@ -0,0 +1,219 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/policy"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+var _ = Describe("PolicyEvaluateCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = policy.NewEvaluateCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a policy evaluate command", func() {
+		Expect(cmd.Name()).To(Equal("evaluate"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("evaluate <policy>"))
+	})
+})
+
+var _ = Describe("PolicyEvaluateImplementation", func() {
+	var (
+		impl     *policy.EvaluateCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		impl = &policy.EvaluateCommand{}
+		impl.Settings = settings
+		impl.Policy = "AND('Org1MSP.member', 'Org2MSP.peer')"
+		impl.Signers = []string{"Org1MSP.admin", "Org2MSP.peer"}
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the policy is not set", func() {
+			BeforeEach(func() {
+				impl.Policy = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("policy not specified"))
+			})
+		})
+
+		Context("when a signer has no role", func() {
+			BeforeEach(func() {
+				impl.Signers = []string{"Org1MSP"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid signer 'Org1MSP', expected MSP.role"))
+			})
+		})
+
+		Context("when a signer has an invalid role", func() {
+			BeforeEach(func() {
+				impl.Signers = []string{"Org1MSP.user"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid role 'user' of signer 'Org1MSP.user', expected member, admin, client, peer or orderer"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+			Expect(err).To(BeNil())
+
+			err = impl.Run()
+		})
+
+		It("should report a satisfied policy", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(Equal(
+				"Policy: AND('Org1MSP.member', 'Org2MSP.peer')\n" +
+					"Result: satisfied\n" +
+					"  AND: satisfied (2 of 2 rules satisfied, 2 required)\n" +
+					"    'Org1MSP.member': satisfied (signed by Org1MSP.admin)\n" +
+					"    'Org2MSP.peer': satisfied (signed by Org2MSP.peer)\n"))
+		})
+
+		Context("when a signer does not have the required role", func() {
+			BeforeEach(func() {
+				impl.Signers = []string{"Org1MSP.member", "Org2MSP.client"}
+			})
+
+			It("should report why the policy is not satisfied", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("policy not satisfied"))
+				Expect(fmt.Sprint(out)).To(Equal(
+					"Policy: AND('Org1MSP.member', 'Org2MSP.peer')\n" +
+						"Result: not satisfied\n" +
+						"  AND: not satisfied (1 of 2 rules satisfied, 2 required)\n" +
+						"    'Org1MSP.member': satisfied (signed by Org1MSP.member)\n" +
+						"    'Org2MSP.peer': not satisfied (no matching signer)\n"))
+			})
+		})
+
+		Context("when a signer is needed for more than one principal", func() {
+			BeforeEach(func() {
+				impl.Policy = "AND('Org1MSP.member', 'Org1MSP.admin')"
+				impl.Signers = []string{"Org1MSP.admin"}
+			})
+
+			It("should use each signer only once", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("policy not satisfied"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Result: not satisfied\n"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring(
+					"    'Org1MSP.admin': not satisfied (matching signer Org1MSP.admin already used by another principal)\n"))
+			})
+		})
+
+		Context("when an out of policy is satisfied by enough signers", func() {
+			BeforeEach(func() {
+				impl.Policy = "OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')"
+				impl.Signers = []string{"Org3MSP.peer", "Org1MSP.peer"}
+			})
+
+			It("should report a satisfied policy", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Result: satisfied\n"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("  OutOf(2): satisfied (2 of 3 rules satisfied, 2 required)\n"))
+			})
+		})
+
+		Context("when an MSP ID contains dots", func() {
+			BeforeEach(func() {
+				impl.Policy = "OR('MSP.WITH.DOTS.member')"
+				impl.Signers = []string{"MSP.WITH.DOTS.peer"}
+			})
+
+			It("should match the signer", func() {
+				Expect(err).To(BeNil())
+				Expect(fmt.Sprint(out)).To(ContainSubstring("'MSP.WITH.DOTS.member': satisfied (signed by MSP.WITH.DOTS.peer)"))
+			})
+		})
+
+		Context("when there are no signers", func() {
+			BeforeEach(func() {
+				impl.Signers = nil
+			})
+
+			It("should report a policy which is not satisfied", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("policy not satisfied"))
+				Expect(fmt.Sprint(out)).To(ContainSubstring("Result: not satisfied\n"))
+			})
+		})
+	})
+})
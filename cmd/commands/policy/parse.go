@@ -0,0 +1,169 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+const jsonFormat = "json"
+
+// NewParseCommand creates a new "fabric policy parse" command
+func NewParseCommand(settings *environment.Settings) *cobra.Command {
+	c := ParseCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "parse <policy>",
+		Short: "Parse a signature policy",
+		Long:  "Parse a signature policy and print it as normalized DSL and as a SignaturePolicyEnvelope in JSON",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Validate()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Policy)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.OutputFormat, "output", "", "set the output format, 'json' for the envelope only or both DSL and JSON if not set")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// ParseCommand implements the policy parse command
+type ParseCommand struct {
+	BaseCommand
+
+	Policy       string
+	OutputFormat string
+}
+
+// envelopeJSON is a SignaturePolicyEnvelope with decoded principals
+type envelopeJSON struct {
+	Version    int32            `json:"version"`
+	Rule       *ruleJSON        `json:"rule"`
+	Identities []*principalJSON `json:"identities"`
+}
+
+type ruleJSON struct {
+	SignedBy *int32      `json:"signed_by,omitempty"`
+	NOutOf   *nOutOfJSON `json:"n_out_of,omitempty"`
+}
+
+type nOutOfJSON struct {
+	N     int32       `json:"n"`
+	Rules []*ruleJSON `json:"rules"`
+}
+
+type principalJSON struct {
+	PrincipalClassification string    `json:"principal_classification"`
+	Principal               *roleJSON `json:"principal"`
+}
+
+type roleJSON struct {
+	MSPIdentifier string `json:"msp_identifier"`
+	Role          string `json:"role"`
+}
+
+// Validate checks the required parameters for run
+func (c *ParseCommand) Validate() error {
+	if len(c.Policy) == 0 {
+		return errors.New("policy not specified")
+	}
+
+	if c.OutputFormat != "" && c.OutputFormat != jsonFormat {
+		return fmt.Errorf("invalid output format '%s'", c.OutputFormat)
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *ParseCommand) Run() error {
+	policy, err := parsePolicy(c.Policy)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := newEnvelopeJSON(policy)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if c.OutputFormat != jsonFormat {
+		dsl, err := common.GetPolicyString(policy)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.Settings.Streams.Out, "Policy: %s\n", dsl)
+	}
+
+	fmt.Fprintln(c.Settings.Streams.Out, string(data))
+
+	return nil
+}
+
+func newEnvelopeJSON(policy *cb.SignaturePolicyEnvelope) (*envelopeJSON, error) {
+	envelope := &envelopeJSON{
+		Version: policy.Version,
+		Rule:    newRuleJSON(policy.Rule),
+	}
+
+	for _, principal := range policy.Identities {
+		r, err := common.PrincipalRole(principal)
+		if err != nil {
+			return nil, err
+		}
+
+		envelope.Identities = append(envelope.Identities, &principalJSON{
+			PrincipalClassification: principal.PrincipalClassification.String(),
+			Principal: &roleJSON{
+				MSPIdentifier: r.MspIdentifier,
+				Role:          r.Role.String(),
+			},
+		})
+	}
+
+	return envelope, nil
+}
+
+func newRuleJSON(rule *cb.SignaturePolicy) *ruleJSON {
+	switch t := rule.GetType().(type) {
+	case *cb.SignaturePolicy_SignedBy:
+		return &ruleJSON{SignedBy: &t.SignedBy}
+	case *cb.SignaturePolicy_NOutOf_:
+		n := &nOutOfJSON{N: t.NOutOf.N, Rules: []*ruleJSON{}}
+		for _, r := range t.NOutOf.Rules {
+			n.Rules = append(n.Rules, newRuleJSON(r))
+		}
+
+		return &ruleJSON{NOutOf: n}
+	default:
+		return nil
+	}
+}
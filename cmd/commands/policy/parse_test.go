@@ -0,0 +1,174 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/policy"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+var _ = Describe("PolicyParseCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = policy.NewParseCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a policy parse command", func() {
+		Expect(cmd.Name()).To(Equal("parse"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("parse <policy>"))
+	})
+})
+
+var _ = Describe("PolicyParseImplementation", func() {
+	const envelope = `{
+		"version": 0,
+		"rule": {
+			"n_out_of": {
+				"n": 2,
+				"rules": [
+					{"signed_by": 2},
+					{"n_out_of": {"n": 1, "rules": [{"signed_by": 0}, {"signed_by": 1}]}}
+				]
+			}
+		},
+		"identities": [
+			{"principal_classification": "ROLE", "principal": {"msp_identifier": "Org2MSP", "role": "PEER"}},
+			{"principal_classification": "ROLE", "principal": {"msp_identifier": "Org3MSP", "role": "ADMIN"}},
+			{"principal_classification": "ROLE", "principal": {"msp_identifier": "Org1MSP", "role": "MEMBER"}}
+		]
+	}`
+
+	var (
+		impl     *policy.ParseCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		impl = &policy.ParseCommand{}
+		impl.Settings = settings
+		impl.Policy = "and('Org1MSP.member', or('Org2MSP.peer', 'Org3MSP.admin'))"
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should succeed with all arguments", func() {
+			Expect(err).To(BeNil())
+		})
+
+		Context("when the policy is not set", func() {
+			BeforeEach(func() {
+				impl.Policy = ""
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("policy not specified"))
+			})
+		})
+
+		Context("when the output format is invalid", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "yaml"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("invalid output format 'yaml'"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should print the normalized policy and the envelope", func() {
+			Expect(err).To(BeNil())
+
+			lines := strings.SplitN(out.String(), "\n", 2)
+			Expect(lines[0]).To(Equal("Policy: AND('Org1MSP.member', OR('Org2MSP.peer', 'Org3MSP.admin'))"))
+			Expect(lines[1]).To(MatchJSON(envelope))
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				impl.OutputFormat = "json"
+			})
+
+			It("should print the envelope only", func() {
+				Expect(err).To(BeNil())
+				Expect(out.String()).To(MatchJSON(envelope))
+			})
+		})
+
+		Context("when the policy is invalid", func() {
+			BeforeEach(func() {
+				impl.Policy = "AND('Org1MSP.member',"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(HavePrefix("invalid policy: "))
+			})
+		})
+	})
+})
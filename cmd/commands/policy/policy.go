@@ -0,0 +1,59 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/policydsl"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewCommand creates a new "fabric policy" command
+func NewCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Parse and evaluate signature policies",
+		Long: "Parse and evaluate signature policies offline with parse|evaluate\n\n" +
+			"Policies are written in the DSL of endorsement and collection policies, e.g.\n" +
+			"\"AND('Org1MSP.member', OR('Org2MSP.peer', 'Org3MSP.peer'))\".",
+	}
+
+	cmd.AddCommand(
+		NewParseCommand(settings),
+		NewEvaluateCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// BaseCommand implements common policy command functions
+type BaseCommand struct {
+	common.Command
+}
+
+// parsePolicy parses the given policy DSL into a signature policy envelope
+func parsePolicy(dsl string) (*cb.SignaturePolicyEnvelope, error) {
+	if len(strings.TrimSpace(dsl)) == 0 {
+		return nil, errors.New("policy not specified")
+	}
+
+	policy, err := policydsl.FromString(dsl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy: %s", strings.TrimSpace(err.Error()))
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewProposalCreateCommand creates a new "fabric tx proposal create" command
+func NewProposalCreateCommand(settings *environment.Settings) *cobra.Command {
+	c := ProposalCreateCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "create <chaincode-name>",
+		Short: "Create an unsigned transaction proposal",
+		Long:  "Create an unsigned transaction proposal file which can be signed offline with 'fabric tx sign'",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return c.Validate()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.ChaincodeName)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.Channel, "channel", "", "set the channel (defaults to the channel of the current context)")
+	flags.StringVar(&c.ChaincodeFcn, "fcn", "", "set the invoke function")
+	flags.StringArrayVar(&c.ChaincodeArgs, "args", []string{}, "set the invoke arguments")
+	flags.StringVarP(&c.Ctor, "ctor", "c", "", "set the invoke function and arguments as peer CLI JSON, e.g. {\"Args\":[\"fcn\",\"arg1\"]}")
+	flags.StringVar(&c.ArgsFile, "args-file", "", "set the path to a JSON file containing an array of invoke arguments or a constructor")
+	flags.StringVar(&c.ArgEncoding, "arg-encoding", common.ArgEncodingUTF8, common.ArgEncodingUsage)
+	flags.BoolVar(&c.IsInit, "is-init", false, "indicates whether or not this invocation is meant to initialize the chaincode")
+	flags.StringArrayVar(&c.Transient, "transient", []string{}, "set a transient data entry as key=value (this option may be specified multiple times)")
+	flags.StringVar(&c.TransientFile, "transient-file", "", "set the path to a JSON file containing an object of transient data values")
+	flags.StringVar(&c.TransientEncoding, "transient-encoding", common.TransientEncodingUTF8,
+		"set the encoding of the transient data values (utf8, base64 or hex)")
+	flags.StringVar(&c.Output, "output", "", "set the path of the transaction file to write")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// ProposalCreateCommand implements the tx proposal create command
+type ProposalCreateCommand struct {
+	BaseCommand
+
+	ChaincodeName string
+	Channel       string
+
+	ChaincodeFcn  string
+	ChaincodeArgs []string
+	Ctor          string
+	ArgsFile      string
+	ArgEncoding   string
+	IsInit        bool
+
+	Transient         []string
+	TransientFile     string
+	TransientEncoding string
+
+	Output string
+}
+
+// Validate checks the required parameters for run
+func (c *ProposalCreateCommand) Validate() error {
+	if len(c.ChaincodeName) == 0 {
+		return errors.New("chaincode name not specified")
+	}
+
+	if len(c.Output) == 0 {
+		return errors.New("output file not specified")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *ProposalCreateCommand) Run() error {
+	channel := c.Channel
+	if channel == "" {
+		context, err := c.Settings.Config.GetCurrentContext()
+		if err != nil {
+			return err
+		}
+
+		channel = context.Channel
+	}
+
+	if channel == "" {
+		return errors.New("channel not specified")
+	}
+
+	fcn, args, err := common.GetChaincodeArgs(c.ChaincodeFcn, c.ChaincodeArgs, c.Ctor, c.ArgsFile, c.ArgEncoding)
+	if err != nil {
+		return err
+	}
+
+	if c.IsInit {
+		fcn = "Init"
+	}
+
+	transientMap, err := common.GetTransientMap(c.Transient, c.TransientFile, c.TransientEncoding)
+	if err != nil {
+		return err
+	}
+
+	f := &transactionFile{
+		Channel:   channel,
+		Chaincode: c.ChaincodeName,
+		Function:  fcn,
+		Args:      args,
+		Transient: transientMap,
+		IsInit:    c.IsInit,
+	}
+
+	if err := writeTransactionFile(c.Output, f); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Settings.Streams.Out, "successfully created proposal for chaincode '%s' in '%s'\n", c.ChaincodeName, c.Output)
+
+	return nil
+}
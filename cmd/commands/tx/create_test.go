@@ -0,0 +1,193 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/tx"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+var _ = Describe("TxProposalCreateCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = tx.NewProposalCreateCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a tx proposal create command", func() {
+		Expect(cmd.Name()).To(Equal("create"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("create <chaincode-name>"))
+	})
+})
+
+var _ = Describe("TxProposalCreateImplementation", func() {
+	var (
+		impl     *tx.ProposalCreateCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		dir      string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {
+						Channel: "mychannel",
+					},
+				},
+				CurrentContext: "foo",
+			},
+		}
+
+		dir, err = ioutil.TempDir("", "tx")
+		Expect(err).To(BeNil())
+
+		impl = &tx.ProposalCreateCommand{}
+		impl.Settings = settings
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should not be nil", func() {
+		Expect(impl).ShouldNot(BeNil())
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should fail when name is not set", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("chaincode name not specified"))
+		})
+
+		Context("when output is not set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("output file not specified"))
+			})
+		})
+
+		Context("when all arguments are set", func() {
+			BeforeEach(func() {
+				impl.ChaincodeName = "mycc"
+				impl.Output = "tx.json"
+			})
+
+			It("should succeed", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			impl.ChaincodeName = "mycc"
+			impl.ChaincodeFcn = "move"
+			impl.ChaincodeArgs = []string{"a", "hex:62"}
+			impl.ArgEncoding = "prefixed"
+			impl.Output = filepath.Join(dir, "tx.json")
+		})
+
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		It("should write an unsigned proposal", func() {
+			Expect(err).To(BeNil())
+			Expect(fmt.Sprint(out)).To(ContainSubstring("successfully created proposal for chaincode 'mycc'"))
+
+			data, err := ioutil.ReadFile(impl.Output)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(MatchJSON(`{
+				"channel": "mychannel",
+				"chaincode": "mycc",
+				"function": "move",
+				"args": ["YQ==", "Yg=="]
+			}`))
+		})
+
+		Context("when the channel is set", func() {
+			BeforeEach(func() {
+				impl.Channel = "other"
+			})
+
+			It("should use the channel", func() {
+				Expect(err).To(BeNil())
+
+				data, err := ioutil.ReadFile(impl.Output)
+				Expect(err).To(BeNil())
+				Expect(string(data)).To(ContainSubstring(`"channel": "other"`))
+			})
+		})
+
+		Context("when the arguments are invalid", func() {
+			BeforeEach(func() {
+				impl.ChaincodeArgs = []string{"b64:!"}
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,185 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewEndorseCommand creates a new "fabric tx endorse" command
+func NewEndorseCommand(settings *environment.Settings) *cobra.Command {
+	c := EndorseCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "endorse <transaction-file>",
+		Short: "Endorse a signed transaction proposal",
+		Long: "Send the signed proposal of a transaction file to peers and add their endorsements to the file.\n" +
+			"Endorsements may be collected with several invocations.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Path)
+
+	flags := cmd.Flags()
+	flags.StringArrayVar(&c.Peers, "peer", []string{},
+		"set an endorsing peer (this option may be specified multiple times, defaults to the peers of the current context)")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// EndorseCommand implements the tx endorse command
+type EndorseCommand struct {
+	BaseCommand
+
+	Path  string
+	Peers []string
+}
+
+// Validate checks the required parameters for run
+func (c *EndorseCommand) Validate() error {
+	if len(c.Path) == 0 {
+		return errors.New("transaction file not specified")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *EndorseCommand) Run() error {
+	f, err := readTransactionFile(c.Path)
+	if err != nil {
+		return err
+	}
+
+	if len(f.Proposal) == 0 {
+		return errors.New("proposal is not signed, sign it with 'fabric tx sign'")
+	}
+
+	if len(f.PayloadSignature) > 0 {
+		return errors.New("transaction is already signed, submit it with 'fabric tx submit'")
+	}
+
+	peers := c.Peers
+	if len(peers) == 0 {
+		context, err := c.Settings.Config.GetCurrentContext()
+		if err != nil {
+			return err
+		}
+
+		peers = context.Peers
+	}
+
+	if len(peers) == 0 {
+		return errors.New("no peers specified")
+	}
+
+	ctx, err := c.clientContext("")
+	if err != nil {
+		return err
+	}
+
+	signedProposal := &pb.SignedProposal{ProposalBytes: f.Proposal, Signature: f.ProposalSignature}
+
+	for _, peer := range peers {
+		response, err := endorse(ctx, peer, signedProposal)
+		if err != nil {
+			return fmt.Errorf("failed to endorse transaction on peer %s: %s", peer, err)
+		}
+
+		if err := f.addEndorsement(peer, response); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.Settings.Streams.Out, "Endorsed by %s\n", peer)
+	}
+
+	return writeTransactionFile(c.Path, f)
+}
+
+func endorse(ctx context.Client, peer string, signedProposal *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	peerCfg, ok := ctx.EndpointConfig().PeerConfig(peer)
+	if !ok {
+		return nil, errors.New("peer not found in network configuration")
+	}
+
+	target, err := ctx.InfraProvider().CreatePeerFromConfig(&fab.NetworkPeer{PeerConfig: *peerCfg})
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeoutType(fab.PeerResponse))
+	defer cancel()
+
+	resp, err := target.ProcessTransactionProposal(reqCtx, fab.ProcessProposalRequest{SignedProposal: signedProposal})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status < 200 || resp.Status >= 400 {
+		return nil, fmt.Errorf("endorsement failed with status %d: %s", resp.Status, resp.ProposalResponse.GetResponse().GetMessage())
+	}
+
+	return resp.ProposalResponse, nil
+}
+
+// addEndorsement adds or replaces the endorsement of the given peer, which must
+// match the endorsements collected so far
+func (f *transactionFile) addEndorsement(peer string, response *pb.ProposalResponse) error {
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	responses, err := f.proposalResponses()
+	if err != nil {
+		return err
+	}
+
+	endorsements := []*endorsement{}
+	for i, e := range f.Endorsements {
+		if !bytes.Equal(responses[i].ProposalResponse.Payload, response.Payload) {
+			return fmt.Errorf("endorsement of peer %s does not match the endorsement of peer %s", peer, e.Peer)
+		}
+
+		if e.Peer != peer {
+			endorsements = append(endorsements, e)
+		}
+	}
+
+	f.Endorsements = append(endorsements, &endorsement{Peer: peer, Response: data})
+
+	return nil
+}
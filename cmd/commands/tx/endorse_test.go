@@ -0,0 +1,198 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/tx"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
+)
+
+var _ = Describe("TxEndorseCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+
+		args []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+
+		args = os.Args
+	})
+
+	JustBeforeEach(func() {
+		cmd = tx.NewEndorseCommand(settings)
+	})
+
+	AfterEach(func() {
+		os.Args = args
+	})
+
+	It("should create a tx endorse command", func() {
+		Expect(cmd.Name()).To(Equal("endorse"))
+		Expect(cmd.HasSubCommands()).To(BeFalse())
+	})
+
+	It("should provide a help prompt", func() {
+		os.Args = append(os.Args, "--help")
+
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("endorse <transaction-file>"))
+	})
+})
+
+var _ = Describe("TxEndorseImplementation", func() {
+	var (
+		impl     *tx.EndorseCommand
+		err      error
+		out      *bytes.Buffer
+		settings *environment.Settings
+		factory  *mocks.Factory
+		dir      string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+			Config: &environment.Config{
+				Contexts: map[string]*environment.Context{
+					"foo": {
+						User:         "User1",
+						Organization: "Org1",
+						Peers:        []string{"peer1"},
+					},
+				},
+				CurrentContext: "foo",
+			},
+		}
+
+		factory = &mocks.Factory{}
+		factory.SDKReturns(nil, errors.New("sdk error"))
+
+		dir, err = ioutil.TempDir("", "tx")
+		Expect(err).To(BeNil())
+
+		impl = &tx.EndorseCommand{}
+		impl.Settings = settings
+		impl.Factory = factory
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should not be nil", func() {
+		Expect(impl).ShouldNot(BeNil())
+	})
+
+	Describe("Validate", func() {
+		JustBeforeEach(func() {
+			err = impl.Validate()
+		})
+
+		It("should fail when the transaction file is not set", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("transaction file not specified"))
+		})
+
+		Context("when the transaction file is set", func() {
+			BeforeEach(func() {
+				impl.Path = "tx.json"
+			})
+
+			It("should succeed", func() {
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		JustBeforeEach(func() {
+			err = impl.Run()
+		})
+
+		Context("when the transaction file does not exist", func() {
+			BeforeEach(func() {
+				impl.Path = dir + "/missing.json"
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("failed to read transaction file"))
+			})
+		})
+
+		Context("when the proposal is not signed", func() {
+			BeforeEach(func() {
+				impl.Path = writeFile(dir, `{"channel":"mychannel","chaincode":"mycc"}`)
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("proposal is not signed, sign it with 'fabric tx sign'"))
+			})
+		})
+
+		Context("when the proposal is signed", func() {
+			BeforeEach(func() {
+				impl.Path = writeFile(dir, `{"channel":"mychannel","chaincode":"mycc","proposal":"AQ==","proposal_signature":"AQ=="}`)
+			})
+
+			It("should connect to the peers", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("sdk error"))
+			})
+
+			Context("when there are no peers", func() {
+				BeforeEach(func() {
+					settings.Config.Contexts["foo"].Peers = nil
+				})
+
+				It("should fail", func() {
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("no peers specified"))
+				})
+			})
+		})
+
+		Context("when the transaction is signed", func() {
+			BeforeEach(func() {
+				impl.Path = writeFile(dir, `{"channel":"mychannel","chaincode":"mycc","proposal":"AQ==","payload_signature":"AQ=="}`)
+			})
+
+			It("should fail", func() {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("transaction is already signed, submit it with 'fabric tx submit'"))
+			})
+		})
+	})
+})
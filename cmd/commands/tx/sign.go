@@ -0,0 +1,208 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewSignCommand creates a new "fabric tx sign" command
+func NewSignCommand(settings *environment.Settings) *cobra.Command {
+	c := SignCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "sign <transaction-file>",
+		Short: "Sign a transaction file",
+		Long: "Sign the proposal of a transaction file or, once it is endorsed, the transaction itself.\n" +
+			"Both must be signed by the same identity.",
+		Args: c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Path)
+
+	flags := cmd.Flags()
+	flags.StringVar(&c.Identity, "identity", "", "set the signing user (defaults to the user of the current context)")
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// SignCommand implements the tx sign command
+type SignCommand struct {
+	BaseCommand
+
+	Path     string
+	Identity string
+}
+
+// Validate checks the required parameters for run
+func (c *SignCommand) Validate() error {
+	if len(c.Path) == 0 {
+		return errors.New("transaction file not specified")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *SignCommand) Run() error {
+	f, err := readTransactionFile(c.Path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(f.PayloadSignature) > 0:
+		return errors.New("transaction is already signed, submit it with 'fabric tx submit'")
+	case len(f.Proposal) > 0 && len(f.Endorsements) == 0:
+		return errors.New("proposal is already signed, endorse it with 'fabric tx endorse'")
+	}
+
+	ctx, err := c.clientContext(c.Identity)
+	if err != nil {
+		return err
+	}
+
+	if len(f.Proposal) == 0 {
+		err = c.signProposal(ctx, f)
+	} else {
+		err = c.signTransaction(ctx, f)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeTransactionFile(c.Path, f)
+}
+
+func (c *SignCommand) signProposal(ctx context.Client, f *transactionFile) error {
+	txh, err := txn.NewHeader(ctx, f.Channel)
+	if err != nil {
+		return err
+	}
+
+	proposal, err := txn.CreateChaincodeInvokeProposal(txh, fab.ChaincodeInvokeRequest{
+		ChaincodeID:  f.Chaincode,
+		Fcn:          f.Function,
+		Args:         f.Args,
+		TransientMap: f.Transient,
+		IsInit:       f.IsInit,
+	})
+	if err != nil {
+		return err
+	}
+
+	proposalBytes, err := proto.Marshal(proposal.Proposal)
+	if err != nil {
+		return err
+	}
+
+	signature, err := ctx.SigningManager().Sign(proposalBytes, ctx.PrivateKey())
+	if err != nil {
+		return err
+	}
+
+	f.TxID = string(proposal.TxnID)
+	f.Proposal = proposalBytes
+	f.ProposalSignature = signature
+
+	fmt.Fprintf(c.Settings.Streams.Out, "Signed proposal of transaction '%s'\n", f.TxID)
+	fmt.Fprintf(c.Settings.Streams.Out, "Channel: %s\nChaincode: %s\nFunction: %s\nArguments: %d\n",
+		f.Channel, f.Chaincode, f.Function, len(f.Args))
+
+	return nil
+}
+
+func (c *SignCommand) signTransaction(ctx context.Client, f *transactionFile) error {
+	proposal := &pb.Proposal{}
+	if err := proto.Unmarshal(f.Proposal, proposal); err != nil {
+		return err
+	}
+
+	header := &cb.Header{}
+	if err := proto.Unmarshal(proposal.Header, header); err != nil {
+		return err
+	}
+
+	signatureHeader := &cb.SignatureHeader{}
+	if err := proto.Unmarshal(header.SignatureHeader, signatureHeader); err != nil {
+		return err
+	}
+
+	creator, err := ctx.Serialize()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(creator, signatureHeader.Creator) {
+		return errors.New("the transaction must be signed by the identity which signed the proposal")
+	}
+
+	responses, err := f.proposalResponses()
+	if err != nil {
+		return err
+	}
+
+	tx, err := txn.New(fab.TransactionRequest{
+		Proposal:          &fab.TransactionProposal{TxnID: fab.TransactionID(f.TxID), Proposal: proposal},
+		ProposalResponses: responses,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(tx.Transaction)
+	if err != nil {
+		return err
+	}
+
+	payload, err := proto.Marshal(&cb.Payload{Header: header, Data: data})
+	if err != nil {
+		return err
+	}
+
+	signature, err := ctx.SigningManager().Sign(payload, ctx.PrivateKey())
+	if err != nil {
+		return err
+	}
+
+	f.Payload = payload
+	f.PayloadSignature = signature
+
+	fmt.Fprintf(c.Settings.Streams.Out, "Signed transaction '%s' with %d endorsements\n", f.TxID, len(f.Endorsements))
+
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx
+
+import (
+	"errors"
+	"fmt"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// NewSubmitCommand creates a new "fabric tx submit" command
+func NewSubmitCommand(settings *environment.Settings) *cobra.Command {
+	c := SubmitCommand{}
+
+	c.Settings = settings
+
+	cmd := &cobra.Command{
+		Use:   "submit <transaction-file>",
+		Short: "Submit a signed transaction",
+		Long:  "Create the envelope of a signed transaction file and broadcast it to the orderers of its channel",
+		Args:  c.ParseArgs(),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := c.Complete(); err != nil {
+				return err
+			}
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return c.Run()
+		},
+	}
+
+	c.AddArg(&c.Path)
+
+	cmd.SetOutput(c.Settings.Streams.Out)
+
+	return cmd
+}
+
+// SubmitCommand implements the tx submit command
+type SubmitCommand struct {
+	BaseCommand
+
+	Path string
+}
+
+// Validate checks the required parameters for run
+func (c *SubmitCommand) Validate() error {
+	if len(c.Path) == 0 {
+		return errors.New("transaction file not specified")
+	}
+
+	return nil
+}
+
+// Run executes the command
+func (c *SubmitCommand) Run() error {
+	f, err := readTransactionFile(c.Path)
+	if err != nil {
+		return err
+	}
+
+	if len(f.PayloadSignature) == 0 {
+		return errors.New("transaction is not signed, endorse it with 'fabric tx endorse' and sign it with 'fabric tx sign'")
+	}
+
+	ctx, err := c.clientContext("")
+	if err != nil {
+		return err
+	}
+
+	orderers, err := orderers(ctx, f.Channel)
+	if err != nil {
+		return err
+	}
+
+	envelope := &fab.SignedEnvelope{Payload: f.Payload, Signature: f.PayloadSignature}
+
+	for _, orderer := range orderers {
+		if err = broadcast(ctx, orderer, envelope); err == nil {
+			fmt.Fprintf(c.Settings.Streams.Out, "successfully submitted transaction '%s'\n", f.TxID)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("failed to submit transaction '%s': %s", f.TxID, err)
+}
+
+// orderers returns the orderers of the given channel or, if the channel does
+// not list its orderers, all orderers of the network configuration
+func orderers(ctx context.Client, channel string) ([]fab.Orderer, error) {
+	var configs []fab.OrdererConfig
+
+	if channelCfg := ctx.EndpointConfig().ChannelConfig(channel); channelCfg != nil {
+		for _, name := range channelCfg.Orderers {
+			cfg, found, ignore := ctx.EndpointConfig().OrdererConfig(name)
+			if found && !ignore {
+				configs = append(configs, *cfg)
+			}
+		}
+	}
+
+	if len(configs) == 0 {
+		configs = ctx.EndpointConfig().OrderersConfig()
+	}
+
+	if len(configs) == 0 {
+		return nil, errors.New("no orderers found in network configuration")
+	}
+
+	var orderers []fab.Orderer
+	for i := range configs {
+		orderer, err := ctx.InfraProvider().CreateOrdererFromConfig(&configs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		orderers = append(orderers, orderer)
+	}
+
+	return orderers, nil
+}
+
+func broadcast(ctx context.Client, orderer fab.Orderer, envelope *fab.SignedEnvelope) error {
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeoutType(fab.OrdererResponse))
+	defer cancel()
+
+	status, err := orderer.SendBroadcast(reqCtx, envelope)
+	if err != nil {
+		return err
+	}
+
+	if status != nil && *status != cb.Status_SUCCESS {
+		return fmt.Errorf("orderer %s returned status %s", orderer.URL(), status)
+	}
+
+	return nil
+}
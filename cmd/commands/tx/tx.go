@@ -0,0 +1,170 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/common"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+// NewCommand creates a new "fabric tx" command
+func NewCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Manage offline signed transactions",
+		Long: "Manage offline signed transactions with proposal|sign|endorse|submit\n\n" +
+			"A transaction file is created with 'proposal create', signed offline with 'sign', endorsed with\n" +
+			"'endorse', signed offline again with 'sign' and finally sent to the orderer with 'submit'.",
+	}
+
+	cmd.AddCommand(
+		NewProposalCommand(settings),
+		NewSignCommand(settings),
+		NewEndorseCommand(settings),
+		NewSubmitCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// NewProposalCommand creates a new "fabric tx proposal" command
+func NewProposalCommand(settings *environment.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposal",
+		Short: "Manage transaction proposals",
+		Long:  "Manage transaction proposals with create",
+	}
+
+	cmd.AddCommand(
+		NewProposalCreateCommand(settings),
+	)
+
+	cmd.SetOutput(settings.Streams.Out)
+
+	return cmd
+}
+
+// BaseCommand implements common tx command functions
+type BaseCommand struct {
+	common.Command
+
+	Factory fabric.Factory
+}
+
+// Complete initializes all clients needed for Run
+func (c *BaseCommand) Complete() error {
+	var err error
+
+	if c.Factory == nil {
+		c.Factory, err = fabric.NewFactory(c.Settings.Config)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clientContext returns the client context of the given user of the current organization,
+// or of the user of the current context if no user is given
+func (c *BaseCommand) clientContext(user string) (context.Client, error) {
+	current, err := c.Settings.Config.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	if user == "" {
+		user = current.User
+	}
+
+	sdk, err := c.Factory.SDK()
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.Context(fabsdk.WithUser(user), fabsdk.WithOrg(current.Organization))()
+}
+
+// transactionFile holds a transaction while it moves between the online and offline machines
+type transactionFile struct {
+	Channel   string            `json:"channel"`
+	Chaincode string            `json:"chaincode"`
+	Function  string            `json:"function"`
+	Args      [][]byte          `json:"args"`
+	Transient map[string][]byte `json:"transient,omitempty"`
+	IsInit    bool              `json:"is_init,omitempty"`
+
+	TxID              string         `json:"tx_id,omitempty"`
+	Proposal          []byte         `json:"proposal,omitempty"`
+	ProposalSignature []byte         `json:"proposal_signature,omitempty"`
+	Endorsements      []*endorsement `json:"endorsements,omitempty"`
+	Payload           []byte         `json:"payload,omitempty"`
+	PayloadSignature  []byte         `json:"payload_signature,omitempty"`
+}
+
+// endorsement is the marshalled proposal response of an endorser
+type endorsement struct {
+	Peer     string `json:"peer"`
+	Response []byte `json:"response"`
+}
+
+func readTransactionFile(path string) (*transactionFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction file: %s", err)
+	}
+
+	var f transactionFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction file: %s", err)
+	}
+
+	return &f, nil
+}
+
+func writeTransactionFile(path string, f *transactionFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// proposalResponses unmarshals the endorsements of the transaction file
+func (f *transactionFile) proposalResponses() ([]*fab.TransactionProposalResponse, error) {
+	var responses []*fab.TransactionProposalResponse
+
+	for _, e := range f.Endorsements {
+		response := &pb.ProposalResponse{}
+		if err := proto.Unmarshal(e.Response, response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal endorsement of peer %s: %s", e.Peer, err)
+		}
+
+		responses = append(responses, &fab.TransactionProposalResponse{
+			Endorser:         e.Peer,
+			Status:           response.GetResponse().GetStatus(),
+			ProposalResponse: response,
+		})
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tx_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/fabric-cli/cmd/commands/tx"
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+func TestTx(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tx Suite")
+}
+
+var _ = Describe("TxCommand", func() {
+	var (
+		cmd      *cobra.Command
+		settings *environment.Settings
+		out      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+
+		settings = &environment.Settings{
+			Home: environment.Home(os.TempDir()),
+			Streams: environment.Streams{
+				Out: out,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		cmd = tx.NewCommand(settings)
+	})
+
+	It("should create a tx command", func() {
+		Expect(cmd.Name()).To(Equal("tx"))
+		Expect(cmd.HasSubCommands()).To(BeTrue())
+		Expect(cmd.Execute()).Should(Succeed())
+		Expect(fmt.Sprint(out)).To(ContainSubstring("tx [command]"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("proposal"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("sign"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("endorse"))
+		Expect(fmt.Sprint(out)).To(ContainSubstring("submit"))
+	})
+})
+
+// writeFile writes a transaction file for the tests and returns its path
+func writeFile(dir, content string) string {
+	path := dir + "/tx.json"
+	Expect(ioutil.WriteFile(path, []byte(content), 0600)).To(Succeed())
+
+	return path
+}
@@ -50,6 +50,32 @@ func SetContext(name string, context *Context) Action {
 		if len(context.Peers) > 0 {
 			current.Peers = context.Peers
 		}
+
+		if context.Gateway {
+			current.Gateway = context.Gateway
+		}
+
+		if len(context.GatewayEndpoint) > 0 {
+			current.GatewayEndpoint = context.GatewayEndpoint
+		}
+
+		if len(context.GatewayTLSCACert) > 0 {
+			current.GatewayTLSCACert = context.GatewayTLSCACert
+		}
+
+		if len(context.GatewayServerName) > 0 {
+			current.GatewayServerName = context.GatewayServerName
+		}
+	}
+}
+
+// SetContextGateway enables or disables the gateway of the specified context. Unlike the other
+// settings, which SetContext only overrides when set, this allows to switch back to the SDK.
+func SetContextGateway(name string, gateway bool) Action {
+	return func(c *Config) {
+		if context, ok := c.Contexts[name]; ok {
+			context.Gateway = gateway
+		}
 	}
 }
 
@@ -73,6 +73,36 @@ var _ = Describe("Actions", func() {
 				Expect(config.Contexts["foo"].Organization).To(Equal("Org1"))
 			})
 		})
+
+		Context("when the gateway is enabled for an existing context", func() {
+			BeforeEach(func() {
+				config.Contexts["foo"] = &environment.Context{Organization: "Org1"}
+
+				action = environment.SetContext("foo", &environment.Context{
+					Gateway:         true,
+					GatewayEndpoint: "grpcs://peer0.org1.example.com:7051",
+				})
+			})
+
+			It("should keep the other settings", func() {
+				Expect(config.Contexts["foo"].Organization).To(Equal("Org1"))
+				Expect(config.Contexts["foo"].Gateway).To(BeTrue())
+				Expect(config.Contexts["foo"].GatewayEndpoint).To(Equal("grpcs://peer0.org1.example.com:7051"))
+			})
+		})
+	})
+
+	Describe("SetContextGateway", func() {
+		BeforeEach(func() {
+			config.Contexts["foo"] = &environment.Context{Organization: "Org1", Gateway: true}
+
+			action = environment.SetContextGateway("foo", false)
+		})
+
+		It("should disable the gateway", func() {
+			Expect(config.Contexts["foo"].Gateway).To(BeFalse())
+			Expect(config.Contexts["foo"].Organization).To(Equal("Org1"))
+		})
 	})
 
 	Describe("DeleteContext", func() {
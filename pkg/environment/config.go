@@ -32,9 +32,18 @@ Orderers:
 Peers:
 {{- range .Peers}}
 	{{.}}
+{{- end}}
+{{- if .Gateway}}
+Gateway:	{{if .GatewayEndpoint}}{{.GatewayEndpoint}}{{else}}first peer{{end}}
 {{- end}}`
 
-const networkTemplateString = `Path:	{{.ConfigPath}}`
+const networkTemplateString = `Path:	{{.ConfigPath}}
+{{- with .Operations}}
+Operations:
+{{- range $node, $address := .Nodes}}
+	{{$node}}:	{{$address}}
+{{- end}}
+{{- end}}`
 
 // Context contains network interaction parameters
 type Context struct {
@@ -44,6 +53,15 @@ type Context struct {
 	Channel      string   `yaml:",omitempty"`
 	Orderers     []string `yaml:",omitempty"`
 	Peers        []string `yaml:",omitempty"`
+
+	// send transactions through the Gateway service of a Fabric 2.4 peer
+	Gateway bool `yaml:",omitempty"`
+	// gateway peer URL, defaults to the first peer of the context
+	GatewayEndpoint string `yaml:"gateway-endpoint,omitempty"`
+	// path to the TLS CA certificate of the gateway peer
+	GatewayTLSCACert string `yaml:"gateway-tls-ca-cert,omitempty"`
+	// overrides the server name verified against the gateway peer's TLS certificate
+	GatewayServerName string `yaml:"gateway-server-name,omitempty"`
 }
 
 func (c *Context) String() string {
@@ -63,6 +81,20 @@ func (c *Context) String() string {
 type Network struct {
 	// path to fabric go sdk config file
 	ConfigPath string `yaml:"path,omitempty"`
+	// operations service endpoints of the peers and orderers
+	Operations *Operations `yaml:",omitempty"`
+}
+
+// Operations contains the addresses of the operations listeners of the nodes of a network
+// and the client TLS settings used to connect to them
+type Operations struct {
+	// operations listener URL of each node by name, e.g. https://peer0.org1.example.com:9443
+	Nodes map[string]string `yaml:",omitempty"`
+	// path to the CA certificate of the operations listeners' TLS certificates
+	TLSCACert string `yaml:"tls-ca-cert,omitempty"`
+	// paths to the client certificate and key used if the listeners require client authentication
+	TLSClientCert string `yaml:"tls-client-cert,omitempty"`
+	TLSClientKey  string `yaml:"tls-client-key,omitempty"`
 }
 
 func (n *Network) String() string {
@@ -56,6 +56,20 @@ var _ = Describe("Network", func() {
 		It("should return a string", func() {
 			Expect(networkString).NotTo(BeEmpty())
 			Expect(networkString).To(ContainSubstring("Path"))
+			Expect(networkString).NotTo(ContainSubstring("Operations"))
+		})
+
+		Context("when operations are set", func() {
+			BeforeEach(func() {
+				network.Operations = &environment.Operations{
+					Nodes: map[string]string{"peer0": "https://peer0:9443"},
+				}
+			})
+
+			It("should list the operations endpoints", func() {
+				Expect(networkString).To(ContainSubstring("Operations"))
+				Expect(networkString).To(ContainSubstring("peer0:    https://peer0:9443"))
+			})
 		})
 	})
 })
@@ -0,0 +1,300 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	sdkctx "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	corecomm "github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/comm"
+	"google.golang.org/grpc"
+)
+
+// DiscoveryTimeout is the time to wait for a response of the discovery service
+const DiscoveryTimeout = 30 * time.Second
+
+// Discovery defines the queries of the discovery service of a peer
+type Discovery interface {
+	Peers(channelID string) ([]*DiscoveredPeer, error)
+	Config(channelID string) (*discovery.ConfigResult, error)
+	Endorsers(channelID string, chaincode string, collections ...string) (*EndorsementPlan, error)
+}
+
+// DiscoveredPeer is a peer as reported by the discovery service. The ledger height and
+// chaincodes are only known for the peers of a channel.
+type DiscoveredPeer struct {
+	MSPID        string
+	Endpoint     string
+	LedgerHeight uint64
+	Chaincodes   []*gossip.Chaincode
+}
+
+// EndorsementPlan contains the groups of peers of a chaincode and the layouts, each of which
+// is a number of peers per group that together satisfy the endorsement policy
+type EndorsementPlan struct {
+	Chaincode string
+	Groups    map[string][]*DiscoveredPeer
+	Layouts   []map[string]uint32
+}
+
+type discoveryClient struct {
+	client      discovery.DiscoveryClient
+	conn        *comm.GRPCConnection
+	identity    Identity
+	tlsCertHash []byte
+}
+
+// interface implementation checks
+var _ io.Closer = &discoveryClient{}
+
+// NewDiscovery creates a discovery client that sends the requests of the given identity to the
+// discovery service of the given connection. The TLS certificate hash is only needed if the peer
+// requires client authentication.
+func NewDiscovery(conn *grpc.ClientConn, identity Identity, tlsCertHash []byte) Discovery {
+	return &discoveryClient{
+		client:      discovery.NewDiscoveryClient(conn),
+		identity:    identity,
+		tlsCertHash: tlsCertHash,
+	}
+}
+
+// NewPeerDiscovery connects to the discovery service of a peer of the network config with the
+// identity of the given client context. The returned client is an io.Closer which releases the
+// connection to the peer.
+func NewPeerDiscovery(ctx sdkctx.Client, peer string) (Discovery, error) {
+	peerConfig, ok := ctx.EndpointConfig().PeerConfig(peer)
+	if !ok {
+		return nil, fmt.Errorf("peer '%s' not found in the network config", peer)
+	}
+
+	tlsCertHash, err := corecomm.TLSCertHash(ctx.EndpointConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := comm.NewConnection(ctx, peerConfig.URL, comm.OptsFromPeerConfig(peerConfig)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discoveryClient{
+		client:      discovery.NewDiscoveryClient(conn.ClientConn()),
+		conn:        conn,
+		identity:    &clientIdentity{ctx},
+		tlsCertHash: tlsCertHash,
+	}, nil
+}
+
+// Close releases the connection opened by NewPeerDiscovery; a connection given to NewDiscovery
+// is left open
+func (d *discoveryClient) Close() error {
+	if d.conn != nil {
+		d.conn.Close()
+	}
+
+	return nil
+}
+
+// Peers returns the peers of a channel, or the peers known to the target peer if no channel is given
+func (d *discoveryClient) Peers(channelID string) ([]*DiscoveredPeer, error) {
+	query := &discovery.Query{Channel: channelID}
+	if channelID == "" {
+		query.Query = &discovery.Query_LocalPeers{LocalPeers: &discovery.LocalPeerQuery{}}
+	} else {
+		query.Query = &discovery.Query_PeerQuery{PeerQuery: &discovery.PeerMembershipQuery{}}
+	}
+
+	result, err := d.query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []*DiscoveredPeer
+	for mspID, orgPeers := range result.GetMembers().GetPeersByOrg() {
+		for _, p := range orgPeers.GetPeers() {
+			peer, err := discoveredPeer(mspID, p)
+			if err != nil {
+				return nil, err
+			}
+
+			peers = append(peers, peer)
+		}
+	}
+
+	sortPeers(peers)
+
+	return peers, nil
+}
+
+// Config returns the MSPs and orderers of a channel
+func (d *discoveryClient) Config(channelID string) (*discovery.ConfigResult, error) {
+	result, err := d.query(&discovery.Query{
+		Channel: channelID,
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	config := result.GetConfigResult()
+	if config == nil {
+		return nil, errors.New("discovery returned no config")
+	}
+
+	return config, nil
+}
+
+// Endorsers returns the endorsement plan of a chaincode which writes to the given collections
+func (d *discoveryClient) Endorsers(channelID string, chaincode string, collections ...string) (*EndorsementPlan, error) {
+	result, err := d.query(&discovery.Query{
+		Channel: channelID,
+		Query: &discovery.Query_CcQuery{
+			CcQuery: &discovery.ChaincodeQuery{
+				Interests: []*discovery.ChaincodeInterest{
+					{Chaincodes: []*discovery.ChaincodeCall{{Name: chaincode, CollectionNames: collections}}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors := result.GetCcQueryRes().GetContent()
+	if len(descriptors) == 0 {
+		return nil, errors.New("discovery returned no endorsement descriptor")
+	}
+
+	descriptor := descriptors[0]
+
+	plan := &EndorsementPlan{
+		Chaincode: descriptor.Chaincode,
+		Groups:    make(map[string][]*DiscoveredPeer),
+	}
+
+	for group, groupPeers := range descriptor.EndorsersByGroups {
+		var peers []*DiscoveredPeer
+		for _, p := range groupPeers.GetPeers() {
+			peer, err := discoveredPeer("", p)
+			if err != nil {
+				return nil, err
+			}
+
+			peers = append(peers, peer)
+		}
+
+		sortPeers(peers)
+
+		plan.Groups[group] = peers
+	}
+
+	for _, layout := range descriptor.Layouts {
+		plan.Layouts = append(plan.Layouts, layout.QuantitiesByGroup)
+	}
+
+	return plan, nil
+}
+
+// query sends a signed request with a single query and returns its result
+func (d *discoveryClient) query(query *discovery.Query) (*discovery.QueryResult, error) {
+	creator, err := d.identity.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := proto.Marshal(&discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity:    creator,
+			ClientTlsCertHash: d.tlsCertHash,
+		},
+		Queries: []*discovery.Query{query},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := d.identity.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DiscoveryTimeout)
+	defer cancel()
+
+	resp, err := d.client.Discover(ctx, &discovery.SignedRequest{Payload: payload, Signature: signature})
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %s", err)
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, errors.New("discovery returned no results")
+	}
+
+	result := resp.Results[0]
+	if e := result.GetError(); e != nil {
+		return nil, fmt.Errorf("discovery failed: %s", e.Content)
+	}
+
+	return result, nil
+}
+
+// discoveredPeer decodes the gossip messages of a peer. The MSP ID is taken from the peer
+// identity if it is not given.
+func discoveredPeer(mspID string, p *discovery.Peer) (*DiscoveredPeer, error) {
+	if mspID == "" {
+		identity := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(p.Identity, identity); err != nil {
+			return nil, fmt.Errorf("invalid peer identity: %s", err)
+		}
+
+		mspID = identity.Mspid
+	}
+
+	peer := &DiscoveredPeer{MSPID: mspID}
+
+	if p.MembershipInfo != nil {
+		msg := &gossip.GossipMessage{}
+		if err := proto.Unmarshal(p.MembershipInfo.Payload, msg); err != nil {
+			return nil, fmt.Errorf("invalid membership info: %s", err)
+		}
+
+		peer.Endpoint = msg.GetAliveMsg().GetMembership().GetEndpoint()
+	}
+
+	if p.StateInfo != nil {
+		msg := &gossip.GossipMessage{}
+		if err := proto.Unmarshal(p.StateInfo.Payload, msg); err != nil {
+			return nil, fmt.Errorf("invalid state info: %s", err)
+		}
+
+		properties := msg.GetStateInfo().GetProperties()
+		peer.LedgerHeight = properties.GetLedgerHeight()
+		peer.Chaincodes = properties.GetChaincodes()
+	}
+
+	return peer, nil
+}
+
+func sortPeers(peers []*DiscoveredPeer) {
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].MSPID != peers[j].MSPID {
+			return peers[i].MSPID < peers[j].MSPID
+		}
+
+		return peers[i].Endpoint < peers[j].Endpoint
+	})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testDiscovery records the last request and returns the configured result
+type testDiscovery struct {
+	request *discovery.Request
+	result  *discovery.QueryResult
+}
+
+func (d *testDiscovery) Discover(_ context.Context, req *discovery.SignedRequest) (*discovery.Response, error) {
+	d.request = &discovery.Request{}
+	if err := proto.Unmarshal(req.Payload, d.request); err != nil {
+		return nil, err
+	}
+
+	return &discovery.Response{Results: []*discovery.QueryResult{d.result}}, nil
+}
+
+// discoveryPeer creates a discovered peer with the gossip messages of the given endpoint and ledger height
+func discoveryPeer(mspID string, endpoint string, height uint64, chaincodes ...*gossip.Chaincode) *discovery.Peer {
+	alive, _ := proto.Marshal(&gossip.GossipMessage{
+		Content: &gossip.GossipMessage_AliveMsg{
+			AliveMsg: &gossip.AliveMessage{Membership: &gossip.Member{Endpoint: endpoint}},
+		},
+	})
+	state, _ := proto.Marshal(&gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{Properties: &gossip.Properties{LedgerHeight: height, Chaincodes: chaincodes}},
+		},
+	})
+	identity, _ := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID})
+
+	return &discovery.Peer{
+		MembershipInfo: &gossip.Envelope{Payload: alive},
+		StateInfo:      &gossip.Envelope{Payload: state},
+		Identity:       identity,
+	}
+}
+
+var _ = Describe("Discovery", func() {
+	var (
+		server  *grpc.Server
+		conn    *grpc.ClientConn
+		service *testDiscovery
+		client  fabric.Discovery
+	)
+
+	BeforeEach(func() {
+		listener := bufconn.Listen(1024 * 1024)
+
+		service = &testDiscovery{}
+
+		server = grpc.NewServer()
+		discovery.RegisterDiscoveryServer(server, service)
+
+		go server.Serve(listener)
+
+		var err error
+		conn, err = grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+			return listener.Dial()
+		}))
+		Expect(err).To(BeNil())
+
+		client = fabric.NewDiscovery(conn, testIdentity{}, []byte("hash"))
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	Describe("Close", func() {
+		It("should leave a connection given to NewDiscovery open", func() {
+			Expect(client.(io.Closer).Close()).To(Succeed())
+			Expect(conn.GetState()).NotTo(Equal(connectivity.Shutdown))
+		})
+	})
+
+	Describe("Peers", func() {
+		BeforeEach(func() {
+			service.result = &discovery.QueryResult{
+				Result: &discovery.QueryResult_Members{
+					Members: &discovery.PeerMembershipResult{
+						PeersByOrg: map[string]*discovery.Peers{
+							"Org2MSP": {Peers: []*discovery.Peer{discoveryPeer("Org2MSP", "peer0.org2:9051", 4)}},
+							"Org1MSP": {Peers: []*discovery.Peer{
+								discoveryPeer("Org1MSP", "peer1.org1:8051", 5),
+								discoveryPeer("Org1MSP", "peer0.org1:7051", 5, &gossip.Chaincode{Name: "mycc", Version: "1.0"}),
+							}},
+						},
+					},
+				},
+			}
+		})
+
+		It("should return the sorted peers of the channel", func() {
+			peers, err := client.Peers("mychannel")
+			Expect(err).To(BeNil())
+			Expect(peers).To(HaveLen(3))
+			Expect(peers[0].MSPID).To(Equal("Org1MSP"))
+			Expect(peers[0].Endpoint).To(Equal("peer0.org1:7051"))
+			Expect(peers[0].LedgerHeight).To(Equal(uint64(5)))
+			Expect(peers[0].Chaincodes[0].Name).To(Equal("mycc"))
+			Expect(peers[2].Endpoint).To(Equal("peer0.org2:9051"))
+
+			Expect(service.request.Authentication.ClientIdentity).To(Equal([]byte("creator")))
+			Expect(service.request.Authentication.ClientTlsCertHash).To(Equal([]byte("hash")))
+			Expect(service.request.Queries[0].Channel).To(Equal("mychannel"))
+			Expect(service.request.Queries[0].GetPeerQuery()).NotTo(BeNil())
+		})
+
+		It("should query the local peers without a channel", func() {
+			_, err := client.Peers("")
+			Expect(err).To(BeNil())
+			Expect(service.request.Queries[0].GetLocalPeers()).NotTo(BeNil())
+		})
+	})
+
+	Describe("Config", func() {
+		BeforeEach(func() {
+			service.result = &discovery.QueryResult{
+				Result: &discovery.QueryResult_ConfigResult{
+					ConfigResult: &discovery.ConfigResult{
+						Msps: map[string]*msp.FabricMSPConfig{"Org1MSP": {Name: "Org1MSP"}},
+					},
+				},
+			}
+		})
+
+		It("should return the config of the channel", func() {
+			config, err := client.Config("mychannel")
+			Expect(err).To(BeNil())
+			Expect(config.Msps).To(HaveKey("Org1MSP"))
+		})
+	})
+
+	Describe("Endorsers", func() {
+		BeforeEach(func() {
+			service.result = &discovery.QueryResult{
+				Result: &discovery.QueryResult_CcQueryRes{
+					CcQueryRes: &discovery.ChaincodeQueryResult{
+						Content: []*discovery.EndorsementDescriptor{
+							{
+								Chaincode: "mycc",
+								EndorsersByGroups: map[string]*discovery.Peers{
+									"G0": {Peers: []*discovery.Peer{discoveryPeer("Org1MSP", "peer0.org1:7051", 5)}},
+								},
+								Layouts: []*discovery.Layout{
+									{QuantitiesByGroup: map[string]uint32{"G0": 1}},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should return the endorsement plan of the chaincode", func() {
+			plan, err := client.Endorsers("mychannel", "mycc", "coll1")
+			Expect(err).To(BeNil())
+			Expect(plan.Chaincode).To(Equal("mycc"))
+			Expect(plan.Layouts).To(Equal([]map[string]uint32{{"G0": 1}}))
+			Expect(plan.Groups["G0"][0].MSPID).To(Equal("Org1MSP"))
+			Expect(plan.Groups["G0"][0].Endpoint).To(Equal("peer0.org1:7051"))
+
+			call := service.request.Queries[0].GetCcQuery().Interests[0].Chaincodes[0]
+			Expect(call.Name).To(Equal("mycc"))
+			Expect(call.CollectionNames).To(Equal([]string{"coll1"}))
+		})
+
+		Context("when discovery returns an error", func() {
+			BeforeEach(func() {
+				service.result = &discovery.QueryResult{
+					Result: &discovery.QueryResult_Error{Error: &discovery.Error{Content: "no endorsement plan"}},
+				}
+			})
+
+			It("should fail", func() {
+				_, err := client.Endorsers("mychannel", "mycc")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("discovery failed: no endorsement plan"))
+			})
+		})
+	})
+})
@@ -60,6 +60,10 @@ func (f *factory) Channel() (Channel, error) {
 		return nil, err
 	}
 
+	if f.context.Gateway {
+		return f.gatewayChannel(sdk)
+	}
+
 	ctx := sdk.ChannelContext(
 		f.context.Channel,
 		fabsdk.WithUser(f.context.User),
@@ -95,13 +99,17 @@ func (f *factory) Event() (Event, error) {
 }
 
 func (f *factory) Ledger() (Ledger, error) {
+	return f.ChannelLedger(f.context.Channel)
+}
+
+func (f *factory) ChannelLedger(channelID string) (Ledger, error) {
 	sdk, err := f.SDK()
 	if err != nil {
 		return nil, err
 	}
 
 	ctx := sdk.ChannelContext(
-		f.context.Channel,
+		channelID,
 		fabsdk.WithUser(f.context.User),
 		fabsdk.WithOrg(f.context.Organization),
 	)
@@ -7,21 +7,28 @@ SPDX-License-Identifier: Apache-2.0
 package fabric_test
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+
 	"github.com/hyperledger/fabric-cli/pkg/environment"
 	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/mocks"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/factory.go --fake-name Factory . Factory
+//go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/sdk.go --fake-name SDK . SDK
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/channel.go --fake-name Channel . Channel
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/event.go --fake-name Event . Event
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/ledger.go --fake-name Ledger . Ledger
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/resmgmt.go --fake-name ResourceManagement . ResourceManagement
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/msp.go --fake-name MSP . MSP
+//go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/discovery.go --fake-name Discovery . Discovery
+//go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/operations.go --fake-name Operations . Operations
 //go:generate gobin -m -run github.com/maxbrunsfeld/counterfeiter/v6 -o mocks/channelcfg.go --fake-name ChannelCfg github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab.ChannelCfg
 
 func TestFabric(t *testing.T) {
@@ -91,3 +98,109 @@ var _ = Describe("Factory", func() {
 	})
 
 })
+
+const networkConfig = `
+organizations:
+  Org1:
+    mspid: Org1MSP
+    peers:
+      - peer1.org1.example.com
+      - peer0.org1.example.com
+  Org2:
+    mspid: Org2MSP
+    peers:
+      - peer0.org2.example.com
+peers:
+  peer0.org1.example.com:
+    url: grpc://localhost:7051
+  peer1.org1.example.com:
+    url: grpc://localhost:8051
+  peer0.org2.example.com:
+    url: grpc://localhost:9051
+`
+
+var _ = Describe("OrganizationPeers", func() {
+	var (
+		sdk   *mocks.SDK
+		org   string
+		peers []string
+		err   error
+	)
+
+	BeforeEach(func() {
+		backends, err := config.FromRaw([]byte(networkConfig), "yaml")()
+		Expect(err).To(BeNil())
+
+		sdk = &mocks.SDK{}
+		sdk.ConfigReturns(backends[0], nil)
+
+		org = "Org1"
+	})
+
+	JustBeforeEach(func() {
+		peers, err = fabric.OrganizationPeers(sdk, org)
+	})
+
+	It("should return the peers of the organization", func() {
+		Expect(err).To(BeNil())
+		Expect(peers).To(Equal([]string{"peer0.org1.example.com", "peer1.org1.example.com"}))
+	})
+
+	Context("when the organization is not set", func() {
+		BeforeEach(func() {
+			org = ""
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("organization not specified"))
+		})
+	})
+
+	Context("when the organization is unknown", func() {
+		BeforeEach(func() {
+			org = "Org3"
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("organization 'Org3' not found in the network config"))
+		})
+	})
+
+	Context("when the config cannot be loaded", func() {
+		BeforeEach(func() {
+			sdk.ConfigReturns(nil, errors.New("config error"))
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("config error"))
+		})
+	})
+})
+
+var _ = Describe("AllPeers", func() {
+	var sdk *mocks.SDK
+
+	BeforeEach(func() {
+		backends, err := config.FromRaw([]byte(networkConfig), "yaml")()
+		Expect(err).To(BeNil())
+
+		sdk = &mocks.SDK{}
+		sdk.ConfigReturns(backends[0], nil)
+	})
+
+	It("should return all peers", func() {
+		peers, err := fabric.AllPeers(sdk)
+		Expect(err).To(BeNil())
+		Expect(peers).To(Equal([]string{"peer0.org1.example.com", "peer0.org2.example.com", "peer1.org1.example.com"}))
+	})
+
+	It("should fail when the config cannot be loaded", func() {
+		sdk.ConfigReturns(nil, errors.New("config error"))
+
+		_, err := fabric.AllPeers(sdk)
+		Expect(err).NotTo(BeNil())
+	})
+})
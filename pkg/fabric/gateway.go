@@ -0,0 +1,529 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	sdkctx "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/gateway"
+)
+
+// GatewayTimeout is the default time to wait for a response of the gateway, including the commit
+// status of a submitted transaction
+const GatewayTimeout = 2 * time.Minute
+
+// Identity creates and signs the requests sent to a gateway
+type Identity interface {
+	Serialize() ([]byte, error)
+	Sign(msg []byte) ([]byte, error)
+}
+
+// GatewayChannel implements Channel with the Gateway service of a Fabric 2.4 peer, which endorses,
+// orders and checks the commit status of transactions on behalf of the client. The request options
+// of the SDK channel client do not apply to the gateway and are ignored.
+type GatewayChannel struct {
+	client    gateway.Client
+	conn      *grpc.ClientConn
+	sdk       SDK
+	channelID string
+	identity  Identity
+}
+
+// interface implementation checks
+var (
+	_ Channel   = &GatewayChannel{}
+	_ Submitter = &GatewayChannel{}
+	_ io.Closer = &GatewayChannel{}
+)
+
+// NewGatewayChannel creates a channel client that sends the requests of the given identity to the
+// gateway of the given connection
+func NewGatewayChannel(conn *grpc.ClientConn, channelID string, identity Identity) *GatewayChannel {
+	return &GatewayChannel{
+		client:    gateway.NewClient(conn),
+		conn:      conn,
+		channelID: channelID,
+		identity:  identity,
+	}
+}
+
+// gatewayChannel connects to the gateway of the current context with the identity of its user.
+// The SDK is only used to load the network config, i.e. the signing identity of the user and the
+// URL and TLS certificates of the gateway peer; no SDK clients are created. It is kept open until
+// the channel is closed, since the identity signs with the crypto suite of the SDK.
+func (f *factory) gatewayChannel(sdk SDK) (Channel, error) {
+	ctx, err := sdk.Context(fabsdk.WithUser(f.context.User), fabsdk.WithOrg(f.context.Organization))()
+	if err != nil {
+		sdk.Close()
+		return nil, err
+	}
+
+	conn, err := dialGateway(f.context, ctx.EndpointConfig())
+	if err != nil {
+		sdk.Close()
+		return nil, err
+	}
+
+	channel := NewGatewayChannel(conn, f.context.Channel, &clientIdentity{ctx})
+	channel.sdk = sdk
+
+	return channel, nil
+}
+
+// dialGateway connects to the gateway endpoint of a context, which defaults to the first
+// peer of the context as defined by the network config
+func dialGateway(current *environment.Context, config fab.EndpointConfig) (*grpc.ClientConn, error) {
+	url := current.GatewayEndpoint
+	serverName := current.GatewayServerName
+	certPool := x509.NewCertPool()
+	hasCACert := false
+
+	if len(url) == 0 {
+		if len(current.Peers) == 0 {
+			return nil, errors.New("gateway endpoint not specified and the context has no peers")
+		}
+
+		peer, ok := config.PeerConfig(current.Peers[0])
+		if !ok {
+			return nil, fmt.Errorf("peer '%s' not found in the network config", current.Peers[0])
+		}
+
+		url = peer.URL
+
+		if peer.TLSCACert != nil {
+			certPool.AddCert(peer.TLSCACert)
+			hasCACert = true
+		}
+
+		if name, ok := peer.GRPCOptions["ssl-target-name-override"].(string); ok && len(serverName) == 0 {
+			serverName = name
+		}
+	}
+
+	if len(current.GatewayTLSCACert) > 0 {
+		pem, err := ioutil.ReadFile(os.ExpandEnv(current.GatewayTLSCACert))
+		if err != nil {
+			return nil, err
+		}
+
+		if !certPool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in '%s'", current.GatewayTLSCACert)
+		}
+
+		hasCACert = true
+	}
+
+	option := grpc.WithInsecure()
+	if endpoint.AttemptSecured(url, !hasCACert) {
+		tlsConfig := &tls.Config{
+			ServerName:   serverName,
+			Certificates: config.TLSClientCerts(),
+		}
+
+		if hasCACert {
+			tlsConfig.RootCAs = certPool
+		}
+
+		option = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	return grpc.Dial(endpoint.ToAddress(url), option)
+}
+
+// clientIdentity signs with the identity of an SDK client context
+type clientIdentity struct {
+	ctx sdkctx.Client
+}
+
+func (i *clientIdentity) Serialize() ([]byte, error) {
+	return i.ctx.Serialize()
+}
+
+func (i *clientIdentity) Sign(msg []byte) ([]byte, error) {
+	return i.ctx.SigningManager().Sign(msg, i.ctx.PrivateKey())
+}
+
+// gatewayRegistration is the registration of a chaincode event stream
+type gatewayRegistration struct {
+	cancel context.CancelFunc
+}
+
+// Close closes the connection to the gateway and the SDK the identity was loaded with
+func (c *GatewayChannel) Close() error {
+	if c.sdk != nil {
+		c.sdk.Close()
+	}
+
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// Query evaluates a transaction on a peer chosen by the gateway
+func (c *GatewayChannel) Query(request channel.Request, _ ...channel.RequestOption) (channel.Response, error) {
+	proposal, txID, err := c.newProposal(request)
+	if err != nil {
+		return channel.Response{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GatewayTimeout)
+	defer cancel()
+
+	resp, err := c.client.Evaluate(ctx, &gateway.EvaluateRequest{
+		TransactionId:       txID,
+		ChannelId:           c.channelID,
+		ProposedTransaction: proposal,
+	})
+	if err != nil {
+		return channel.Response{}, fmt.Errorf("failed to evaluate transaction: %s", err)
+	}
+
+	return channel.Response{
+		TransactionID:   fab.TransactionID(txID),
+		ChaincodeStatus: resp.GetResult().GetStatus(),
+		Payload:         resp.GetResult().GetPayload(),
+	}, nil
+}
+
+// Execute submits a transaction and waits until it is committed. An error is returned
+// if the committed transaction is invalid.
+func (c *GatewayChannel) Execute(request channel.Request, _ ...channel.RequestOption) (channel.Response, error) {
+	resp, _, err := c.Submit(request, true, 0)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.TxValidationCode != pb.TxValidationCode_VALID {
+		return resp, fmt.Errorf("transaction '%s' is invalid: %s", resp.TransactionID, resp.TxValidationCode)
+	}
+
+	return resp, nil
+}
+
+// InvokeHandler is not supported since the gateway, not the client, endorses and submits transactions
+func (c *GatewayChannel) InvokeHandler(_ invoke.Handler, _ channel.Request, _ ...channel.RequestOption) (channel.Response, error) {
+	return channel.Response{}, errors.New("invoke handlers are not supported by the gateway")
+}
+
+// Submit endorses a transaction and sends it to the orderers. When wait is set, the validation code
+// and block number of the committed transaction are returned. The timeout applies to the whole
+// submission and defaults to GatewayTimeout if it is zero.
+func (c *GatewayChannel) Submit(request channel.Request, wait bool, timeout time.Duration) (channel.Response, uint64, error) {
+	proposal, txID, err := c.newProposal(request)
+	if err != nil {
+		return channel.Response{}, 0, err
+	}
+
+	if timeout == 0 {
+		timeout = GatewayTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	endorsed, err := c.client.Endorse(ctx, &gateway.EndorseRequest{
+		TransactionId:       txID,
+		ChannelId:           c.channelID,
+		ProposedTransaction: proposal,
+	})
+	if err != nil {
+		return channel.Response{}, 0, fmt.Errorf("failed to endorse transaction: %s", err)
+	}
+
+	envelope := endorsed.GetPreparedTransaction()
+	if envelope == nil {
+		return channel.Response{}, 0, errors.New("gateway returned no prepared transaction")
+	}
+
+	result, err := transactionResult(envelope)
+	if err != nil {
+		return channel.Response{}, 0, err
+	}
+
+	envelope.Signature, err = c.identity.Sign(envelope.Payload)
+	if err != nil {
+		return channel.Response{}, 0, err
+	}
+
+	_, err = c.client.Submit(ctx, &gateway.SubmitRequest{
+		TransactionId:       txID,
+		ChannelId:           c.channelID,
+		PreparedTransaction: envelope,
+	})
+	if err != nil {
+		return channel.Response{}, 0, fmt.Errorf("failed to submit transaction: %s", err)
+	}
+
+	resp := channel.Response{
+		TransactionID:   fab.TransactionID(txID),
+		ChaincodeStatus: result.GetStatus(),
+		Payload:         result.GetPayload(),
+	}
+
+	if !wait {
+		return resp, 0, nil
+	}
+
+	status, err := c.commitStatus(ctx, txID)
+	if err != nil {
+		return resp, 0, err
+	}
+
+	resp.TxValidationCode = status.Result
+
+	return resp, status.BlockNumber, nil
+}
+
+// RegisterChaincodeEvent streams the events of a chaincode committed from now on whose name
+// matches the given regular expression
+func (c *GatewayChannel) RegisterChaincodeEvent(chainCodeID string, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+	filter, err := regexp.Compile(eventFilter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid event filter '%s': %s", eventFilter, err)
+	}
+
+	creator, err := c.identity.Serialize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request, err := proto.Marshal(&gateway.ChaincodeEventsRequest{
+		ChannelId:   c.channelID,
+		ChaincodeId: chainCodeID,
+		Identity:    creator,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := c.identity.Sign(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := c.client.ChaincodeEvents(ctx, &gateway.SignedChaincodeEventsRequest{
+		Request:   request,
+		Signature: signature,
+	})
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to register for chaincode events: %s", err)
+	}
+
+	events := make(chan *fab.CCEvent)
+
+	go func() {
+		defer close(events)
+
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			for _, event := range resp.GetEvents() {
+				if !filter.MatchString(event.EventName) {
+					continue
+				}
+
+				select {
+				case events <- &fab.CCEvent{
+					TxID:        event.TxId,
+					ChaincodeID: event.ChaincodeId,
+					EventName:   event.EventName,
+					Payload:     event.Payload,
+					BlockNumber: resp.GetBlockNumber(),
+				}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return &gatewayRegistration{cancel: cancel}, events, nil
+}
+
+// UnregisterChaincodeEvent closes the event stream of a registration
+func (c *GatewayChannel) UnregisterChaincodeEvent(registration fab.Registration) {
+	if reg, ok := registration.(*gatewayRegistration); ok {
+		reg.cancel()
+	}
+}
+
+// commitStatus waits for the validation code of a submitted transaction
+func (c *GatewayChannel) commitStatus(ctx context.Context, txID string) (*gateway.CommitStatusResponse, error) {
+	creator, err := c.identity.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := proto.Marshal(&gateway.CommitStatusRequest{
+		TransactionId: txID,
+		ChannelId:     c.channelID,
+		Identity:      creator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := c.identity.Sign(request)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := c.client.CommitStatus(ctx, &gateway.SignedCommitStatusRequest{
+		Request:   request,
+		Signature: signature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit status of transaction '%s': %s", txID, err)
+	}
+
+	return status, nil
+}
+
+// newProposal creates a signed chaincode proposal and returns it with its transaction ID
+func (c *GatewayChannel) newProposal(request channel.Request) (*pb.SignedProposal, string, error) {
+	creator, err := c.identity.Serialize()
+	if err != nil {
+		return nil, "", err
+	}
+
+	nonce := make([]byte, 24)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, "", err
+	}
+
+	hash := sha256.Sum256(append(append([]byte{}, nonce...), creator...))
+	txID := hex.EncodeToString(hash[:])
+
+	timestamp, err := ptypes.TimestampProto(time.Now().UTC())
+	if err != nil {
+		return nil, "", err
+	}
+
+	ccID := &pb.ChaincodeID{Name: request.ChaincodeID}
+	input := &pb.ChaincodeInput{
+		Args:   append([][]byte{[]byte(request.Fcn)}, request.Args...),
+		IsInit: request.IsInit,
+	}
+
+	extension, err := proto.Marshal(&pb.ChaincodeHeaderExtension{ChaincodeId: ccID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	channelHeader, err := proto.Marshal(&cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: c.channelID,
+		TxId:      txID,
+		Timestamp: timestamp,
+		Extension: extension,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	signatureHeader, err := proto.Marshal(&cb.SignatureHeader{Creator: creator, Nonce: nonce})
+	if err != nil {
+		return nil, "", err
+	}
+
+	header, err := proto.Marshal(&cb.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader})
+	if err != nil {
+		return nil, "", err
+	}
+
+	spec, err := proto.Marshal(&pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeId: ccID, Input: input},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	payload, err := proto.Marshal(&pb.ChaincodeProposalPayload{Input: spec, TransientMap: request.TransientMap})
+	if err != nil {
+		return nil, "", err
+	}
+
+	proposal, err := proto.Marshal(&pb.Proposal{Header: header, Payload: payload})
+	if err != nil {
+		return nil, "", err
+	}
+
+	signature, err := c.identity.Sign(proposal)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &pb.SignedProposal{ProposalBytes: proposal, Signature: signature}, txID, nil
+}
+
+// transactionResult returns the chaincode response endorsed in a prepared transaction
+func transactionResult(envelope *cb.Envelope) (*pb.Response, error) {
+	payload := &cb.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, fmt.Errorf("invalid prepared transaction: %s", err)
+	}
+
+	tx := &pb.Transaction{}
+	if err := proto.Unmarshal(payload.Data, tx); err != nil {
+		return nil, fmt.Errorf("invalid prepared transaction: %s", err)
+	}
+
+	if len(tx.Actions) == 0 {
+		return nil, errors.New("invalid prepared transaction: no actions")
+	}
+
+	actionPayload := &pb.ChaincodeActionPayload{}
+	if err := proto.Unmarshal(tx.Actions[0].Payload, actionPayload); err != nil {
+		return nil, fmt.Errorf("invalid prepared transaction: %s", err)
+	}
+
+	responsePayload := &pb.ProposalResponsePayload{}
+	if err := proto.Unmarshal(actionPayload.GetAction().GetProposalResponsePayload(), responsePayload); err != nil {
+		return nil, fmt.Errorf("invalid prepared transaction: %s", err)
+	}
+
+	action := &pb.ChaincodeAction{}
+	if err := proto.Unmarshal(responsePayload.Extension, action); err != nil {
+		return nil, fmt.Errorf("invalid prepared transaction: %s", err)
+	}
+
+	return action.Response, nil
+}
@@ -0,0 +1,470 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package gateway contains the messages and the gRPC client of the Fabric Gateway
+// service of Fabric 2.4 peers, as defined by gateway/gateway.proto of fabric-protos. They are
+// written by hand since the version of fabric-protos-go used by the SDK predates the service.
+package gateway
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/grpc"
+)
+
+// EndorseRequest is the request to endorse a proposed transaction
+type EndorseRequest struct {
+	TransactionId          string             `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3"`
+	ChannelId              string             `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3"`
+	ProposedTransaction    *pb.SignedProposal `protobuf:"bytes,3,opt,name=proposed_transaction,json=proposedTransaction,proto3"`
+	EndorsingOrganizations []string           `protobuf:"bytes,4,rep,name=endorsing_organizations,json=endorsingOrganizations,proto3"`
+}
+
+func (m *EndorseRequest) Reset()         { *m = EndorseRequest{} }
+func (m *EndorseRequest) String() string { return proto.CompactTextString(m) }
+func (*EndorseRequest) ProtoMessage()    {}
+
+func (m *EndorseRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+
+	return ""
+}
+
+func (m *EndorseRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+
+	return ""
+}
+
+func (m *EndorseRequest) GetProposedTransaction() *pb.SignedProposal {
+	if m != nil {
+		return m.ProposedTransaction
+	}
+
+	return nil
+}
+
+func (m *EndorseRequest) GetEndorsingOrganizations() []string {
+	if m != nil {
+		return m.EndorsingOrganizations
+	}
+
+	return nil
+}
+
+// EndorseResponse contains the endorsed transaction, which must be signed before it is submitted
+type EndorseResponse struct {
+	PreparedTransaction *cb.Envelope `protobuf:"bytes,1,opt,name=prepared_transaction,json=preparedTransaction,proto3"`
+}
+
+func (m *EndorseResponse) Reset()         { *m = EndorseResponse{} }
+func (m *EndorseResponse) String() string { return proto.CompactTextString(m) }
+func (*EndorseResponse) ProtoMessage()    {}
+
+func (m *EndorseResponse) GetPreparedTransaction() *cb.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+
+	return nil
+}
+
+// SubmitRequest is the request to submit a signed transaction to the orderers
+type SubmitRequest struct {
+	TransactionId       string       `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3"`
+	ChannelId           string       `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3"`
+	PreparedTransaction *cb.Envelope `protobuf:"bytes,3,opt,name=prepared_transaction,json=preparedTransaction,proto3"`
+}
+
+func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()    {}
+
+func (m *SubmitRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+
+	return ""
+}
+
+func (m *SubmitRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+
+	return ""
+}
+
+func (m *SubmitRequest) GetPreparedTransaction() *cb.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+
+	return nil
+}
+
+// SubmitResponse is returned once a transaction was accepted by the orderers
+type SubmitResponse struct{}
+
+func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
+func (m *SubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitResponse) ProtoMessage()    {}
+
+// SignedCommitStatusRequest contains a serialized CommitStatusRequest and its signature
+type SignedCommitStatusRequest struct {
+	Request   []byte `protobuf:"bytes,1,opt,name=request,proto3"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3"`
+}
+
+func (m *SignedCommitStatusRequest) Reset()         { *m = SignedCommitStatusRequest{} }
+func (m *SignedCommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedCommitStatusRequest) ProtoMessage()    {}
+
+func (m *SignedCommitStatusRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+
+	return nil
+}
+
+func (m *SignedCommitStatusRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+
+	return nil
+}
+
+// CommitStatusRequest is the request for the commit status of a transaction
+type CommitStatusRequest struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3"`
+	ChannelId     string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3"`
+	Identity      []byte `protobuf:"bytes,3,opt,name=identity,proto3"`
+}
+
+func (m *CommitStatusRequest) Reset()         { *m = CommitStatusRequest{} }
+func (m *CommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusRequest) ProtoMessage()    {}
+
+func (m *CommitStatusRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+
+	return ""
+}
+
+func (m *CommitStatusRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+
+	return ""
+}
+
+func (m *CommitStatusRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+
+	return nil
+}
+
+// CommitStatusResponse contains the validation code and block of a committed transaction
+type CommitStatusResponse struct {
+	Result      pb.TxValidationCode `protobuf:"varint,1,opt,name=result,proto3,enum=protos.TxValidationCode"`
+	BlockNumber uint64              `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3"`
+}
+
+func (m *CommitStatusResponse) Reset()         { *m = CommitStatusResponse{} }
+func (m *CommitStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusResponse) ProtoMessage()    {}
+
+func (m *CommitStatusResponse) GetResult() pb.TxValidationCode {
+	if m != nil {
+		return m.Result
+	}
+
+	return pb.TxValidationCode_VALID
+}
+
+func (m *CommitStatusResponse) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+
+	return 0
+}
+
+// EvaluateRequest is the request to evaluate a proposed transaction without submitting it
+type EvaluateRequest struct {
+	TransactionId       string             `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3"`
+	ChannelId           string             `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3"`
+	ProposedTransaction *pb.SignedProposal `protobuf:"bytes,3,opt,name=proposed_transaction,json=proposedTransaction,proto3"`
+	TargetOrganizations []string           `protobuf:"bytes,4,rep,name=target_organizations,json=targetOrganizations,proto3"`
+}
+
+func (m *EvaluateRequest) Reset()         { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()    {}
+
+func (m *EvaluateRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+
+	return ""
+}
+
+func (m *EvaluateRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+
+	return ""
+}
+
+func (m *EvaluateRequest) GetProposedTransaction() *pb.SignedProposal {
+	if m != nil {
+		return m.ProposedTransaction
+	}
+
+	return nil
+}
+
+func (m *EvaluateRequest) GetTargetOrganizations() []string {
+	if m != nil {
+		return m.TargetOrganizations
+	}
+
+	return nil
+}
+
+// EvaluateResponse contains the chaincode response of an evaluated transaction
+type EvaluateResponse struct {
+	Result *pb.Response `protobuf:"bytes,1,opt,name=result,proto3"`
+}
+
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+
+func (m *EvaluateResponse) GetResult() *pb.Response {
+	if m != nil {
+		return m.Result
+	}
+
+	return nil
+}
+
+// SignedChaincodeEventsRequest contains a serialized ChaincodeEventsRequest and its signature
+type SignedChaincodeEventsRequest struct {
+	Request   []byte `protobuf:"bytes,1,opt,name=request,proto3"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3"`
+}
+
+func (m *SignedChaincodeEventsRequest) Reset()         { *m = SignedChaincodeEventsRequest{} }
+func (m *SignedChaincodeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedChaincodeEventsRequest) ProtoMessage()    {}
+
+func (m *SignedChaincodeEventsRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+
+	return nil
+}
+
+func (m *SignedChaincodeEventsRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+
+	return nil
+}
+
+// ChaincodeEventsRequest is the request for the events of a chaincode
+type ChaincodeEventsRequest struct {
+	ChannelId          string           `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3"`
+	ChaincodeId        string           `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId,proto3"`
+	Identity           []byte           `protobuf:"bytes,3,opt,name=identity,proto3"`
+	StartPosition      *ob.SeekPosition `protobuf:"bytes,4,opt,name=start_position,json=startPosition,proto3"`
+	AfterTransactionId string           `protobuf:"bytes,5,opt,name=after_transaction_id,json=afterTransactionId,proto3"`
+}
+
+func (m *ChaincodeEventsRequest) Reset()         { *m = ChaincodeEventsRequest{} }
+func (m *ChaincodeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsRequest) ProtoMessage()    {}
+
+func (m *ChaincodeEventsRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+
+	return ""
+}
+
+func (m *ChaincodeEventsRequest) GetChaincodeId() string {
+	if m != nil {
+		return m.ChaincodeId
+	}
+
+	return ""
+}
+
+func (m *ChaincodeEventsRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+
+	return nil
+}
+
+func (m *ChaincodeEventsRequest) GetStartPosition() *ob.SeekPosition {
+	if m != nil {
+		return m.StartPosition
+	}
+
+	return nil
+}
+
+func (m *ChaincodeEventsRequest) GetAfterTransactionId() string {
+	if m != nil {
+		return m.AfterTransactionId
+	}
+
+	return ""
+}
+
+// ChaincodeEventsResponse contains the chaincode events of a block
+type ChaincodeEventsResponse struct {
+	Events      []*pb.ChaincodeEvent `protobuf:"bytes,1,rep,name=events,proto3"`
+	BlockNumber uint64               `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3"`
+}
+
+func (m *ChaincodeEventsResponse) Reset()         { *m = ChaincodeEventsResponse{} }
+func (m *ChaincodeEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsResponse) ProtoMessage()    {}
+
+func (m *ChaincodeEventsResponse) GetEvents() []*pb.ChaincodeEvent {
+	if m != nil {
+		return m.Events
+	}
+
+	return nil
+}
+
+func (m *ChaincodeEventsResponse) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+
+	return 0
+}
+
+// Client is the client of the Gateway service
+type Client interface {
+	Endorse(ctx context.Context, in *EndorseRequest, opts ...grpc.CallOption) (*EndorseResponse, error)
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	CommitStatus(ctx context.Context, in *SignedCommitStatusRequest, opts ...grpc.CallOption) (*CommitStatusResponse, error)
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	ChaincodeEvents(ctx context.Context, in *SignedChaincodeEventsRequest, opts ...grpc.CallOption) (ChaincodeEventsClient, error)
+}
+
+// ChaincodeEventsClient receives the responses of a chaincode events request
+type ChaincodeEventsClient interface {
+	Recv() (*ChaincodeEventsResponse, error)
+	grpc.ClientStream
+}
+
+// chaincodeEventsStream describes the server stream of chaincode events
+var chaincodeEventsStream = &grpc.StreamDesc{StreamName: "ChaincodeEvents", ServerStreams: true}
+
+type client struct {
+	cc *grpc.ClientConn
+}
+
+// NewClient returns a client of the Gateway service of the given connection
+func NewClient(cc *grpc.ClientConn) Client {
+	return &client{cc: cc}
+}
+
+func (c *client) Endorse(ctx context.Context, in *EndorseRequest, opts ...grpc.CallOption) (*EndorseResponse, error) {
+	out := new(EndorseResponse)
+	if err := c.cc.Invoke(ctx, "/gateway.Gateway/Endorse", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *client) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	if err := c.cc.Invoke(ctx, "/gateway.Gateway/Submit", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *client) CommitStatus(ctx context.Context, in *SignedCommitStatusRequest, opts ...grpc.CallOption) (*CommitStatusResponse, error) {
+	out := new(CommitStatusResponse)
+	if err := c.cc.Invoke(ctx, "/gateway.Gateway/CommitStatus", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *client) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	if err := c.cc.Invoke(ctx, "/gateway.Gateway/Evaluate", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *client) ChaincodeEvents(ctx context.Context, in *SignedChaincodeEventsRequest, opts ...grpc.CallOption) (ChaincodeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, chaincodeEventsStream, "/gateway.Gateway/ChaincodeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &chaincodeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+type chaincodeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeEventsClient) Recv() (*ChaincodeEventsResponse, error) {
+	m := new(ChaincodeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
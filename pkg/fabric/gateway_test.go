@@ -0,0 +1,373 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-cli/pkg/fabric/gateway"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testIdentity struct{}
+
+func (testIdentity) Serialize() ([]byte, error) {
+	return []byte("creator"), nil
+}
+
+func (testIdentity) Sign(msg []byte) ([]byte, error) {
+	return append([]byte("signed:"), msg[:4]...), nil
+}
+
+// testGateway records the requests of the client and returns the configured responses
+type testGateway struct {
+	evaluate     *gateway.EvaluateRequest
+	endorse      *gateway.EndorseRequest
+	submit       *gateway.SubmitRequest
+	commitStatus *gateway.SignedCommitStatusRequest
+	events       *gateway.SignedChaincodeEventsRequest
+
+	result       *pb.Response
+	validation   pb.TxValidationCode
+	endorseErr   error
+	commitDelay  time.Duration
+	eventBatches []*gateway.ChaincodeEventsResponse
+}
+
+func (g *testGateway) Endorse(_ context.Context, req *gateway.EndorseRequest) (*gateway.EndorseResponse, error) {
+	g.endorse = req
+	if g.endorseErr != nil {
+		return nil, g.endorseErr
+	}
+
+	return &gateway.EndorseResponse{PreparedTransaction: preparedTransaction(g.result)}, nil
+}
+
+func (g *testGateway) Submit(_ context.Context, req *gateway.SubmitRequest) (*gateway.SubmitResponse, error) {
+	g.submit = req
+	return &gateway.SubmitResponse{}, nil
+}
+
+func (g *testGateway) CommitStatus(ctx context.Context, req *gateway.SignedCommitStatusRequest) (*gateway.CommitStatusResponse, error) {
+	g.commitStatus = req
+
+	select {
+	case <-time.After(g.commitDelay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return &gateway.CommitStatusResponse{Result: g.validation, BlockNumber: 7}, nil
+}
+
+func (g *testGateway) Evaluate(_ context.Context, req *gateway.EvaluateRequest) (*gateway.EvaluateResponse, error) {
+	g.evaluate = req
+	return &gateway.EvaluateResponse{Result: g.result}, nil
+}
+
+func (g *testGateway) ChaincodeEvents(req *gateway.SignedChaincodeEventsRequest, stream grpc.ServerStream) error {
+	g.events = req
+	for _, batch := range g.eventBatches {
+		if err := stream.SendMsg(batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// register registers the test gateway as the Gateway service of a server
+func (g *testGateway) register(s *grpc.Server) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "gateway.Gateway",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("Endorse", func(ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := &gateway.EndorseRequest{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				return g.Endorse(ctx, in)
+			}),
+			unaryMethod("Submit", func(ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := &gateway.SubmitRequest{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				return g.Submit(ctx, in)
+			}),
+			unaryMethod("CommitStatus", func(ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := &gateway.SignedCommitStatusRequest{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				return g.CommitStatus(ctx, in)
+			}),
+			unaryMethod("Evaluate", func(ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				in := &gateway.EvaluateRequest{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				return g.Evaluate(ctx, in)
+			}),
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "ChaincodeEvents",
+				ServerStreams: true,
+				Handler: func(_ interface{}, stream grpc.ServerStream) error {
+					in := &gateway.SignedChaincodeEventsRequest{}
+					if err := stream.RecvMsg(in); err != nil {
+						return err
+					}
+
+					return g.ChaincodeEvents(in, stream)
+				},
+			},
+		},
+	}, g)
+}
+
+// unaryMethod describes a unary method of the Gateway service, the test server has no interceptors
+func unaryMethod(name string, handle func(context.Context, func(interface{}) error) (interface{}, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			return handle(ctx, dec)
+		},
+	}
+}
+
+// preparedTransaction wraps a chaincode response into a transaction envelope like the gateway
+func preparedTransaction(response *pb.Response) *cb.Envelope {
+	action, _ := proto.Marshal(&pb.ChaincodeAction{Response: response})
+	responsePayload, _ := proto.Marshal(&pb.ProposalResponsePayload{Extension: action})
+	actionPayload, _ := proto.Marshal(&pb.ChaincodeActionPayload{
+		Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: responsePayload},
+	})
+	tx, _ := proto.Marshal(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: actionPayload}}})
+	payload, _ := proto.Marshal(&cb.Payload{Data: tx})
+
+	return &cb.Envelope{Payload: payload}
+}
+
+var _ = Describe("GatewayChannel", func() {
+	var (
+		server  *grpc.Server
+		conn    *grpc.ClientConn
+		service *testGateway
+		client  *fabric.GatewayChannel
+		request channel.Request
+	)
+
+	BeforeEach(func() {
+		listener := bufconn.Listen(1024 * 1024)
+
+		service = &testGateway{
+			result:     &pb.Response{Status: 200, Payload: []byte("100")},
+			validation: pb.TxValidationCode_VALID,
+		}
+
+		server = grpc.NewServer()
+		service.register(server)
+
+		go server.Serve(listener)
+
+		var err error
+		conn, err = grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+			return listener.Dial()
+		}))
+		Expect(err).To(BeNil())
+
+		client = fabric.NewGatewayChannel(conn, "mychannel", testIdentity{})
+
+		request = channel.Request{
+			ChaincodeID: "mycc",
+			Fcn:         "query",
+			Args:        [][]byte{[]byte("a")},
+		}
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	Describe("Query", func() {
+		It("should evaluate a signed proposal", func() {
+			resp, err := client.Query(request)
+			Expect(err).To(BeNil())
+			Expect(resp.Payload).To(Equal([]byte("100")))
+			Expect(resp.ChaincodeStatus).To(Equal(int32(200)))
+
+			Expect(service.evaluate.ChannelId).To(Equal("mychannel"))
+			Expect(service.evaluate.TransactionId).To(Equal(string(resp.TransactionID)))
+
+			proposal := &pb.Proposal{}
+			Expect(proto.Unmarshal(service.evaluate.ProposedTransaction.ProposalBytes, proposal)).To(Succeed())
+			Expect(service.evaluate.ProposedTransaction.Signature).To(Equal(
+				append([]byte("signed:"), service.evaluate.ProposedTransaction.ProposalBytes[:4]...)))
+
+			payload := &pb.ChaincodeProposalPayload{}
+			Expect(proto.Unmarshal(proposal.Payload, payload)).To(Succeed())
+			spec := &pb.ChaincodeInvocationSpec{}
+			Expect(proto.Unmarshal(payload.Input, spec)).To(Succeed())
+			Expect(spec.ChaincodeSpec.ChaincodeId.Name).To(Equal("mycc"))
+			Expect(spec.ChaincodeSpec.Input.Args).To(Equal([][]byte{[]byte("query"), []byte("a")}))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("should endorse, submit and wait for the transaction", func() {
+			resp, err := client.Execute(request)
+			Expect(err).To(BeNil())
+			Expect(resp.Payload).To(Equal([]byte("100")))
+			Expect(resp.TxValidationCode).To(Equal(pb.TxValidationCode_VALID))
+
+			Expect(service.submit.TransactionId).To(Equal(service.endorse.TransactionId))
+			Expect(service.submit.PreparedTransaction.Signature).To(Equal(
+				append([]byte("signed:"), service.submit.PreparedTransaction.Payload[:4]...)))
+
+			status := &gateway.CommitStatusRequest{}
+			Expect(proto.Unmarshal(service.commitStatus.Request, status)).To(Succeed())
+			Expect(status.TransactionId).To(Equal(string(resp.TransactionID)))
+			Expect(status.Identity).To(Equal([]byte("creator")))
+		})
+
+		Context("when the transaction is invalid", func() {
+			BeforeEach(func() {
+				service.validation = pb.TxValidationCode_MVCC_READ_CONFLICT
+			})
+
+			It("should fail", func() {
+				resp, err := client.Execute(request)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("transaction '" + string(resp.TransactionID) + "' is invalid: MVCC_READ_CONFLICT"))
+			})
+		})
+
+		Context("when the endorsement fails", func() {
+			BeforeEach(func() {
+				service.endorseErr = errors.New("endorsement error")
+			})
+
+			It("should fail", func() {
+				_, err := client.Execute(request)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("endorsement error"))
+				Expect(service.submit).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Submit", func() {
+		It("should return the block number when waiting", func() {
+			_, blockNumber, err := client.Submit(request, true, 0)
+			Expect(err).To(BeNil())
+			Expect(blockNumber).To(Equal(uint64(7)))
+		})
+
+		It("should not wait for the commit status otherwise", func() {
+			_, _, err := client.Submit(request, false, 0)
+			Expect(err).To(BeNil())
+			Expect(service.submit).NotTo(BeNil())
+			Expect(service.commitStatus).To(BeNil())
+		})
+
+		Context("when the commit takes longer than the timeout", func() {
+			BeforeEach(func() {
+				service.commitDelay = time.Second
+			})
+
+			It("should fail", func() {
+				_, _, err := client.Submit(request, true, 10*time.Millisecond)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("DeadlineExceeded"))
+			})
+		})
+	})
+
+	Describe("InvokeHandler", func() {
+		It("should not be supported", func() {
+			_, err := client.InvokeHandler(nil, request)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("RegisterChaincodeEvent", func() {
+		BeforeEach(func() {
+			service.eventBatches = []*gateway.ChaincodeEventsResponse{
+				{
+					BlockNumber: 3,
+					Events: []*pb.ChaincodeEvent{
+						{ChaincodeId: "mycc", TxId: "tx1", EventName: "created", Payload: []byte("a")},
+						{ChaincodeId: "mycc", TxId: "tx1", EventName: "deleted", Payload: []byte("b")},
+					},
+				},
+				{
+					BlockNumber: 4,
+					Events: []*pb.ChaincodeEvent{
+						{ChaincodeId: "mycc", TxId: "tx2", EventName: "created", Payload: []byte("c")},
+					},
+				},
+			}
+		})
+
+		It("should stream the matching events", func() {
+			registration, events, err := client.RegisterChaincodeEvent("mycc", "^created$")
+			Expect(err).To(BeNil())
+			defer client.UnregisterChaincodeEvent(registration)
+
+			var received []*fab.CCEvent
+			for event := range events {
+				received = append(received, event)
+			}
+
+			Expect(received).To(Equal([]*fab.CCEvent{
+				{TxID: "tx1", ChaincodeID: "mycc", EventName: "created", Payload: []byte("a"), BlockNumber: 3},
+				{TxID: "tx2", ChaincodeID: "mycc", EventName: "created", Payload: []byte("c"), BlockNumber: 4},
+			}))
+
+			req := &gateway.ChaincodeEventsRequest{}
+			Expect(proto.Unmarshal(service.events.Request, req)).To(Succeed())
+			Expect(req.ChannelId).To(Equal("mychannel"))
+			Expect(req.ChaincodeId).To(Equal("mycc"))
+		})
+
+		It("should fail with an invalid filter", func() {
+			_, _, err := client.RegisterChaincodeEvent("mycc", "(")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Close", func() {
+		It("should close the connection", func() {
+			Expect(client.Close()).To(Succeed())
+
+			_, err := client.Query(request)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
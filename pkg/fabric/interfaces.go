@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package fabric
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
@@ -28,6 +30,7 @@ type Factory interface {
 	Channel() (Channel, error)
 	Event() (Event, error)
 	Ledger() (Ledger, error)
+	ChannelLedger(channelID string) (Ledger, error)
 	ResourceManagement() (ResourceManagement, error)
 	MSP() (MSP, error)
 }
@@ -50,6 +53,13 @@ type Channel interface {
 	UnregisterChaincodeEvent(registration fab.Registration)
 }
 
+// Submitter is implemented by channels which select the endorsing peers themselves, such as the
+// gateway. Requests of these channels can't be targeted at peers or use invoke handlers; instead
+// the transaction is submitted as a whole.
+type Submitter interface {
+	Submit(request channel.Request, wait bool, timeout time.Duration) (channel.Response, uint64, error)
+}
+
 // Event defines the methods implemented by SDK event client
 type Event interface {
 	RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error)
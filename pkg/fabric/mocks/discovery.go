@@ -0,0 +1,279 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+)
+
+type Discovery struct {
+	ConfigStub        func(string) (*discovery.ConfigResult, error)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct {
+		arg1 string
+	}
+	configReturns struct {
+		result1 *discovery.ConfigResult
+		result2 error
+	}
+	configReturnsOnCall map[int]struct {
+		result1 *discovery.ConfigResult
+		result2 error
+	}
+	EndorsersStub        func(string, string, ...string) (*fabric.EndorsementPlan, error)
+	endorsersMutex       sync.RWMutex
+	endorsersArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	endorsersReturns struct {
+		result1 *fabric.EndorsementPlan
+		result2 error
+	}
+	endorsersReturnsOnCall map[int]struct {
+		result1 *fabric.EndorsementPlan
+		result2 error
+	}
+	PeersStub        func(string) ([]*fabric.DiscoveredPeer, error)
+	peersMutex       sync.RWMutex
+	peersArgsForCall []struct {
+		arg1 string
+	}
+	peersReturns struct {
+		result1 []*fabric.DiscoveredPeer
+		result2 error
+	}
+	peersReturnsOnCall map[int]struct {
+		result1 []*fabric.DiscoveredPeer
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Discovery) Config(arg1 string) (*discovery.ConfigResult, error) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ConfigStub
+	fakeReturns := fake.configReturns
+	fake.recordInvocation("Config", []interface{}{arg1})
+	fake.configMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Discovery) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *Discovery) ConfigCalls(stub func(string) (*discovery.ConfigResult, error)) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = stub
+}
+
+func (fake *Discovery) ConfigArgsForCall(i int) string {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	argsForCall := fake.configArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Discovery) ConfigReturns(result1 *discovery.ConfigResult, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 *discovery.ConfigResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Discovery) ConfigReturnsOnCall(i int, result1 *discovery.ConfigResult, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 *discovery.ConfigResult
+			result2 error
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 *discovery.ConfigResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Discovery) Endorsers(arg1 string, arg2 string, arg3 ...string) (*fabric.EndorsementPlan, error) {
+	fake.endorsersMutex.Lock()
+	ret, specificReturn := fake.endorsersReturnsOnCall[len(fake.endorsersArgsForCall)]
+	fake.endorsersArgsForCall = append(fake.endorsersArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	stub := fake.EndorsersStub
+	fakeReturns := fake.endorsersReturns
+	fake.recordInvocation("Endorsers", []interface{}{arg1, arg2, arg3})
+	fake.endorsersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Discovery) EndorsersCallCount() int {
+	fake.endorsersMutex.RLock()
+	defer fake.endorsersMutex.RUnlock()
+	return len(fake.endorsersArgsForCall)
+}
+
+func (fake *Discovery) EndorsersCalls(stub func(string, string, ...string) (*fabric.EndorsementPlan, error)) {
+	fake.endorsersMutex.Lock()
+	defer fake.endorsersMutex.Unlock()
+	fake.EndorsersStub = stub
+}
+
+func (fake *Discovery) EndorsersArgsForCall(i int) (string, string, []string) {
+	fake.endorsersMutex.RLock()
+	defer fake.endorsersMutex.RUnlock()
+	argsForCall := fake.endorsersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Discovery) EndorsersReturns(result1 *fabric.EndorsementPlan, result2 error) {
+	fake.endorsersMutex.Lock()
+	defer fake.endorsersMutex.Unlock()
+	fake.EndorsersStub = nil
+	fake.endorsersReturns = struct {
+		result1 *fabric.EndorsementPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Discovery) EndorsersReturnsOnCall(i int, result1 *fabric.EndorsementPlan, result2 error) {
+	fake.endorsersMutex.Lock()
+	defer fake.endorsersMutex.Unlock()
+	fake.EndorsersStub = nil
+	if fake.endorsersReturnsOnCall == nil {
+		fake.endorsersReturnsOnCall = make(map[int]struct {
+			result1 *fabric.EndorsementPlan
+			result2 error
+		})
+	}
+	fake.endorsersReturnsOnCall[i] = struct {
+		result1 *fabric.EndorsementPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Discovery) Peers(arg1 string) ([]*fabric.DiscoveredPeer, error) {
+	fake.peersMutex.Lock()
+	ret, specificReturn := fake.peersReturnsOnCall[len(fake.peersArgsForCall)]
+	fake.peersArgsForCall = append(fake.peersArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PeersStub
+	fakeReturns := fake.peersReturns
+	fake.recordInvocation("Peers", []interface{}{arg1})
+	fake.peersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Discovery) PeersCallCount() int {
+	fake.peersMutex.RLock()
+	defer fake.peersMutex.RUnlock()
+	return len(fake.peersArgsForCall)
+}
+
+func (fake *Discovery) PeersCalls(stub func(string) ([]*fabric.DiscoveredPeer, error)) {
+	fake.peersMutex.Lock()
+	defer fake.peersMutex.Unlock()
+	fake.PeersStub = stub
+}
+
+func (fake *Discovery) PeersArgsForCall(i int) string {
+	fake.peersMutex.RLock()
+	defer fake.peersMutex.RUnlock()
+	argsForCall := fake.peersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Discovery) PeersReturns(result1 []*fabric.DiscoveredPeer, result2 error) {
+	fake.peersMutex.Lock()
+	defer fake.peersMutex.Unlock()
+	fake.PeersStub = nil
+	fake.peersReturns = struct {
+		result1 []*fabric.DiscoveredPeer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Discovery) PeersReturnsOnCall(i int, result1 []*fabric.DiscoveredPeer, result2 error) {
+	fake.peersMutex.Lock()
+	defer fake.peersMutex.Unlock()
+	fake.PeersStub = nil
+	if fake.peersReturnsOnCall == nil {
+		fake.peersReturnsOnCall = make(map[int]struct {
+			result1 []*fabric.DiscoveredPeer
+			result2 error
+		})
+	}
+	fake.peersReturnsOnCall[i] = struct {
+		result1 []*fabric.DiscoveredPeer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Discovery) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	fake.endorsersMutex.RLock()
+	defer fake.endorsersMutex.RUnlock()
+	fake.peersMutex.RLock()
+	defer fake.peersMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Discovery) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ fabric.Discovery = new(Discovery)
@@ -20,6 +20,19 @@ type Factory struct {
 		result1 fabric.Channel
 		result2 error
 	}
+	ChannelLedgerStub        func(string) (fabric.Ledger, error)
+	channelLedgerMutex       sync.RWMutex
+	channelLedgerArgsForCall []struct {
+		arg1 string
+	}
+	channelLedgerReturns struct {
+		result1 fabric.Ledger
+		result2 error
+	}
+	channelLedgerReturnsOnCall map[int]struct {
+		result1 fabric.Ledger
+		result2 error
+	}
 	EventStub        func() (fabric.Event, error)
 	eventMutex       sync.RWMutex
 	eventArgsForCall []struct {
@@ -89,15 +102,16 @@ func (fake *Factory) Channel() (fabric.Channel, error) {
 	ret, specificReturn := fake.channelReturnsOnCall[len(fake.channelArgsForCall)]
 	fake.channelArgsForCall = append(fake.channelArgsForCall, struct {
 	}{})
+	stub := fake.ChannelStub
+	fakeReturns := fake.channelReturns
 	fake.recordInvocation("Channel", []interface{}{})
 	fake.channelMutex.Unlock()
-	if fake.ChannelStub != nil {
-		return fake.ChannelStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.channelReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -139,20 +153,85 @@ func (fake *Factory) ChannelReturnsOnCall(i int, result1 fabric.Channel, result2
 	}{result1, result2}
 }
 
+func (fake *Factory) ChannelLedger(arg1 string) (fabric.Ledger, error) {
+	fake.channelLedgerMutex.Lock()
+	ret, specificReturn := fake.channelLedgerReturnsOnCall[len(fake.channelLedgerArgsForCall)]
+	fake.channelLedgerArgsForCall = append(fake.channelLedgerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChannelLedgerStub
+	fakeReturns := fake.channelLedgerReturns
+	fake.recordInvocation("ChannelLedger", []interface{}{arg1})
+	fake.channelLedgerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Factory) ChannelLedgerCallCount() int {
+	fake.channelLedgerMutex.RLock()
+	defer fake.channelLedgerMutex.RUnlock()
+	return len(fake.channelLedgerArgsForCall)
+}
+
+func (fake *Factory) ChannelLedgerCalls(stub func(string) (fabric.Ledger, error)) {
+	fake.channelLedgerMutex.Lock()
+	defer fake.channelLedgerMutex.Unlock()
+	fake.ChannelLedgerStub = stub
+}
+
+func (fake *Factory) ChannelLedgerArgsForCall(i int) string {
+	fake.channelLedgerMutex.RLock()
+	defer fake.channelLedgerMutex.RUnlock()
+	argsForCall := fake.channelLedgerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Factory) ChannelLedgerReturns(result1 fabric.Ledger, result2 error) {
+	fake.channelLedgerMutex.Lock()
+	defer fake.channelLedgerMutex.Unlock()
+	fake.ChannelLedgerStub = nil
+	fake.channelLedgerReturns = struct {
+		result1 fabric.Ledger
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Factory) ChannelLedgerReturnsOnCall(i int, result1 fabric.Ledger, result2 error) {
+	fake.channelLedgerMutex.Lock()
+	defer fake.channelLedgerMutex.Unlock()
+	fake.ChannelLedgerStub = nil
+	if fake.channelLedgerReturnsOnCall == nil {
+		fake.channelLedgerReturnsOnCall = make(map[int]struct {
+			result1 fabric.Ledger
+			result2 error
+		})
+	}
+	fake.channelLedgerReturnsOnCall[i] = struct {
+		result1 fabric.Ledger
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Factory) Event() (fabric.Event, error) {
 	fake.eventMutex.Lock()
 	ret, specificReturn := fake.eventReturnsOnCall[len(fake.eventArgsForCall)]
 	fake.eventArgsForCall = append(fake.eventArgsForCall, struct {
 	}{})
+	stub := fake.EventStub
+	fakeReturns := fake.eventReturns
 	fake.recordInvocation("Event", []interface{}{})
 	fake.eventMutex.Unlock()
-	if fake.EventStub != nil {
-		return fake.EventStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.eventReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -199,15 +278,16 @@ func (fake *Factory) Ledger() (fabric.Ledger, error) {
 	ret, specificReturn := fake.ledgerReturnsOnCall[len(fake.ledgerArgsForCall)]
 	fake.ledgerArgsForCall = append(fake.ledgerArgsForCall, struct {
 	}{})
+	stub := fake.LedgerStub
+	fakeReturns := fake.ledgerReturns
 	fake.recordInvocation("Ledger", []interface{}{})
 	fake.ledgerMutex.Unlock()
-	if fake.LedgerStub != nil {
-		return fake.LedgerStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.ledgerReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -254,15 +334,16 @@ func (fake *Factory) MSP() (fabric.MSP, error) {
 	ret, specificReturn := fake.mSPReturnsOnCall[len(fake.mSPArgsForCall)]
 	fake.mSPArgsForCall = append(fake.mSPArgsForCall, struct {
 	}{})
+	stub := fake.MSPStub
+	fakeReturns := fake.mSPReturns
 	fake.recordInvocation("MSP", []interface{}{})
 	fake.mSPMutex.Unlock()
-	if fake.MSPStub != nil {
-		return fake.MSPStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.mSPReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -309,15 +390,16 @@ func (fake *Factory) ResourceManagement() (fabric.ResourceManagement, error) {
 	ret, specificReturn := fake.resourceManagementReturnsOnCall[len(fake.resourceManagementArgsForCall)]
 	fake.resourceManagementArgsForCall = append(fake.resourceManagementArgsForCall, struct {
 	}{})
+	stub := fake.ResourceManagementStub
+	fakeReturns := fake.resourceManagementReturns
 	fake.recordInvocation("ResourceManagement", []interface{}{})
 	fake.resourceManagementMutex.Unlock()
-	if fake.ResourceManagementStub != nil {
-		return fake.ResourceManagementStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.resourceManagementReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -364,15 +446,16 @@ func (fake *Factory) SDK() (fabric.SDK, error) {
 	ret, specificReturn := fake.sDKReturnsOnCall[len(fake.sDKArgsForCall)]
 	fake.sDKArgsForCall = append(fake.sDKArgsForCall, struct {
 	}{})
+	stub := fake.SDKStub
+	fakeReturns := fake.sDKReturns
 	fake.recordInvocation("SDK", []interface{}{})
 	fake.sDKMutex.Unlock()
-	if fake.SDKStub != nil {
-		return fake.SDKStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.sDKReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -419,6 +502,8 @@ func (fake *Factory) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.channelMutex.RLock()
 	defer fake.channelMutex.RUnlock()
+	fake.channelLedgerMutex.RLock()
+	defer fake.channelLedgerMutex.RUnlock()
 	fake.eventMutex.RLock()
 	defer fake.eventMutex.RUnlock()
 	fake.ledgerMutex.RLock()
@@ -0,0 +1,391 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+)
+
+type Operations struct {
+	HealthStub        func() (*fabric.HealthStatus, error)
+	healthMutex       sync.RWMutex
+	healthArgsForCall []struct {
+	}
+	healthReturns struct {
+		result1 *fabric.HealthStatus
+		result2 error
+	}
+	healthReturnsOnCall map[int]struct {
+		result1 *fabric.HealthStatus
+		result2 error
+	}
+	LogSpecStub        func() (string, error)
+	logSpecMutex       sync.RWMutex
+	logSpecArgsForCall []struct {
+	}
+	logSpecReturns struct {
+		result1 string
+		result2 error
+	}
+	logSpecReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	MetricsStub        func() (string, error)
+	metricsMutex       sync.RWMutex
+	metricsArgsForCall []struct {
+	}
+	metricsReturns struct {
+		result1 string
+		result2 error
+	}
+	metricsReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SetLogSpecStub        func(string) error
+	setLogSpecMutex       sync.RWMutex
+	setLogSpecArgsForCall []struct {
+		arg1 string
+	}
+	setLogSpecReturns struct {
+		result1 error
+	}
+	setLogSpecReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VersionStub        func() (*fabric.VersionInfo, error)
+	versionMutex       sync.RWMutex
+	versionArgsForCall []struct {
+	}
+	versionReturns struct {
+		result1 *fabric.VersionInfo
+		result2 error
+	}
+	versionReturnsOnCall map[int]struct {
+		result1 *fabric.VersionInfo
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Operations) Health() (*fabric.HealthStatus, error) {
+	fake.healthMutex.Lock()
+	ret, specificReturn := fake.healthReturnsOnCall[len(fake.healthArgsForCall)]
+	fake.healthArgsForCall = append(fake.healthArgsForCall, struct {
+	}{})
+	stub := fake.HealthStub
+	fakeReturns := fake.healthReturns
+	fake.recordInvocation("Health", []interface{}{})
+	fake.healthMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Operations) HealthCallCount() int {
+	fake.healthMutex.RLock()
+	defer fake.healthMutex.RUnlock()
+	return len(fake.healthArgsForCall)
+}
+
+func (fake *Operations) HealthCalls(stub func() (*fabric.HealthStatus, error)) {
+	fake.healthMutex.Lock()
+	defer fake.healthMutex.Unlock()
+	fake.HealthStub = stub
+}
+
+func (fake *Operations) HealthReturns(result1 *fabric.HealthStatus, result2 error) {
+	fake.healthMutex.Lock()
+	defer fake.healthMutex.Unlock()
+	fake.HealthStub = nil
+	fake.healthReturns = struct {
+		result1 *fabric.HealthStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) HealthReturnsOnCall(i int, result1 *fabric.HealthStatus, result2 error) {
+	fake.healthMutex.Lock()
+	defer fake.healthMutex.Unlock()
+	fake.HealthStub = nil
+	if fake.healthReturnsOnCall == nil {
+		fake.healthReturnsOnCall = make(map[int]struct {
+			result1 *fabric.HealthStatus
+			result2 error
+		})
+	}
+	fake.healthReturnsOnCall[i] = struct {
+		result1 *fabric.HealthStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) LogSpec() (string, error) {
+	fake.logSpecMutex.Lock()
+	ret, specificReturn := fake.logSpecReturnsOnCall[len(fake.logSpecArgsForCall)]
+	fake.logSpecArgsForCall = append(fake.logSpecArgsForCall, struct {
+	}{})
+	stub := fake.LogSpecStub
+	fakeReturns := fake.logSpecReturns
+	fake.recordInvocation("LogSpec", []interface{}{})
+	fake.logSpecMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Operations) LogSpecCallCount() int {
+	fake.logSpecMutex.RLock()
+	defer fake.logSpecMutex.RUnlock()
+	return len(fake.logSpecArgsForCall)
+}
+
+func (fake *Operations) LogSpecCalls(stub func() (string, error)) {
+	fake.logSpecMutex.Lock()
+	defer fake.logSpecMutex.Unlock()
+	fake.LogSpecStub = stub
+}
+
+func (fake *Operations) LogSpecReturns(result1 string, result2 error) {
+	fake.logSpecMutex.Lock()
+	defer fake.logSpecMutex.Unlock()
+	fake.LogSpecStub = nil
+	fake.logSpecReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) LogSpecReturnsOnCall(i int, result1 string, result2 error) {
+	fake.logSpecMutex.Lock()
+	defer fake.logSpecMutex.Unlock()
+	fake.LogSpecStub = nil
+	if fake.logSpecReturnsOnCall == nil {
+		fake.logSpecReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.logSpecReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) Metrics() (string, error) {
+	fake.metricsMutex.Lock()
+	ret, specificReturn := fake.metricsReturnsOnCall[len(fake.metricsArgsForCall)]
+	fake.metricsArgsForCall = append(fake.metricsArgsForCall, struct {
+	}{})
+	stub := fake.MetricsStub
+	fakeReturns := fake.metricsReturns
+	fake.recordInvocation("Metrics", []interface{}{})
+	fake.metricsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Operations) MetricsCallCount() int {
+	fake.metricsMutex.RLock()
+	defer fake.metricsMutex.RUnlock()
+	return len(fake.metricsArgsForCall)
+}
+
+func (fake *Operations) MetricsCalls(stub func() (string, error)) {
+	fake.metricsMutex.Lock()
+	defer fake.metricsMutex.Unlock()
+	fake.MetricsStub = stub
+}
+
+func (fake *Operations) MetricsReturns(result1 string, result2 error) {
+	fake.metricsMutex.Lock()
+	defer fake.metricsMutex.Unlock()
+	fake.MetricsStub = nil
+	fake.metricsReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) MetricsReturnsOnCall(i int, result1 string, result2 error) {
+	fake.metricsMutex.Lock()
+	defer fake.metricsMutex.Unlock()
+	fake.MetricsStub = nil
+	if fake.metricsReturnsOnCall == nil {
+		fake.metricsReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.metricsReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) SetLogSpec(arg1 string) error {
+	fake.setLogSpecMutex.Lock()
+	ret, specificReturn := fake.setLogSpecReturnsOnCall[len(fake.setLogSpecArgsForCall)]
+	fake.setLogSpecArgsForCall = append(fake.setLogSpecArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SetLogSpecStub
+	fakeReturns := fake.setLogSpecReturns
+	fake.recordInvocation("SetLogSpec", []interface{}{arg1})
+	fake.setLogSpecMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Operations) SetLogSpecCallCount() int {
+	fake.setLogSpecMutex.RLock()
+	defer fake.setLogSpecMutex.RUnlock()
+	return len(fake.setLogSpecArgsForCall)
+}
+
+func (fake *Operations) SetLogSpecCalls(stub func(string) error) {
+	fake.setLogSpecMutex.Lock()
+	defer fake.setLogSpecMutex.Unlock()
+	fake.SetLogSpecStub = stub
+}
+
+func (fake *Operations) SetLogSpecArgsForCall(i int) string {
+	fake.setLogSpecMutex.RLock()
+	defer fake.setLogSpecMutex.RUnlock()
+	argsForCall := fake.setLogSpecArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Operations) SetLogSpecReturns(result1 error) {
+	fake.setLogSpecMutex.Lock()
+	defer fake.setLogSpecMutex.Unlock()
+	fake.SetLogSpecStub = nil
+	fake.setLogSpecReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Operations) SetLogSpecReturnsOnCall(i int, result1 error) {
+	fake.setLogSpecMutex.Lock()
+	defer fake.setLogSpecMutex.Unlock()
+	fake.SetLogSpecStub = nil
+	if fake.setLogSpecReturnsOnCall == nil {
+		fake.setLogSpecReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setLogSpecReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Operations) Version() (*fabric.VersionInfo, error) {
+	fake.versionMutex.Lock()
+	ret, specificReturn := fake.versionReturnsOnCall[len(fake.versionArgsForCall)]
+	fake.versionArgsForCall = append(fake.versionArgsForCall, struct {
+	}{})
+	stub := fake.VersionStub
+	fakeReturns := fake.versionReturns
+	fake.recordInvocation("Version", []interface{}{})
+	fake.versionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Operations) VersionCallCount() int {
+	fake.versionMutex.RLock()
+	defer fake.versionMutex.RUnlock()
+	return len(fake.versionArgsForCall)
+}
+
+func (fake *Operations) VersionCalls(stub func() (*fabric.VersionInfo, error)) {
+	fake.versionMutex.Lock()
+	defer fake.versionMutex.Unlock()
+	fake.VersionStub = stub
+}
+
+func (fake *Operations) VersionReturns(result1 *fabric.VersionInfo, result2 error) {
+	fake.versionMutex.Lock()
+	defer fake.versionMutex.Unlock()
+	fake.VersionStub = nil
+	fake.versionReturns = struct {
+		result1 *fabric.VersionInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) VersionReturnsOnCall(i int, result1 *fabric.VersionInfo, result2 error) {
+	fake.versionMutex.Lock()
+	defer fake.versionMutex.Unlock()
+	fake.VersionStub = nil
+	if fake.versionReturnsOnCall == nil {
+		fake.versionReturnsOnCall = make(map[int]struct {
+			result1 *fabric.VersionInfo
+			result2 error
+		})
+	}
+	fake.versionReturnsOnCall[i] = struct {
+		result1 *fabric.VersionInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Operations) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.healthMutex.RLock()
+	defer fake.healthMutex.RUnlock()
+	fake.logSpecMutex.RLock()
+	defer fake.logSpecMutex.RUnlock()
+	fake.metricsMutex.RLock()
+	defer fake.metricsMutex.RUnlock()
+	fake.setLogSpecMutex.RLock()
+	defer fake.setLogSpecMutex.RUnlock()
+	fake.versionMutex.RLock()
+	defer fake.versionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Operations) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ fabric.Operations = new(Operations)
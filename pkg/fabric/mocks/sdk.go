@@ -0,0 +1,330 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+)
+
+type SDK struct {
+	ChannelContextStub        func(string, ...fabsdk.ContextOption) context.ChannelProvider
+	channelContextMutex       sync.RWMutex
+	channelContextArgsForCall []struct {
+		arg1 string
+		arg2 []fabsdk.ContextOption
+	}
+	channelContextReturns struct {
+		result1 context.ChannelProvider
+	}
+	channelContextReturnsOnCall map[int]struct {
+		result1 context.ChannelProvider
+	}
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	CloseContextStub        func(fab.ClientContext)
+	closeContextMutex       sync.RWMutex
+	closeContextArgsForCall []struct {
+		arg1 fab.ClientContext
+	}
+	ConfigStub        func() (core.ConfigBackend, error)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct {
+	}
+	configReturns struct {
+		result1 core.ConfigBackend
+		result2 error
+	}
+	configReturnsOnCall map[int]struct {
+		result1 core.ConfigBackend
+		result2 error
+	}
+	ContextStub        func(...fabsdk.ContextOption) context.ClientProvider
+	contextMutex       sync.RWMutex
+	contextArgsForCall []struct {
+		arg1 []fabsdk.ContextOption
+	}
+	contextReturns struct {
+		result1 context.ClientProvider
+	}
+	contextReturnsOnCall map[int]struct {
+		result1 context.ClientProvider
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SDK) ChannelContext(arg1 string, arg2 ...fabsdk.ContextOption) context.ChannelProvider {
+	fake.channelContextMutex.Lock()
+	ret, specificReturn := fake.channelContextReturnsOnCall[len(fake.channelContextArgsForCall)]
+	fake.channelContextArgsForCall = append(fake.channelContextArgsForCall, struct {
+		arg1 string
+		arg2 []fabsdk.ContextOption
+	}{arg1, arg2})
+	stub := fake.ChannelContextStub
+	fakeReturns := fake.channelContextReturns
+	fake.recordInvocation("ChannelContext", []interface{}{arg1, arg2})
+	fake.channelContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SDK) ChannelContextCallCount() int {
+	fake.channelContextMutex.RLock()
+	defer fake.channelContextMutex.RUnlock()
+	return len(fake.channelContextArgsForCall)
+}
+
+func (fake *SDK) ChannelContextCalls(stub func(string, ...fabsdk.ContextOption) context.ChannelProvider) {
+	fake.channelContextMutex.Lock()
+	defer fake.channelContextMutex.Unlock()
+	fake.ChannelContextStub = stub
+}
+
+func (fake *SDK) ChannelContextArgsForCall(i int) (string, []fabsdk.ContextOption) {
+	fake.channelContextMutex.RLock()
+	defer fake.channelContextMutex.RUnlock()
+	argsForCall := fake.channelContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SDK) ChannelContextReturns(result1 context.ChannelProvider) {
+	fake.channelContextMutex.Lock()
+	defer fake.channelContextMutex.Unlock()
+	fake.ChannelContextStub = nil
+	fake.channelContextReturns = struct {
+		result1 context.ChannelProvider
+	}{result1}
+}
+
+func (fake *SDK) ChannelContextReturnsOnCall(i int, result1 context.ChannelProvider) {
+	fake.channelContextMutex.Lock()
+	defer fake.channelContextMutex.Unlock()
+	fake.ChannelContextStub = nil
+	if fake.channelContextReturnsOnCall == nil {
+		fake.channelContextReturnsOnCall = make(map[int]struct {
+			result1 context.ChannelProvider
+		})
+	}
+	fake.channelContextReturnsOnCall[i] = struct {
+		result1 context.ChannelProvider
+	}{result1}
+}
+
+func (fake *SDK) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	stub := fake.CloseStub
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		fake.CloseStub()
+	}
+}
+
+func (fake *SDK) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *SDK) CloseCalls(stub func()) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *SDK) CloseContext(arg1 fab.ClientContext) {
+	fake.closeContextMutex.Lock()
+	fake.closeContextArgsForCall = append(fake.closeContextArgsForCall, struct {
+		arg1 fab.ClientContext
+	}{arg1})
+	stub := fake.CloseContextStub
+	fake.recordInvocation("CloseContext", []interface{}{arg1})
+	fake.closeContextMutex.Unlock()
+	if stub != nil {
+		fake.CloseContextStub(arg1)
+	}
+}
+
+func (fake *SDK) CloseContextCallCount() int {
+	fake.closeContextMutex.RLock()
+	defer fake.closeContextMutex.RUnlock()
+	return len(fake.closeContextArgsForCall)
+}
+
+func (fake *SDK) CloseContextCalls(stub func(fab.ClientContext)) {
+	fake.closeContextMutex.Lock()
+	defer fake.closeContextMutex.Unlock()
+	fake.CloseContextStub = stub
+}
+
+func (fake *SDK) CloseContextArgsForCall(i int) fab.ClientContext {
+	fake.closeContextMutex.RLock()
+	defer fake.closeContextMutex.RUnlock()
+	argsForCall := fake.closeContextArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SDK) Config() (core.ConfigBackend, error) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct {
+	}{})
+	stub := fake.ConfigStub
+	fakeReturns := fake.configReturns
+	fake.recordInvocation("Config", []interface{}{})
+	fake.configMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SDK) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *SDK) ConfigCalls(stub func() (core.ConfigBackend, error)) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = stub
+}
+
+func (fake *SDK) ConfigReturns(result1 core.ConfigBackend, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 core.ConfigBackend
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SDK) ConfigReturnsOnCall(i int, result1 core.ConfigBackend, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 core.ConfigBackend
+			result2 error
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 core.ConfigBackend
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SDK) Context(arg1 ...fabsdk.ContextOption) context.ClientProvider {
+	fake.contextMutex.Lock()
+	ret, specificReturn := fake.contextReturnsOnCall[len(fake.contextArgsForCall)]
+	fake.contextArgsForCall = append(fake.contextArgsForCall, struct {
+		arg1 []fabsdk.ContextOption
+	}{arg1})
+	stub := fake.ContextStub
+	fakeReturns := fake.contextReturns
+	fake.recordInvocation("Context", []interface{}{arg1})
+	fake.contextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SDK) ContextCallCount() int {
+	fake.contextMutex.RLock()
+	defer fake.contextMutex.RUnlock()
+	return len(fake.contextArgsForCall)
+}
+
+func (fake *SDK) ContextCalls(stub func(...fabsdk.ContextOption) context.ClientProvider) {
+	fake.contextMutex.Lock()
+	defer fake.contextMutex.Unlock()
+	fake.ContextStub = stub
+}
+
+func (fake *SDK) ContextArgsForCall(i int) []fabsdk.ContextOption {
+	fake.contextMutex.RLock()
+	defer fake.contextMutex.RUnlock()
+	argsForCall := fake.contextArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SDK) ContextReturns(result1 context.ClientProvider) {
+	fake.contextMutex.Lock()
+	defer fake.contextMutex.Unlock()
+	fake.ContextStub = nil
+	fake.contextReturns = struct {
+		result1 context.ClientProvider
+	}{result1}
+}
+
+func (fake *SDK) ContextReturnsOnCall(i int, result1 context.ClientProvider) {
+	fake.contextMutex.Lock()
+	defer fake.contextMutex.Unlock()
+	fake.ContextStub = nil
+	if fake.contextReturnsOnCall == nil {
+		fake.contextReturnsOnCall = make(map[int]struct {
+			result1 context.ClientProvider
+		})
+	}
+	fake.contextReturnsOnCall[i] = struct {
+		result1 context.ClientProvider
+	}{result1}
+}
+
+func (fake *SDK) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.channelContextMutex.RLock()
+	defer fake.channelContextMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	fake.closeContextMutex.RLock()
+	defer fake.closeContextMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	fake.contextMutex.RLock()
+	defer fake.contextMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SDK) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ fabric.SDK = new(SDK)
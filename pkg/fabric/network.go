@@ -0,0 +1,73 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fabImpl "github.com/hyperledger/fabric-sdk-go/pkg/fab"
+)
+
+// OrganizationPeers returns the names of the peers that the network config assigns
+// to the given organization. It never falls back to all peers of the network, which
+// would send admin queries to the peers of other organizations; use AllPeers for those.
+func OrganizationPeers(sdk SDK, org string) ([]string, error) {
+	if org == "" {
+		return nil, errors.New("organization not specified")
+	}
+
+	networkConfig, err := networkConfig(sdk)
+	if err != nil {
+		return nil, err
+	}
+
+	orgConfig, ok := networkConfig.Organizations[strings.ToLower(org)]
+	if !ok {
+		return nil, fmt.Errorf("organization '%s' not found in the network config", org)
+	}
+
+	peers := append([]string{}, orgConfig.Peers...)
+
+	sort.Strings(peers)
+
+	return peers, nil
+}
+
+// AllPeers returns the names of all peers of the network config
+func AllPeers(sdk SDK) ([]string, error) {
+	networkConfig, err := networkConfig(sdk)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]string, 0, len(networkConfig.Peers))
+	for name := range networkConfig.Peers {
+		peers = append(peers, name)
+	}
+
+	sort.Strings(peers)
+
+	return peers, nil
+}
+
+func networkConfig(sdk SDK) (*fab.NetworkConfig, error) {
+	backend, err := sdk.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	endpointConfig, err := fabImpl.ConfigFromBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpointConfig.NetworkConfig(), nil
+}
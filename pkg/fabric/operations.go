@@ -0,0 +1,249 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+)
+
+// OperationsTimeout is the time to wait for a response of an operations listener
+const OperationsTimeout = 30 * time.Second
+
+// Operations defines the requests to the operations listener of a peer or orderer
+type Operations interface {
+	Health() (*HealthStatus, error)
+	Metrics() (string, error)
+	LogSpec() (string, error)
+	SetLogSpec(spec string) error
+	Version() (*VersionInfo, error)
+}
+
+// HealthStatus is the health of a node as reported by /healthz
+type HealthStatus struct {
+	Status       string        `json:"status"`
+	Time         time.Time     `json:"time"`
+	FailedChecks []FailedCheck `json:"failed_checks,omitempty"`
+}
+
+// FailedCheck is a component of a node which failed its health check
+type FailedCheck struct {
+	Component string `json:"component"`
+	Reason    string `json:"reason"`
+}
+
+// VersionInfo is the build of a node as reported by /version
+type VersionInfo struct {
+	Version   string `json:"Version"`
+	CommitSHA string `json:"CommitSHA"`
+}
+
+type operationsClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewOperations creates a client for the operations listener of a node of the given
+// operations config
+func NewOperations(config *environment.Operations, node string) (Operations, error) {
+	if config == nil || len(config.Nodes) == 0 {
+		return nil, errors.New("no operations endpoints are set for the network")
+	}
+
+	address, ok := config.Nodes[node]
+	if !ok {
+		return nil, fmt.Errorf("operations endpoint of node '%s' not found", node)
+	}
+
+	secured := len(config.TLSCACert) > 0 || len(config.TLSClientCert) > 0
+	if !strings.Contains(address, "://") {
+		if secured {
+			address = "https://" + address
+		} else {
+			address = "http://" + address
+		}
+	}
+
+	transport := &http.Transport{}
+
+	if secured {
+		tlsConfig := &tls.Config{}
+
+		if len(config.TLSCACert) > 0 {
+			pem, err := ioutil.ReadFile(os.ExpandEnv(config.TLSCACert))
+			if err != nil {
+				return nil, err
+			}
+
+			certPool := x509.NewCertPool()
+			if !certPool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in '%s'", config.TLSCACert)
+			}
+
+			tlsConfig.RootCAs = certPool
+		}
+
+		if len(config.TLSClientCert) > 0 {
+			cert, err := tls.LoadX509KeyPair(os.ExpandEnv(config.TLSClientCert), os.ExpandEnv(config.TLSClientKey))
+			if err != nil {
+				return nil, err
+			}
+
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &operationsClient{
+		client:  &http.Client{Transport: transport, Timeout: OperationsTimeout},
+		baseURL: strings.TrimSuffix(address, "/"),
+	}, nil
+}
+
+// Health returns the health of the node. An unavailable node is reported by its status rather
+// than an error.
+func (o *operationsClient) Health() (*HealthStatus, error) {
+	resp, err := o.do(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, responseError(http.MethodGet, "/healthz", resp)
+	}
+
+	status := &HealthStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, fmt.Errorf("invalid health status: %s", err)
+	}
+
+	return status, nil
+}
+
+// Metrics returns the metrics of the node in the Prometheus text format
+func (o *operationsClient) Metrics() (string, error) {
+	body, err := o.get("/metrics")
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// LogSpec returns the logging specification of the node
+func (o *operationsClient) LogSpec() (string, error) {
+	body, err := o.get("/logspec")
+	if err != nil {
+		return "", err
+	}
+
+	var logSpec struct {
+		Spec string `json:"spec"`
+	}
+	if err := json.Unmarshal(body, &logSpec); err != nil {
+		return "", fmt.Errorf("invalid log spec: %s", err)
+	}
+
+	return logSpec.Spec, nil
+}
+
+// SetLogSpec changes the logging specification of the node, e.g. "info:gossip=debug"
+func (o *operationsClient) SetLogSpec(spec string) error {
+	data, err := json.Marshal(map[string]string{"spec": spec})
+	if err != nil {
+		return err
+	}
+
+	resp, err := o.do(http.MethodPut, "/logspec", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(http.MethodPut, "/logspec", resp)
+	}
+
+	return nil
+}
+
+// Version returns the build of the node
+func (o *operationsClient) Version() (*VersionInfo, error) {
+	body, err := o.get("/version")
+	if err != nil {
+		return nil, err
+	}
+
+	version := &VersionInfo{}
+	if err := json.Unmarshal(body, version); err != nil {
+		return nil, fmt.Errorf("invalid version: %s", err)
+	}
+
+	return version, nil
+}
+
+func (o *operationsClient) get(path string) ([]byte, error) {
+	resp, err := o.do(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(http.MethodGet, path, resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (o *operationsClient) do(method string, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, o.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return o.client.Do(req)
+}
+
+// responseError returns the error message of an operations response, which is either a JSON
+// object with an error field or plain text
+func responseError(method string, path string, resp *http.Response) error {
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	var errResp struct {
+		Error string `json:"error"`
+	}
+
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &errResp) == nil && len(errResp.Error) > 0 {
+		message = errResp.Error
+	}
+
+	if len(message) == 0 {
+		message = resp.Status
+	}
+
+	return fmt.Errorf("%s %s failed: %s", method, path, message)
+}
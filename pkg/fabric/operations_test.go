@@ -0,0 +1,167 @@
+/*
+Copyright State Street Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabric_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hyperledger/fabric-cli/pkg/environment"
+	"github.com/hyperledger/fabric-cli/pkg/fabric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Operations", func() {
+	var (
+		server     *httptest.Server
+		config     *environment.Operations
+		operations fabric.Operations
+		logSpec    string
+		healthy    bool
+		err        error
+	)
+
+	BeforeEach(func() {
+		logSpec = "info"
+		healthy = true
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+			if healthy {
+				fmt.Fprint(w, `{"status":"OK","time":"2020-10-01T10:00:00Z"}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"status":"Service Unavailable","time":"2020-10-01T10:00:00Z",`+
+				`"failed_checks":[{"component":"docker","reason":"failed to ping docker"}]}`)
+		})
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, "ledger_blockchain_height{channel=\"mychannel\"} 12\n")
+		})
+		mux.HandleFunc("/logspec", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				body, _ := ioutil.ReadAll(r.Body)
+				if string(body) == `{"spec":"invalid="}` {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error":"invalid logging specification"}`)
+					return
+				}
+
+				logSpec = string(body)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			fmt.Fprintf(w, `{"spec":"%s"}`, logSpec)
+		})
+		mux.HandleFunc("/version", func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"Version":"2.2.1","CommitSHA":"344fda6"}`)
+		})
+
+		server = httptest.NewServer(mux)
+
+		config = &environment.Operations{
+			Nodes: map[string]string{"peer0": server.URL},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		operations, err = fabric.NewOperations(config, "peer0")
+	})
+
+	It("should create a client", func() {
+		Expect(err).To(BeNil())
+		Expect(operations).NotTo(BeNil())
+	})
+
+	Context("when the node has no operations endpoint", func() {
+		BeforeEach(func() {
+			config.Nodes = map[string]string{"peer1": server.URL}
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("operations endpoint of node 'peer0' not found"))
+		})
+	})
+
+	Context("when the CA certificate does not exist", func() {
+		BeforeEach(func() {
+			config.TLSCACert = "/does/not/exist.pem"
+		})
+
+		It("should fail", func() {
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Health", func() {
+		It("should return the status", func() {
+			status, err := operations.Health()
+			Expect(err).To(BeNil())
+			Expect(status.Status).To(Equal("OK"))
+			Expect(status.FailedChecks).To(BeEmpty())
+		})
+
+		It("should return the failed checks of an unavailable node", func() {
+			healthy = false
+
+			status, err := operations.Health()
+			Expect(err).To(BeNil())
+			Expect(status.Status).To(Equal("Service Unavailable"))
+			Expect(status.FailedChecks).To(Equal([]fabric.FailedCheck{
+				{Component: "docker", Reason: "failed to ping docker"},
+			}))
+		})
+	})
+
+	Describe("Metrics", func() {
+		It("should return the metrics", func() {
+			metrics, err := operations.Metrics()
+			Expect(err).To(BeNil())
+			Expect(metrics).To(ContainSubstring("ledger_blockchain_height"))
+		})
+	})
+
+	Describe("LogSpec", func() {
+		It("should return the log spec", func() {
+			spec, err := operations.LogSpec()
+			Expect(err).To(BeNil())
+			Expect(spec).To(Equal("info"))
+		})
+	})
+
+	Describe("SetLogSpec", func() {
+		It("should put the log spec", func() {
+			Expect(operations.SetLogSpec("info:gossip=debug")).To(Succeed())
+			Expect(logSpec).To(Equal(`{"spec":"info:gossip=debug"}`))
+		})
+
+		It("should return the error of the node", func() {
+			err := operations.SetLogSpec("invalid=")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("PUT /logspec failed: invalid logging specification"))
+		})
+	})
+
+	Describe("Version", func() {
+		It("should return the version", func() {
+			version, err := operations.Version()
+			Expect(err).To(BeNil())
+			Expect(version).To(Equal(&fabric.VersionInfo{Version: "2.2.1", CommitSHA: "344fda6"}))
+		})
+	})
+})